@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"math"
@@ -13,8 +14,12 @@ import (
 	"github.com/rivo/tview"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
+// sessionMetadataKey is the gRPC metadata key used to pick a server session
+const sessionMetadataKey = "session-id"
+
 // Client represents the motorcycle simulator client
 type Client struct {
 	app            *tview.Application
@@ -23,6 +28,7 @@ type Client struct {
 	conn           *grpc.ClientConn
 	ctx            context.Context
 	cancel         context.CancelFunc
+	sessionID      string
 	throttlePos    float64
 	clutchPos      float64
 	currentGear    int
@@ -33,10 +39,11 @@ type Client struct {
 	dataUpdateTime time.Time
 	statusMsg      string
 	statusMsgTime  time.Time
+	mapCommand     *tview.InputField
 }
 
-// NewClient creates a new client
-func NewClient(serverAddr string) (*Client, error) {
+// NewClient creates a new client. An empty sessionID asks the server for a new session.
+func NewClient(serverAddr, sessionID string) (*Client, error) {
 	// Create a context with cancel
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -60,6 +67,7 @@ func NewClient(serverAddr string) (*Client, error) {
 		conn:           conn,
 		ctx:            ctx,
 		cancel:         cancel,
+		sessionID:      sessionID,
 		throttlePos:    0.0,
 		clutchPos:      1.0, // Start with clutch disengaged
 		currentGear:    0,   // Start in neutral
@@ -142,9 +150,13 @@ func (c *Client) setupUI() {
 [yellow]Map Editing:[-]
 [green]M[-]: Switch to map view
 [green]E[-]: Edit selected map cell
+[green]:[-]: Import or export a map file
 `)
 	c.layouts.InfoPanel.AddItem(controlsPanel, 0, 1, false)
 
+	// Map file commands on the map view
+	c.setupMapCommands()
+
 	// Status bar for messages
 	statusBar := tview.NewTextView().
 		SetDynamicColors(true).
@@ -221,8 +233,8 @@ func (c *Client) setupUI() {
 				} else {
 					// Default status bar
 					currentPage := c.layouts.GetCurrentPage()
-					statusBar.SetText(fmt.Sprintf("[yellow]Ninja 650 ECU Simulator[white] | [blue]%s View[white] | Press [green]Q[white] to quit",
-						title(currentPage)))
+					statusBar.SetText(fmt.Sprintf("[yellow]Ninja 650 ECU Simulator[white] | [blue]%s View[white] | Session [green]%s[white] | Press [green]Q[white] to quit",
+						title(currentPage), c.sessionID))
 				}
 			})
 		}
@@ -236,6 +248,11 @@ func (c *Client) setupUI() {
 // setupInputHandling configures keyboard input handlers
 func (c *Client) setupInputHandling() {
 	c.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Leave every key to the map command line while it is being typed in
+		if c.editingMapCommand() {
+			return event
+		}
+
 		// Handle global keys
 		switch event.Key() {
 		case tcell.KeyEscape, tcell.KeyCtrlC:
@@ -266,6 +283,10 @@ func (c *Client) setupInputHandling() {
 			case 'i':
 				c.layouts.SwitchToPage("info")
 				return nil
+			case ':':
+				// Type a map import or export command
+				c.focusMapCommand()
+				return nil
 			case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 				// Set throttle position (0-9 = 0-90%)
 				if event.Modifiers()&tcell.ModShift != 0 && event.Rune() == '1' {
@@ -417,15 +438,32 @@ func (c *Client) showStatusMessage(message string, color string) {
 	c.statusMsgTime = time.Now()
 }
 
+// sessionContext returns the client context tagged with the current session ID
+func (c *Client) sessionContext() context.Context {
+	if c.sessionID == "" {
+		return c.ctx
+	}
+	return metadata.AppendToOutgoingContext(c.ctx, sessionMetadataKey, c.sessionID)
+}
+
 // Start begins the connection and UI
 func (c *Client) Start() error {
 	// Start stream
-	stream, err := c.ecuClient.StreamEngine(c.ctx)
+	stream, err := c.ecuClient.StreamEngine(c.sessionContext())
 	if err != nil {
 		return err
 	}
 	c.stream = stream
 
+	// Remember which session the server attached us to
+	header, err := stream.Header()
+	if err != nil {
+		return err
+	}
+	if ids := header.Get(sessionMetadataKey); len(ids) > 0 {
+		c.sessionID = ids[0]
+	}
+
 	// Start receive goroutine
 	go c.receiveEngineData()
 
@@ -481,8 +519,12 @@ func title(s string) string {
 }
 
 func main() {
+	serverAddr := flag.String("addr", "localhost:50051", "simulator server address")
+	sessionID := flag.String("session", "", "join an existing session by ID")
+	flag.Parse()
+
 	// Create client
-	client, err := NewClient("localhost:50051")
+	client, err := NewClient(*serverAddr, *sessionID)
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
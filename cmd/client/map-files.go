@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/StevenD2002/ninja650sim/internal/ecu"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// mapCommandHelp explains the map file commands on the map view
+const mapCommandHelp = `
+[yellow]Map Files:[-]
+Press [green]:[-] to type a command, [green]Enter[-] to run it, [green]Esc[-] to cancel
+
+[green]export <map> <file>[-]: Save a map to a .csv or .json file
+[green]import <map> <file>[-]: Load a map from a .csv or .json file
+
+[yellow]Maps:[-] fuel, ignition, afr, ltft, knock,
+warmup, after_start, cold_ignition, cold_idle,
+iat_fuel, iat_ignition, baro_fuel, dead_time,
+fuel_3d, ignition_3d, afr_3d (JSON only)
+
+CSV files have load across the first row and RPM down the first column,
+so they open straight into a spreadsheet.
+`
+
+// setupMapCommands adds the map file command line to the map view
+func (c *Client) setupMapCommands() {
+	helpPanel := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft).
+		SetText(mapCommandHelp)
+	helpPanel.SetBorder(true).SetTitle("Map Files")
+	c.layouts.MapPanel.AddItem(helpPanel, 0, 1, false)
+
+	c.mapCommand = tview.NewInputField().SetLabel("Map command: ")
+	c.mapCommand.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			command := c.mapCommand.GetText()
+			go c.runMapCommand(command)
+		}
+		c.mapCommand.SetText("")
+		c.app.SetFocus(c.layouts.Pages)
+	})
+	c.layouts.MapPanel.AddItem(c.mapCommand, 1, 0, false)
+}
+
+// editingMapCommand reports whether the map command line has the keyboard,
+// so the global key bindings stay out of the way while typing
+func (c *Client) editingMapCommand() bool {
+	return c.mapCommand != nil && c.app.GetFocus() == c.mapCommand
+}
+
+// focusMapCommand switches to the map view and starts a map command
+func (c *Client) focusMapCommand() {
+	c.layouts.SwitchToPage("maps")
+	c.app.SetFocus(c.mapCommand)
+}
+
+// runMapCommand runs an export or import command typed on the map view
+func (c *Client) runMapCommand(command string) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return
+	}
+	if len(fields) != 3 {
+		c.showStatusMessage("Usage: export|import <map> <file>", "red")
+		return
+	}
+
+	var err error
+	switch verb, mapType, path := fields[0], fields[1], fields[2]; verb {
+	case "export":
+		err = c.exportMap(mapType, path)
+	case "import":
+		err = c.importMap(mapType, path)
+	default:
+		err = fmt.Errorf("unknown command %q, use export or import", verb)
+	}
+	if err != nil {
+		c.showStatusMessage(err.Error(), "red")
+	}
+}
+
+// exportMap fetches a map from the server and writes it to a local file in
+// the format the file extension names
+func (c *Client) exportMap(mapType, path string) error {
+	file, err := c.ecuClient.ExportMap(c.sessionContext(), &pb.MapExportRequest{
+		MapType: mapType,
+		Format:  ecu.MapFormatFromPath(path),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(file.Data), 0o644); err != nil {
+		return err
+	}
+
+	c.showStatusMessage(fmt.Sprintf("Exported %s to %s", mapType, path), "green")
+	return nil
+}
+
+// importMap reads a local map file and sends it to the server, which checks it
+// before replacing the map
+func (c *Client) importMap(mapType, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	status, err := c.ecuClient.ImportMap(c.sessionContext(), &pb.MapFile{
+		MapType: mapType,
+		Format:  ecu.MapFormatFromPath(path),
+		Data:    string(data),
+	})
+	if err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("import %s: %s", path, status.Message)
+	}
+
+	c.showStatusMessage(status.Message, "green")
+	return nil
+}
@@ -2,119 +2,127 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"io"
 	"log"
 	"net"
-	"time"
 
 	"github.com/StevenD2002/ninja650sim/internal/ecu"
 	"github.com/StevenD2002/ninja650sim/internal/engine"
+	"github.com/StevenD2002/ninja650sim/internal/faults"
+	"github.com/StevenD2002/ninja650sim/internal/session"
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+	"github.com/StevenD2002/ninja650sim/internal/telemetry"
 	pb "github.com/StevenD2002/ninja650sim/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// sessionMetadataKey is the gRPC metadata key clients use to pick a session
+const sessionMetadataKey = "session-id"
+
 // Server implements the gRPC MotorcycleSimulator service
 type server struct {
 	pb.UnimplementedMotorcycleSimulatorServer
-	engine *engine.Engine
-	ecu    *ecu.ECU
 
-	// Current motorcycle state
-	running bool
+	// Every stream gets its own engine and ECU through the session manager
+	sessions *session.Manager
+
+	// Directory telemetry recordings are written to
+	recordDir string
+
+	// Directory tune files are saved to and loaded from
+	tuneDir string
 }
 
 // NewServer creates a new simulator server
 func NewServer() *server {
 	return &server{
-		engine:  engine.NewEngine(),
-		ecu:     ecu.NewECU(),
-		running: false,
+		sessions:  session.NewManager(),
+		recordDir: defaultRecordDir,
+		tuneDir:   defaultTuneDir,
+	}
+}
+
+// sessionIDFromContext reads the requested session ID from the gRPC metadata
+func sessionIDFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(sessionMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// sessionFromContext finds the active session a unary request refers to
+func (s *server) sessionFromContext(ctx context.Context) (*session.Session, error) {
+	id := sessionIDFromContext(ctx)
+	if id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "missing %s metadata", sessionMetadataKey)
+	}
+
+	sess, ok := s.sessions.Get(id)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "session %q not found", id)
 	}
+	return sess, nil
 }
 
 // StreamEngine implements the gRPC service method for streaming engine data
 func (s *server) StreamEngine(stream pb.MotorcycleSimulator_StreamEngineServer) error {
-	// Mark the simulation as running
-	s.running = true
-	defer func() { s.running = false }()
+	// Join the requested session, or start a fresh one
+	sess := s.sessions.Acquire(sessionIDFromContext(stream.Context()))
+	defer s.sessions.Release(sess)
 
-	// Create ticker for simulation updates
-	ticker := time.NewTicker(50 * time.Millisecond) // 20Hz simulation rate
-	defer ticker.Stop()
+	// Tell the client which session it ended up in so it can share or reuse it
+	if err := stream.SendHeader(metadata.Pairs(sessionMetadataKey, sess.ID)); err != nil {
+		return err
+	}
+	log.Printf("Stream attached to session %s", sess.ID)
 
-	// Channel for user input
-	inputChan := make(chan *pb.UserInput)
+	// Receive telemetry from the session's simulation loop
+	updates, unsubscribe := sess.Subscribe()
+	defer unsubscribe()
 
-	// Start goroutine to receive user input
+	// Start goroutine to receive user input and queue it on the session
 	go func() {
 		for {
 			input, err := stream.Recv()
 			if err == io.EOF {
-				close(inputChan)
 				return
 			}
 			if err != nil {
 				log.Printf("Error receiving user input: %v", err)
 				return
 			}
-			inputChan <- input
+
+			sess.Submit(sim.InputCommand{
+				ThrottlePosition: input.ThrottlePosition,
+				ClutchPosition:   input.ClutchPosition,
+				Gear:             int(input.Gear),
+			})
+
+			// For debugging
+			log.Printf("Input received - Throttle: %.1f%%, Clutch: %.2f, Gear: %d",
+				input.ThrottlePosition, input.ClutchPosition, input.Gear)
 		}
 	}()
 
-	// Main simulation loop
+	// Forward every tick to the client
 	for {
 		select {
 		case <-stream.Context().Done():
 			return nil
-		case input, ok := <-inputChan:
-			if ok {
-				// Update throttle position
-				s.engine.SetThrottle(input.ThrottlePosition)
-				// Update clutch position
-				s.engine.ClutchPosition = input.ClutchPosition
-
-				// Update gear
-				s.engine.Gear = int(input.Gear)
-
-				// For debugging
-				log.Printf("Input received - Throttle: %.1f%%, Clutch: %.2f, Gear: %d",
-					input.ThrottlePosition, input.ClutchPosition, input.Gear)
-			}
-		case <-ticker.C:
-			// Get sensor data from engine
-			sensorData := s.engine.GetSensorData()
-
-			// Process sensor data through ECU
-			ecuOutputs := s.ecu.ProcessSensorData(sensorData)
-
-			// Update engine based on ECU outputs
-			s.engine.Update(ecuOutputs, 0.05) // 50ms
-
-			log.Printf("Engine state - RPM: %.1f, Speed: %.1f, Throttle: %.1f%%, Gear: %d, Clutch: %.2f",
-				s.engine.RPM, s.engine.Speed, s.engine.ThrottlePosition, s.engine.Gear, s.engine.ClutchPosition)
-
-			// Calculate performance metrics
-			power, torque := s.engine.CalculatePerformance()
-
-			// Create response message
-			response := &pb.EngineData{
-				Rpm:              s.engine.GetRPM(),
-				ThrottlePosition: s.engine.GetThrottlePosition(),
-				Timestamp:        time.Now().UnixNano(),
-				// Add additional fields if you've extended your proto definition
-				// For example:
-				Power:           power,
-				Torque:          torque,
-				Speed:           sensorData.Speed,
-				EngineTemp:      sensorData.EngineTemperature,
-				AfrCurrent:      sensorData.O2 * 14.7, // Convert lambda to AFR
-				AfrTarget:       ecuOutputs.LambdaTarget * 14.7,
-				FuelInjectionMs: ecuOutputs.FuelInjectionTime,
-				IgnitionAdvance: ecuOutputs.IgnitionAdvance,
-			}
-
+		case data := <-updates:
 			// Send update to client
-			if err := stream.Send(response); err != nil {
+			if err := stream.Send(data); err != nil {
 				return err
 			}
 		}
@@ -123,16 +131,63 @@ func (s *server) StreamEngine(stream pb.MotorcycleSimulator_StreamEngineServer)
 
 // GetECUMaps returns the current ECU maps
 func (s *server) GetECUMaps(ctx context.Context, req *pb.MapsRequest) (*pb.ECUMaps, error) {
-	// Get the current maps from the ECU
-	fuelMap := s.ecu.FuelMap
-	ignitionMap := s.ecu.IgnitionMap
-	afrMap := s.ecu.TargetAFRMap
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy the current maps out of the running simulation
+	var fuelMap, ignitionMap, afrMap, ltftMap, knockMap ecu.Map2D
+	var warmup, afterStart, coldRetard, coldIdle ecu.Map1D
+	var iatFuel, iatIgnition, baroFuel, deadTime ecu.Map1D
+	var fuelMap3D, ignitionMap3D, afrMap3D ecu.Map3D
+	err = sess.Read(func(sm *sim.Simulator) {
+		fuelMap = sm.ECU.FuelMap.Clone()
+		ignitionMap = sm.ECU.IgnitionMap.Clone()
+		afrMap = sm.ECU.TargetAFRMap.Clone()
+		ltftMap = sm.ECU.ClosedLoop.LTFT.Clone()
+		knockMap = sm.ECU.KnockControl.Learned.Clone()
+
+		warmup = sm.ECU.WarmUp.Enrichment.Clone()
+		afterStart = sm.ECU.WarmUp.AfterStart.Clone()
+		coldRetard = sm.ECU.WarmUp.IgnitionRetard.Clone()
+		coldIdle = sm.ECU.WarmUp.IdleRPM.Clone()
+
+		iatFuel = sm.ECU.Compensation.IATFuel.Clone()
+		iatIgnition = sm.ECU.Compensation.IATIgnition.Clone()
+		baroFuel = sm.ECU.Compensation.BaroFuel.Clone()
+		deadTime = sm.ECU.Compensation.DeadTime.Clone()
+
+		fuelMap3D = sm.ECU.FuelMap3D.Clone()
+		ignitionMap3D = sm.ECU.IgnitionMap3D.Clone()
+		afrMap3D = sm.ECU.AFRMap3D.Clone()
+	})
+	if err != nil {
+		return nil, sessionError(err)
+	}
 
 	// Convert to protobuf format
 	response := &pb.ECUMaps{
-		FuelMap:     convertMap2DToProto(fuelMap.Map2D, "fuel"),
-		IgnitionMap: convertMap2DToProto(ignitionMap.Map2D, "ignition"),
-		AfrMap:      convertMap2DToProto(afrMap.Map2D, "afr"),
+		FuelMap:     convertMap2DToProto(fuelMap, ecu.MapTypeFuel),
+		IgnitionMap: convertMap2DToProto(ignitionMap, ecu.MapTypeIgnition),
+		AfrMap:      convertMap2DToProto(afrMap, ecu.MapTypeAFR),
+
+		LongTermFuelTrim: convertMap2DToProto(ltftMap, ecu.MapTypeLTFT),
+		KnockRetard:      convertMap2DToProto(knockMap, ecu.MapTypeKnock),
+
+		WarmupEnrichment:     convertMap1DToProto(warmup, ecu.MapTypeWarmup),
+		AfterStartEnrichment: convertMap1DToProto(afterStart, ecu.MapTypeAfterStart),
+		ColdIgnitionRetard:   convertMap1DToProto(coldRetard, ecu.MapTypeColdRetard),
+		ColdIdleRpm:          convertMap1DToProto(coldIdle, ecu.MapTypeColdIdleRPM),
+
+		IatFuelCorrection:     convertMap1DToProto(iatFuel, ecu.MapTypeIATFuel),
+		IatIgnitionCorrection: convertMap1DToProto(iatIgnition, ecu.MapTypeIATIgnition),
+		BaroFuelCorrection:    convertMap1DToProto(baroFuel, ecu.MapTypeBaroFuel),
+		InjectorDeadTime:      convertMap1DToProto(deadTime, ecu.MapTypeDeadTime),
+
+		FuelMap3D:     convertMap3DToProto(fuelMap3D, ecu.MapTypeFuel3D),
+		IgnitionMap3D: convertMap3DToProto(ignitionMap3D, ecu.MapTypeIgnition3D),
+		AfrMap3D:      convertMap3DToProto(afrMap3D, ecu.MapTypeAFR3D),
 	}
 
 	return response, nil
@@ -140,37 +195,218 @@ func (s *server) GetECUMaps(ctx context.Context, req *pb.MapsRequest) (*pb.ECUMa
 
 // UpdateECUMap updates a specific ECU map
 func (s *server) UpdateECUMap(ctx context.Context, req *pb.MapUpdateRequest) (*pb.UpdateStatus, error) {
-	// Check which map to update
-	switch req.MapType {
-	case "fuel":
-		// Update a single cell in the fuel map
-		s.ecu.FuelMap.SetValue(req.Rpm, req.Load, req.Value)
-		return &pb.UpdateStatus{Success: true, Message: "Fuel map updated"}, nil
-	case "ignition":
-		// Update a single cell in the ignition map
-		s.ecu.IgnitionMap.SetValue(req.Rpm, req.Load, req.Value)
-		return &pb.UpdateStatus{Success: true, Message: "Ignition map updated"}, nil
-	case "afr":
-		// Update a single cell in the AFR map
-		s.ecu.TargetAFRMap.SetValue(req.Rpm, req.Load, req.Value)
-		return &pb.UpdateStatus{Success: true, Message: "AFR map updated"}, nil
-	default:
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update a single cell on the simulation goroutine
+	err = sess.Do(sim.MapEditCommand{
+		MapType: req.MapType,
+		RPM:     req.Rpm,
+		Load:    req.Load,
+		Axis:    req.Axis,
+		Value:   req.Value,
+	})
+	switch {
+	case errors.Is(err, ecu.ErrUnknownMap):
 		return &pb.UpdateStatus{Success: false, Message: "Unknown map type"}, nil
+	case errors.Is(err, ecu.ErrMap3DOff):
+		return &pb.UpdateStatus{Success: false, Message: "3D map is not enabled"}, nil
+	case errors.Is(err, ecu.ErrInvalidMap):
+		return &pb.UpdateStatus{Success: false, Message: err.Error()}, nil
+	case err != nil:
+		return nil, sessionError(err)
 	}
+
+	return &pb.UpdateStatus{Success: true, Message: mapUpdatedMessages[req.MapType]}, nil
+}
+
+// mapUpdatedMessages are the status messages returned for each map type
+var mapUpdatedMessages = map[string]string{
+	ecu.MapTypeFuel:     "Fuel map updated",
+	ecu.MapTypeIgnition: "Ignition map updated",
+	ecu.MapTypeAFR:      "AFR map updated",
+	ecu.MapTypeLTFT:     "Long-term fuel trim updated",
+	ecu.MapTypeKnock:    "Learned knock retard updated",
+
+	ecu.MapTypeWarmup:      "Warm-up enrichment updated",
+	ecu.MapTypeAfterStart:  "After-start enrichment updated",
+	ecu.MapTypeColdRetard:  "Cold ignition retard updated",
+	ecu.MapTypeColdIdleRPM: "Cold idle target updated",
+
+	ecu.MapTypeIATFuel:     "Intake air temperature fuel correction updated",
+	ecu.MapTypeIATIgnition: "Intake air temperature ignition correction updated",
+	ecu.MapTypeBaroFuel:    "Barometric fuel correction updated",
+	ecu.MapTypeDeadTime:    "Injector dead time updated",
+
+	ecu.MapTypeFuel3D:     "3D fuel map updated",
+	ecu.MapTypeIgnition3D: "3D ignition map updated",
+	ecu.MapTypeAFR3D:      "3D AFR map updated",
 }
 
 // SetECUSettings updates the ECU settings
 func (s *server) SetECUSettings(ctx context.Context, req *pb.ECUSettings) (*pb.UpdateStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update ECU settings
-	s.ecu.FuelTrim = req.FuelTrim
-	s.ecu.IgnitionTrim = req.IgnitionTrim
-	s.ecu.IdleRPM = req.IdleRpm
-	s.ecu.RevLimit = req.RevLimit
-	s.ecu.TempCompensation = req.TempCompensation
+	err = sess.Do(sim.SettingsCommand{Settings: ecu.Settings{
+		FuelTrim:         req.FuelTrim,
+		IgnitionTrim:     req.IgnitionTrim,
+		IdleRPM:          req.IdleRpm,
+		RevLimit:         req.RevLimit,
+		TempCompensation: req.TempCompensation,
+		LoadModel:        req.LoadModel,
+		RevLimiter:       req.RevLimiter,
+		GearRevLimits:    req.GearRevLimits,
+		LaunchControl:    req.LaunchControl,
+		LaunchRPM:        req.LaunchRpm,
+		FuelMapAxis:      req.FuelMapAxis,
+		IgnitionMapAxis:  req.IgnitionMapAxis,
+		AFRMapAxis:       req.AfrMapAxis,
+	}})
+	switch {
+	case errors.Is(err, ecu.ErrUnknownLoadModel):
+		return &pb.UpdateStatus{Success: false, Message: "Unknown load model"}, nil
+	case errors.Is(err, ecu.ErrUnknownLimiter):
+		return &pb.UpdateStatus{Success: false, Message: "Unknown rev limiter strategy"}, nil
+	case errors.Is(err, ecu.ErrUnknownAxis):
+		return &pb.UpdateStatus{Success: false, Message: "Unknown 3D map axis"}, nil
+	case errors.Is(err, ecu.ErrInvalidSettings):
+		return &pb.UpdateStatus{Success: false, Message: err.Error()}, nil
+	case err != nil:
+		return nil, sessionError(err)
+	}
 
 	return &pb.UpdateStatus{Success: true, Message: "ECU settings updated"}, nil
 }
 
+// SetInjector fits different injectors to the engine
+func (s *server) SetInjector(ctx context.Context, req *pb.InjectorRequest) (*pb.UpdateStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sess.Do(sim.InjectorCommand{
+		Injector: engine.Injector{
+			FlowRate:      req.FlowRate,
+			RatedPressure: req.RatedPressure,
+			DeadTime:      req.DeadTime,
+		},
+		Rescale: req.RescaleEcu,
+	})
+	switch {
+	case errors.Is(err, engine.ErrInvalidInjector):
+		return &pb.UpdateStatus{Success: false, Message: "Invalid injector data"}, nil
+	case err != nil:
+		return nil, sessionError(err)
+	}
+
+	if req.RescaleEcu {
+		return &pb.UpdateStatus{Success: true, Message: "Injectors fitted and ECU rescaled"}, nil
+	}
+	return &pb.UpdateStatus{Success: true, Message: "Injectors fitted"}, nil
+}
+
+// ReadCodes returns the stored trouble codes with their freeze frames
+func (s *server) ReadCodes(ctx context.Context, req *pb.CodesRequest) (*pb.TroubleCodes, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []ecu.DTC
+	var lamp bool
+	err = sess.Read(func(sm *sim.Simulator) {
+		codes = sm.ECU.Diagnostics.Codes()
+		lamp = sm.ECU.Diagnostics.Lamp
+	})
+	if err != nil {
+		return nil, sessionError(err)
+	}
+
+	response := &pb.TroubleCodes{FiLamp: lamp}
+	for _, code := range codes {
+		response.Codes = append(response.Codes, convertDTCToProto(code))
+	}
+	return response, nil
+}
+
+// ClearCodes erases the stored trouble codes
+func (s *server) ClearCodes(ctx context.Context, req *pb.CodesRequest) (*pb.UpdateStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sess.Do(sim.ClearCodesCommand{}); err != nil {
+		return nil, sessionError(err)
+	}
+	return &pb.UpdateStatus{Success: true, Message: "Trouble codes cleared"}, nil
+}
+
+// InjectFault schedules a sensor fault between the engine and the ECU
+func (s *server) InjectFault(ctx context.Context, req *pb.FaultRequest) (*pb.UpdateStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sess.Do(sim.FaultCommand{Fault: faults.Fault{
+		Sensor:   req.Sensor,
+		Type:     req.Type,
+		Value:    req.Value,
+		Delay:    req.Delay,
+		Duration: req.Duration,
+	}})
+	switch {
+	case errors.Is(err, faults.ErrUnknownSensor):
+		return &pb.UpdateStatus{Success: false, Message: "Unknown sensor"}, nil
+	case errors.Is(err, faults.ErrUnknownFault):
+		return &pb.UpdateStatus{Success: false, Message: "Unknown fault type"}, nil
+	case errors.Is(err, faults.ErrInvalidFault):
+		return &pb.UpdateStatus{Success: false, Message: err.Error()}, nil
+	case err != nil:
+		return nil, sessionError(err)
+	}
+
+	return &pb.UpdateStatus{Success: true, Message: "Fault injected"}, nil
+}
+
+// ClearFaults removes injected sensor faults
+func (s *server) ClearFaults(ctx context.Context, req *pb.ClearFaultsRequest) (*pb.UpdateStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sess.Do(sim.ClearFaultsCommand{Sensor: req.Sensor})
+	switch {
+	case errors.Is(err, faults.ErrUnknownSensor):
+		return &pb.UpdateStatus{Success: false, Message: "Unknown sensor"}, nil
+	case err != nil:
+		return nil, sessionError(err)
+	}
+
+	return &pb.UpdateStatus{Success: true, Message: "Faults cleared"}, nil
+}
+
+// sessionError converts a session failure into a gRPC status
+func sessionError(err error) error {
+	switch {
+	case errors.Is(err, session.ErrClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, session.ErrReplaying):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
 // Helper function to convert Map2D to protobuf format
 func convertMap2DToProto(m ecu.Map2D, mapType string) *pb.Map2D {
 	protoMap := &pb.Map2D{
@@ -189,7 +425,74 @@ func convertMap2DToProto(m ecu.Map2D, mapType string) *pb.Map2D {
 	return protoMap
 }
 
+// convertMap1DToProto converts a 1D table to protobuf format
+func convertMap1DToProto(m ecu.Map1D, mapType string) *pb.Map1D {
+	return &pb.Map1D{
+		Type:        mapType,
+		Breakpoints: m.Breakpoints,
+		Values:      m.Values,
+	}
+}
+
+// convertDTCToProto converts a trouble code and its freeze frame to protobuf format
+func convertDTCToProto(code ecu.DTC) *pb.TroubleCode {
+	frame := code.FreezeFrame
+	return &pb.TroubleCode{
+		Code:        code.Code,
+		Description: code.Description,
+		Status:      code.Status,
+		Active:      code.Active,
+		Occurrences: int32(code.Occurrences),
+		FirstSeen:   code.FirstSeen,
+		LastSeen:    code.LastSeen,
+		FreezeFrame: &pb.FreezeFrame{
+			Timestamp:         frame.Timestamp,
+			Rpm:               frame.RPM,
+			ThrottlePosition:  frame.ThrottlePosition,
+			EngineTemp:        frame.EngineTemp,
+			AirTemp:           frame.AirTemp,
+			ManifoldPressure:  frame.MAP,
+			O2:                frame.O2,
+			Baro:              frame.Baro,
+			BatteryVoltage:    frame.BatteryVoltage,
+			Speed:             frame.Speed,
+			Gear:              int32(frame.Gear),
+			EngineLoad:        frame.Load,
+			ShortTermFuelTrim: frame.STFT,
+			LongTermFuelTrim:  frame.LTFT,
+		},
+	}
+}
+
+// convertMap3DToProto converts a 3D map to protobuf format, or nil while it is off
+func convertMap3DToProto(m ecu.Map3D, mapType string) *pb.Map3D {
+	if !m.Enabled() {
+		return nil
+	}
+
+	protoMap := &pb.Map3D{
+		Type:        mapType,
+		Axis:        m.Axis,
+		Breakpoints: m.Breakpoints,
+	}
+	for _, layer := range m.Layers {
+		protoMap.Layers = append(protoMap.Layers, convertMap2DToProto(layer, mapType))
+	}
+	return protoMap
+}
+
 func main() {
+	recordDir := flag.String("record-dir", defaultRecordDir, "directory for telemetry recordings")
+	replayPath := flag.String("replay", "", "replay a recorded telemetry file instead of simulating")
+	tuneDir := flag.String("tune-dir", defaultTuneDir, "directory for tune files")
+	learnedPath := flag.String("learned", "", "file to keep the ECU's learned fuel trims in between sessions")
+	flag.Parse()
+
+	// Replay sessions play back a log, so they have no learned tables to keep
+	if *replayPath != "" && *learnedPath != "" {
+		log.Fatalf("-learned cannot be used with -replay")
+	}
+
 	// Create a TCP listener
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
@@ -199,6 +502,24 @@ func main() {
 	// Create a new gRPC server
 	s := grpc.NewServer()
 	simulatorServer := NewServer()
+	simulatorServer.recordDir = *recordDir
+	simulatorServer.tuneDir = *tuneDir
+	if *learnedPath != "" {
+		simulatorServer.sessions.PersistLearned(*learnedPath)
+	}
+
+	// In replay mode every session plays back the log instead of running the engine
+	if *replayPath != "" {
+		frames, err := telemetry.ReadFile(*replayPath)
+		if err != nil {
+			log.Fatalf("Failed to load replay: %v", err)
+		}
+		if len(frames) == 0 {
+			log.Fatalf("Replay %s has no telemetry", *replayPath)
+		}
+		simulatorServer.sessions = session.NewReplayManager(frames)
+		log.Printf("Replaying %d ticks from %s", len(frames), *replayPath)
+	}
 
 	// Register our implementation
 	pb.RegisterMotorcycleSimulatorServer(s, simulatorServer)
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/StevenD2002/ninja650sim/internal/ecu"
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportMap returns a map or table encoded as CSV or JSON
+func (s *server) ExportMap(ctx context.Context, req *pb.MapExportRequest) (*pb.MapFile, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encode on the simulation goroutine so the map cannot change mid-export
+	var data []byte
+	var exportErr error
+	err = sess.Read(func(sm *sim.Simulator) {
+		data, exportErr = sm.ECU.ExportMap(req.MapType, req.Format)
+	})
+	if err != nil {
+		return nil, sessionError(err)
+	}
+	if exportErr != nil {
+		return nil, mapFileError(exportErr)
+	}
+
+	return &pb.MapFile{MapType: req.MapType, Format: req.Format, Data: string(data)}, nil
+}
+
+// ImportMap replaces a map or table with one decoded from a CSV or JSON file
+func (s *server) ImportMap(ctx context.Context, req *pb.MapFile) (*pb.UpdateStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sess.Do(sim.MapImportCommand{MapType: req.MapType, Format: req.Format, Data: req.Data})
+	switch {
+	case errors.Is(err, ecu.ErrUnknownMap):
+		return &pb.UpdateStatus{Success: false, Message: "Unknown map type"}, nil
+	case errors.Is(err, ecu.ErrUnknownMapFormat),
+		errors.Is(err, ecu.ErrInvalidMap),
+		errors.Is(err, ecu.ErrUnknownAxis):
+		return &pb.UpdateStatus{Success: false, Message: err.Error()}, nil
+	case err != nil:
+		return nil, sessionError(err)
+	}
+
+	return &pb.UpdateStatus{Success: true, Message: mapUpdatedMessages[req.MapType]}, nil
+}
+
+// mapFileError converts a map export failure into a gRPC status
+func mapFileError(err error) error {
+	switch {
+	case errors.Is(err, ecu.ErrUnknownMap),
+		errors.Is(err, ecu.ErrUnknownMapFormat):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ecu.ErrMap3DOff):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/session"
+	"github.com/StevenD2002/ninja650sim/internal/telemetry"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultRecordDir is where recordings go unless -record-dir says otherwise
+	defaultRecordDir = "recordings"
+
+	// journalExt is the file extension for saved input journals
+	journalExt = "journal.json"
+)
+
+// StartRecording begins writing the session's telemetry to a file on the server
+func (s *server) StartRecording(ctx context.Context, req *pb.RecordingRequest) (*pb.RecordingStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := s.startRecording(sess, req.Format, req.Name)
+	if err != nil {
+		return nil, recordingError(err)
+	}
+	return convertRecordingStatusToProto(rs), nil
+}
+
+// StopRecording finishes the session's current recording
+func (s *server) StopRecording(ctx context.Context, req *pb.StopRecordingRequest) (*pb.RecordingStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := sess.StopRecording()
+	if err != nil {
+		return nil, recordingError(err)
+	}
+	return convertRecordingStatusToProto(rs), nil
+}
+
+// SaveJournal writes the session's input journal to a file on the server
+func (s *server) SaveJournal(ctx context.Context, req *pb.JournalRequest) (*pb.JournalStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := s.saveJournal(sess, req.Name)
+	if err != nil {
+		return nil, recordingError(err)
+	}
+	return &pb.JournalStatus{Path: js.Path, Entries: int32(js.Entries), Ticks: js.Ticks}, nil
+}
+
+// startRecording picks a file in the recording directory and starts the session recorder
+func (s *server) startRecording(sess *session.Session, format, name string) (session.RecordingStatus, error) {
+	if format == "" {
+		format = telemetry.FormatCSV
+	}
+	return sess.StartRecording(s.recordingPath(sess, name, format), format)
+}
+
+// saveJournal writes the session journal next to the recordings
+func (s *server) saveJournal(sess *session.Session, name string) (session.JournalStatus, error) {
+	return sess.SaveJournal(s.recordingPath(sess, name, journalExt))
+}
+
+// recordingPath builds a file path in the recording directory
+func (s *server) recordingPath(sess *session.Session, name, ext string) string {
+	return serverPath(s.recordDir, sess, name, ext)
+}
+
+// serverPath builds a file path in one of the server's directories. Clients
+// only choose the file name, never the directory; without a name the session
+// ID and current time are used. A name already ending in the extension keeps
+// it only once; any other dots are part of the name.
+func serverPath(dir string, sess *session.Session, name, ext string) string {
+	name = filepath.Base(name)
+	if name == "." || name == string(filepath.Separator) {
+		name = ""
+	}
+	name = strings.TrimSuffix(name, "."+ext)
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", sess.ID, time.Now().Format("20060102-150405"))
+	}
+
+	return filepath.Join(dir, name+"."+ext)
+}
+
+// recordingError converts a recorder failure into a gRPC status
+func recordingError(err error) error {
+	switch {
+	case errors.Is(err, session.ErrAlreadyRecording),
+		errors.Is(err, session.ErrNotRecording),
+		errors.Is(err, session.ErrNoJournal):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, telemetry.ErrUnknownFormat):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return sessionError(err)
+	}
+}
+
+// Helper function to convert the recorder state to protobuf format
+func convertRecordingStatusToProto(rs session.RecordingStatus) *pb.RecordingStatus {
+	return &pb.RecordingStatus{
+		Recording: rs.Recording,
+		Path:      rs.Path,
+		Format:    rs.Format,
+		Frames:    rs.Frames,
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/session"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetPaused freezes or resumes the session clock
+func (s *server) SetPaused(ctx context.Context, req *pb.PauseRequest) (*pb.TimeStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := sess.SetPaused(req.Paused)
+	if err != nil {
+		return nil, timeControlError(err)
+	}
+	return convertTimeStatusToProto(ts), nil
+}
+
+// StepSimulation advances a paused session by the requested number of ticks
+func (s *server) StepSimulation(ctx context.Context, req *pb.StepRequest) (*pb.TimeStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := sess.StepTicks(int(req.Ticks))
+	if err != nil {
+		return nil, timeControlError(err)
+	}
+	return convertTimeStatusToProto(ts), nil
+}
+
+// SetTimeControl changes the timestep and time scale of the session clock
+func (s *server) SetTimeControl(ctx context.Context, req *pb.TimeControlRequest) (*pb.TimeStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := sess.SetTimeControl(millisToDuration(req.TimestepMs), req.TimeScale)
+	if err != nil {
+		return nil, timeControlError(err)
+	}
+	return convertTimeStatusToProto(ts), nil
+}
+
+// SeekReplay jumps a replay session to a tick in the log
+func (s *server) SeekReplay(ctx context.Context, req *pb.SeekRequest) (*pb.TimeStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := sess.SeekTo(req.Tick)
+	if err != nil {
+		return nil, timeControlError(err)
+	}
+	return convertTimeStatusToProto(ts), nil
+}
+
+// timeControlError converts a time controller failure into a gRPC status
+func timeControlError(err error) error {
+	switch {
+	case errors.Is(err, session.ErrNotPaused),
+		errors.Is(err, session.ErrNotReplaying):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, session.ErrInvalidSeek):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, session.ErrInvalidTimestep),
+		errors.Is(err, session.ErrInvalidTimeScale),
+		errors.Is(err, session.ErrInvalidStepCount):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return sessionError(err)
+	}
+}
+
+// Helper function to convert the session clock state to protobuf format
+func convertTimeStatusToProto(ts session.TimeStatus) *pb.TimeStatus {
+	return &pb.TimeStatus{
+		Paused:     ts.Paused,
+		TimestepMs: float64(ts.Timestep) / float64(time.Millisecond),
+		TimeScale:  ts.TimeScale,
+		Tick:       ts.Tick,
+		SimTime:    ts.SimTime.Seconds(),
+		Replay:     ts.Replay,
+		EndTick:    ts.EndTick,
+	}
+}
+
+// millisToDuration converts a millisecond value from the wire into a duration
+func millisToDuration(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/ecu"
+	"github.com/StevenD2002/ninja650sim/internal/session"
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultTuneDir is where tunes are kept unless -tune-dir says otherwise
+	defaultTuneDir = "tunes"
+
+	// tuneExt is the file extension for tune files
+	tuneExt = "tune.json"
+)
+
+// SaveTune writes the session's calibration to a tune file on the server. The
+// name, author and notes default to those of the tune last loaded, and the
+// revision counts on from it.
+func (s *server) SaveTune(ctx context.Context, req *pb.SaveTuneRequest) (*pb.TuneStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var path string
+	var tune ecu.Tune
+	var tuneErr error
+	err = sess.Read(func(sm *sim.Simulator) {
+		info := sm.ECU.TuneInfo
+		if req.Name != "" {
+			info.Name = req.Name
+		}
+		if req.Author != "" {
+			info.Author = req.Author
+		}
+		if req.Notes != "" {
+			info.Notes = req.Notes
+		}
+
+		path = s.tunePath(sess, info.Name)
+		info.Name = strings.TrimSuffix(filepath.Base(path), "."+tuneExt)
+		info.Revision++
+		info.Created = time.Now().UTC()
+
+		tune, tuneErr = sm.ECU.Tune(info)
+	})
+	if err != nil {
+		return nil, sessionError(err)
+	}
+	if tuneErr == nil {
+		tuneErr = ecu.SaveTuneFile(path, tune)
+	}
+	if tuneErr != nil {
+		return nil, tuneError(tuneErr)
+	}
+
+	return convertTuneToProto(path, tune), nil
+}
+
+// LoadTune replaces the session's calibration with a tune file from the server
+func (s *server) LoadTune(ctx context.Context, req *pb.LoadTuneRequest) (*pb.TuneStatus, error) {
+	sess, err := s.sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "tune name is required")
+	}
+
+	path := s.tunePath(sess, req.Name)
+	tune, err := ecu.LoadTuneFile(path)
+	if err != nil {
+		return nil, tuneError(err)
+	}
+
+	// Loading goes through the journal so re-simulations pick the tune up too
+	if err := sess.Do(sim.LoadTuneCommand{Tune: *tune}); err != nil {
+		return nil, tuneError(err)
+	}
+
+	return convertTuneToProto(path, *tune), nil
+}
+
+// tunePath builds a file path in the tune directory
+func (s *server) tunePath(sess *session.Session, name string) string {
+	return serverPath(s.tuneDir, sess, name, tuneExt)
+}
+
+// tuneError converts a tune save or load failure into a gRPC status
+func tuneError(err error) error {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ecu.ErrTuneIncompatible):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ecu.ErrTuneChecksum):
+		return status.Error(codes.DataLoss, err.Error())
+	case errors.Is(err, ecu.ErrTuneVersion),
+		errors.Is(err, ecu.ErrInvalidTune),
+		errors.Is(err, ecu.ErrInvalidMap),
+		errors.Is(err, ecu.ErrUnknownMap),
+		errors.Is(err, ecu.ErrUnknownAxis),
+		errors.Is(err, ecu.ErrUnknownLoadModel),
+		errors.Is(err, ecu.ErrUnknownLimiter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return sessionError(err)
+	}
+}
+
+// Helper function to convert a tune's description to protobuf format
+func convertTuneToProto(path string, t ecu.Tune) *pb.TuneStatus {
+	return &pb.TuneStatus{
+		Path:     path,
+		Name:     t.Name,
+		Author:   t.Author,
+		Notes:    t.Notes,
+		Revision: int32(t.Revision),
+		Version:  int32(t.Version),
+		Created:  t.Created.Format(time.RFC3339),
+		Checksum: t.Checksum,
+	}
+}
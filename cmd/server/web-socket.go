@@ -5,6 +5,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/StevenD2002/ninja650sim/internal/session"
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+	pb "github.com/StevenD2002/ninja650sim/proto"
 	"github.com/gorilla/websocket"
 )
 
@@ -23,27 +26,134 @@ type WSUserInput struct {
 }
 
 type WSEngineData struct {
-	RPM              float64 `json:"rpm"`
-	ThrottlePosition float64 `json:"throttle_position"`
-	Timestamp        int64   `json:"timestamp"`
-	Power            float64 `json:"power"`
-	Torque           float64 `json:"torque"`
-	Speed            float64 `json:"speed"`
-	EngineTemp       float64 `json:"engine_temp"`
-	AFRCurrent       float64 `json:"afr_current"`
-	AFRTarget        float64 `json:"afr_target"`
-	FuelInjectionMs  float64 `json:"fuel_injection_ms"`
-	IgnitionAdvance  float64 `json:"ignition_advance"`
-	Gear             int     `json:"gear"`
-	ClutchPosition   float64 `json:"clutch_position"`
+	Type                  string  `json:"type"` // Always "engine_data"
+	SessionID             string  `json:"session_id"`
+	Tick                  int64   `json:"tick"`
+	RPM                   float64 `json:"rpm"`
+	ThrottlePosition      float64 `json:"throttle_position"`
+	Timestamp             int64   `json:"timestamp"`
+	Power                 float64 `json:"power"`
+	Torque                float64 `json:"torque"`
+	Speed                 float64 `json:"speed"`
+	EngineTemp            float64 `json:"engine_temp"`
+	AFRCurrent            float64 `json:"afr_current"`
+	AFRTarget             float64 `json:"afr_target"`
+	FuelInjectionMs       float64 `json:"fuel_injection_ms"`
+	IgnitionAdvance       float64 `json:"ignition_advance"`
+	Gear                  int     `json:"gear"`
+	ClutchPosition        float64 `json:"clutch_position"`
+	KnockCount            int     `json:"knock_count"`
+	AFRDeviation          float64 `json:"afr_deviation"`
+	ClosedLoop            bool    `json:"closed_loop"`
+	STFT                  float64 `json:"short_term_fuel_trim"`
+	LTFT                  float64 `json:"long_term_fuel_trim"`
+	KnockIntensity        float64 `json:"knock_intensity"`
+	Knocking              bool    `json:"knocking"`
+	KnockRetard           float64 `json:"knock_retard"`
+	LearnedRetard         float64 `json:"learned_knock_retard"`
+	EngineLoad            float64 `json:"engine_load"`
+	ManifoldPressure      float64 `json:"manifold_pressure"`
+	AirMass               float64 `json:"air_mass"`
+	AirFlow               float64 `json:"air_flow"`
+	FuelCut               bool    `json:"fuel_cut"`
+	AccelEnrichment       float64 `json:"accel_enrichment"`
+	FuelFilm              float64 `json:"fuel_film"`
+	RevLimiterActive      bool    `json:"rev_limiter_active"`
+	RevLimit              float64 `json:"rev_limit"`
+	LaunchControl         bool    `json:"launch_control"`
+	LimiterRetard         float64 `json:"limiter_retard"`
+	LimiterSparkCut       float64 `json:"limiter_spark_cut"`
+	LimiterFuelCut        float64 `json:"limiter_fuel_cut"`
+	WarmupEnrichment      float64 `json:"warmup_enrichment"`
+	AfterStartEnrichment  float64 `json:"after_start_enrichment"`
+	ColdIgnitionRetard    float64 `json:"cold_ignition_retard"`
+	IdleTarget            float64 `json:"idle_target"`
+	BatteryVoltage        float64 `json:"battery_voltage"`
+	Baro                  float64 `json:"baro"`
+	IATFuelCorrection     float64 `json:"iat_fuel_correction"`
+	BaroFuelCorrection    float64 `json:"baro_fuel_correction"`
+	IATIgnitionCorrection float64 `json:"iat_ignition_correction"`
+	InjectorDeadTime      float64 `json:"injector_dead_time"`
+	FuelMass              float64 `json:"fuel_mass"`
+	InjectorDuty          float64 `json:"injector_duty"`
+	FILamp                bool    `json:"fi_lamp"`
+	DTCCount              int     `json:"dtc_count"`
+	SensorFaults          string  `json:"sensor_faults"`
+	SensorFaultCount      int     `json:"sensor_fault_count"`
+	Failsafe              string  `json:"failsafe"`
+	LimpMode              bool    `json:"limp_mode"`
+}
+
+// newWSEngineData converts session telemetry into the WebSocket message format
+func newWSEngineData(sessionID string, data *pb.EngineData) WSEngineData {
+	return WSEngineData{
+		Type:                  wsTypeEngineData,
+		SessionID:             sessionID,
+		Tick:                  data.Tick,
+		RPM:                   data.Rpm,
+		ThrottlePosition:      data.ThrottlePosition,
+		Timestamp:             data.Timestamp,
+		Power:                 data.Power,
+		Torque:                data.Torque,
+		Speed:                 data.Speed,
+		EngineTemp:            data.EngineTemp,
+		AFRCurrent:            data.AfrCurrent,
+		AFRTarget:             data.AfrTarget,
+		FuelInjectionMs:       data.FuelInjectionMs,
+		IgnitionAdvance:       data.IgnitionAdvance,
+		Gear:                  int(data.Gear),
+		ClutchPosition:        data.ClutchPosition,
+		KnockCount:            int(data.KnockCount),
+		AFRDeviation:          data.AfrDeviation,
+		ClosedLoop:            data.ClosedLoop,
+		STFT:                  data.ShortTermFuelTrim,
+		LTFT:                  data.LongTermFuelTrim,
+		KnockIntensity:        data.KnockIntensity,
+		Knocking:              data.Knocking,
+		KnockRetard:           data.KnockRetard,
+		LearnedRetard:         data.LearnedKnockRetard,
+		EngineLoad:            data.EngineLoad,
+		ManifoldPressure:      data.ManifoldPressure,
+		AirMass:               data.AirMass,
+		AirFlow:               data.AirFlow,
+		FuelCut:               data.FuelCut,
+		AccelEnrichment:       data.AccelEnrichment,
+		FuelFilm:              data.FuelFilm,
+		RevLimiterActive:      data.RevLimiterActive,
+		RevLimit:              data.RevLimit,
+		LaunchControl:         data.LaunchControl,
+		LimiterRetard:         data.LimiterRetard,
+		LimiterSparkCut:       data.LimiterSparkCut,
+		LimiterFuelCut:        data.LimiterFuelCut,
+		WarmupEnrichment:      data.WarmupEnrichment,
+		AfterStartEnrichment:  data.AfterStartEnrichment,
+		ColdIgnitionRetard:    data.ColdIgnitionRetard,
+		IdleTarget:            data.IdleTarget,
+		BatteryVoltage:        data.BatteryVoltage,
+		Baro:                  data.Baro,
+		IATFuelCorrection:     data.IatFuelCorrection,
+		BaroFuelCorrection:    data.BaroFuelCorrection,
+		IATIgnitionCorrection: data.IatIgnitionCorrection,
+		InjectorDeadTime:      data.InjectorDeadTime,
+		FuelMass:              data.FuelMass,
+		InjectorDuty:          data.InjectorDuty,
+		FILamp:                data.FiLamp,
+		DTCCount:              int(data.DtcCount),
+		SensorFaults:          data.SensorFaults,
+		SensorFaultCount:      int(data.SensorFaultCount),
+		Failsafe:              data.Failsafe,
+		LimpMode:              data.LimpMode,
+	}
 }
 
 // WebSocket client connection
 type WSClient struct {
-	conn   *websocket.Conn
-	server *server
-	send   chan WSEngineData
-	input  chan WSUserInput
+	conn    *websocket.Conn
+	server  *server
+	session *session.Session
+	updates <-chan *pb.EngineData
+	replies chan interface{}
+	done    chan struct{}
 }
 
 // Handle WebSocket connections
@@ -56,32 +166,41 @@ func (s *server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	}
 	defer conn.Close()
 
-	log.Println("WebSocket client connected")
+	// Join the session named in the query string, or start a fresh one
+	sess := s.sessions.Acquire(r.URL.Query().Get("session"))
+	defer s.sessions.Release(sess)
+
+	log.Printf("WebSocket client connected to session %s", sess.ID)
+
+	// Receive telemetry from the session's simulation loop
+	updates, unsubscribe := sess.Subscribe()
+	defer unsubscribe()
 
 	// Create client
 	client := &WSClient{
-		conn:   conn,
-		server: s,
-		send:   make(chan WSEngineData, 10),
-		input:  make(chan WSUserInput, 10),
+		conn:    conn,
+		server:  s,
+		session: sess,
+		updates: updates,
+		replies: make(chan interface{}, 10),
+		done:    make(chan struct{}),
 	}
 
-	// Start goroutines for handling messages
-	go client.readMessages()
+	// Start goroutine for sending telemetry
 	go client.writeMessages()
-	go client.simulationLoop()
 
-	// Keep connection alive
-	select {}
+	// Read until the connection drops, then stop the writer
+	client.readMessages()
+	close(client.done)
+
+	log.Printf("WebSocket client left session %s", sess.ID)
 }
 
 // Read messages from WebSocket client
 func (c *WSClient) readMessages() {
-	defer c.conn.Close()
-
 	for {
-		var input WSUserInput
-		err := c.conn.ReadJSON(&input)
+		var msg WSMessage
+		err := c.conn.ReadJSON(&msg)
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket read error: %v", err)
@@ -89,12 +208,32 @@ func (c *WSClient) readMessages() {
 			break
 		}
 
-		// Send input to simulation
-		select {
-		case c.input <- input:
-		default:
-			// Channel full, skip this input
+		// Plain input messages carry no type
+		if msg.Type == "" || msg.Type == wsTypeInput {
+			input := msg.WSUserInput
+
+			// Queue input on the session simulation
+			c.session.Submit(sim.InputCommand{
+				ThrottlePosition: input.ThrottlePosition,
+				ClutchPosition:   input.ClutchPosition,
+				Gear:             input.Gear,
+			})
+
+			log.Printf("WS Input - Throttle: %.1f%%, Clutch: %.2f, Gear: %d",
+				input.ThrottlePosition, input.ClutchPosition, input.Gear)
+			continue
 		}
+
+		c.reply(c.handleCommand(msg))
+	}
+}
+
+// reply queues a response to a command for the writer goroutine
+func (c *WSClient) reply(msg interface{}) {
+	select {
+	case c.replies <- msg:
+	default:
+		// Channel full, drop the reply
 	}
 }
 
@@ -104,63 +243,19 @@ func (c *WSClient) writeMessages() {
 
 	for {
 		select {
-		case data := <-c.send:
+		case <-c.done:
+			return
+		case data := <-c.updates:
 			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := c.conn.WriteJSON(data); err != nil {
+			if err := c.conn.WriteJSON(newWSEngineData(c.session.ID, data)); err != nil {
 				log.Printf("WebSocket write error: %v", err)
 				return
 			}
-		}
-	}
-}
-
-// Simulation loop for WebSocket client
-func (c *WSClient) simulationLoop() {
-	ticker := time.NewTicker(50 * time.Millisecond) // 20Hz
-	defer ticker.Stop()
-
-	for {
-		select {
-		case input := <-c.input:
-			// Apply user input to engine
-			c.server.engine.SetThrottle(input.ThrottlePosition)
-			c.server.engine.ClutchPosition = input.ClutchPosition
-			c.server.engine.Gear = input.Gear
-
-			log.Printf("WS Input - Throttle: %.1f%%, Clutch: %.2f, Gear: %d",
-				input.ThrottlePosition, input.ClutchPosition, input.Gear)
-
-		case <-ticker.C:
-			// Update simulation
-			sensorData := c.server.engine.GetSensorData()
-			ecuOutputs := c.server.ecu.ProcessSensorData(sensorData)
-			c.server.engine.Update(ecuOutputs, 0.05)
-
-			// Calculate performance
-			power, torque := c.server.engine.CalculatePerformance()
-
-			// Create WebSocket response (convert from protobuf format)
-			wsData := WSEngineData{
-				RPM:              c.server.engine.GetRPM(),
-				ThrottlePosition: c.server.engine.GetThrottlePosition(),
-				Timestamp:        time.Now().UnixNano(),
-				Power:            power,
-				Torque:           torque,
-				Speed:            sensorData.Speed,
-				EngineTemp:       sensorData.EngineTemperature,
-				AFRCurrent:       sensorData.O2 * 14.7,
-				AFRTarget:        ecuOutputs.LambdaTarget * 14.7,
-				FuelInjectionMs:  ecuOutputs.FuelInjectionTime,
-				IgnitionAdvance:  ecuOutputs.IgnitionAdvance,
-				Gear:             c.server.engine.Gear,
-				ClutchPosition:   c.server.engine.ClutchPosition,
-			}
-
-			// Send to client
-			select {
-			case c.send <- wsData:
-			default:
-				// Channel full, skip this update
+		case msg := <-c.replies:
+			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				log.Printf("WebSocket write error: %v", err)
+				return
 			}
 		}
 	}
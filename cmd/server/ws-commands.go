@@ -0,0 +1,180 @@
+package main
+
+import (
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/session"
+)
+
+// WebSocket message types. Messages without a type are treated as rider input.
+const (
+	wsTypeInput       = "input"
+	wsTypeEngineData  = "engine_data"
+	wsTypeError       = "error"
+	wsTypePause       = "pause"
+	wsTypeResume      = "resume"
+	wsTypeStep        = "step"
+	wsTypeTimeControl = "time_control"
+	wsTypeTimeStatus  = "time_status"
+	wsTypeSeek        = "seek"
+
+	wsTypeStartRecording  = "start_recording"
+	wsTypeStopRecording   = "stop_recording"
+	wsTypeRecordingStatus = "recording_status"
+	wsTypeSaveJournal     = "save_journal"
+	wsTypeJournalStatus   = "journal_status"
+)
+
+// WSMessage is anything a WebSocket client can send
+type WSMessage struct {
+	Type string `json:"type"`
+
+	// Rider input, used when Type is empty or "input"
+	WSUserInput
+
+	// Time control
+	Ticks      int     `json:"ticks,omitempty"`
+	TimestepMs float64 `json:"timestep_ms,omitempty"`
+	TimeScale  float64 `json:"time_scale,omitempty"`
+
+	// Replay
+	Tick int64 `json:"tick,omitempty"`
+
+	// Recording
+	Format string `json:"format,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// WSError reports a command that could not be carried out
+type WSError struct {
+	Type    string `json:"type"` // Always "error"
+	Command string `json:"command"`
+	Message string `json:"message"`
+}
+
+// WSTimeStatus reports the state of the session clock
+type WSTimeStatus struct {
+	Type       string  `json:"type"` // Always "time_status"
+	Paused     bool    `json:"paused"`
+	TimestepMs float64 `json:"timestep_ms"`
+	TimeScale  float64 `json:"time_scale"`
+	Tick       int64   `json:"tick"`
+	SimTime    float64 `json:"sim_time"`
+	Replay     bool    `json:"replay"`
+	EndTick    int64   `json:"end_tick,omitempty"`
+}
+
+// WSRecordingStatus reports the state of the session recorder
+type WSRecordingStatus struct {
+	Type      string `json:"type"` // Always "recording_status"
+	Recording bool   `json:"recording"`
+	Path      string `json:"path"`
+	Format    string `json:"format"`
+	Frames    int64  `json:"frames"`
+}
+
+// WSJournalStatus reports a saved input journal
+type WSJournalStatus struct {
+	Type    string `json:"type"` // Always "journal_status"
+	Path    string `json:"path"`
+	Entries int    `json:"entries"`
+	Ticks   int64  `json:"ticks"`
+}
+
+// newWSError builds an error reply for a command
+func newWSError(command string, err error) WSError {
+	return WSError{Type: wsTypeError, Command: command, Message: err.Error()}
+}
+
+// newWSTimeStatus converts the session clock state into the WebSocket message format
+func newWSTimeStatus(ts session.TimeStatus) WSTimeStatus {
+	return WSTimeStatus{
+		Type:       wsTypeTimeStatus,
+		Paused:     ts.Paused,
+		TimestepMs: float64(ts.Timestep) / float64(time.Millisecond),
+		TimeScale:  ts.TimeScale,
+		Tick:       ts.Tick,
+		SimTime:    ts.SimTime.Seconds(),
+		Replay:     ts.Replay,
+		EndTick:    ts.EndTick,
+	}
+}
+
+// newWSRecordingStatus converts the recorder state into the WebSocket message format
+func newWSRecordingStatus(rs session.RecordingStatus) WSRecordingStatus {
+	return WSRecordingStatus{
+		Type:      wsTypeRecordingStatus,
+		Recording: rs.Recording,
+		Path:      rs.Path,
+		Format:    rs.Format,
+		Frames:    rs.Frames,
+	}
+}
+
+// handleCommand runs a non-input WebSocket command and returns the reply
+func (c *WSClient) handleCommand(msg WSMessage) interface{} {
+	switch msg.Type {
+	case wsTypePause, wsTypeResume, wsTypeStep, wsTypeTimeControl, wsTypeTimeStatus, wsTypeSeek:
+		return c.handleTimeCommand(msg)
+	case wsTypeStartRecording, wsTypeStopRecording, wsTypeRecordingStatus:
+		return c.handleRecordingCommand(msg)
+	case wsTypeSaveJournal:
+		js, err := c.server.saveJournal(c.session, msg.Name)
+		if err != nil {
+			return newWSError(msg.Type, err)
+		}
+		return WSJournalStatus{Type: wsTypeJournalStatus, Path: js.Path, Entries: js.Entries, Ticks: js.Ticks}
+	default:
+		return WSError{Type: wsTypeError, Command: msg.Type, Message: "unknown command"}
+	}
+}
+
+// handleTimeCommand runs a session clock command
+func (c *WSClient) handleTimeCommand(msg WSMessage) interface{} {
+	var (
+		ts  session.TimeStatus
+		err error
+	)
+
+	switch msg.Type {
+	case wsTypePause:
+		ts, err = c.session.SetPaused(true)
+	case wsTypeResume:
+		ts, err = c.session.SetPaused(false)
+	case wsTypeStep:
+		ts, err = c.session.StepTicks(msg.Ticks)
+	case wsTypeTimeControl:
+		ts, err = c.session.SetTimeControl(millisToDuration(msg.TimestepMs), msg.TimeScale)
+	case wsTypeTimeStatus:
+		ts, err = c.session.TimeStatus()
+	case wsTypeSeek:
+		ts, err = c.session.SeekTo(msg.Tick)
+	}
+
+	if err != nil {
+		return newWSError(msg.Type, err)
+	}
+	return newWSTimeStatus(ts)
+}
+
+// handleRecordingCommand starts, stops or reports the session recorder
+func (c *WSClient) handleRecordingCommand(msg WSMessage) interface{} {
+	var (
+		rs  session.RecordingStatus
+		err error
+	)
+
+	switch msg.Type {
+	case wsTypeStartRecording:
+		rs, err = c.server.startRecording(c.session, msg.Format, msg.Name)
+	case wsTypeStopRecording:
+		rs, err = c.session.StopRecording()
+	case wsTypeRecordingStatus:
+		rs, err = c.session.RecordingStatus()
+	}
+
+	if err != nil {
+		return newWSError(msg.Type, err)
+	}
+	return newWSRecordingStatus(rs)
+}
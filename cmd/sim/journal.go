@@ -0,0 +1,85 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/journal"
+	"github.com/StevenD2002/ninja650sim/internal/scenario"
+	"github.com/StevenD2002/ninja650sim/internal/telemetry"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// runJournal re-simulates a journaled session, optionally with a different ECU
+// setup, and reports how the result differs from the baseline
+func runJournal(journalPath, ecuPath, againstPath, diffPath, outPath, format string) {
+	start := time.Now()
+
+	j, err := journal.Load(journalPath)
+	if err != nil {
+		log.Fatalf("Failed to load journal: %v", err)
+	}
+
+	// Baseline: a recorded log, or the journal re-simulated exactly as it happened
+	var baseline []*pb.EngineData
+	if againstPath != "" {
+		baseline, err = telemetry.ReadFile(againstPath)
+		if err != nil {
+			log.Fatalf("Failed to load telemetry: %v", err)
+		}
+	} else {
+		s, err := j.NewSimulator()
+		if err != nil {
+			log.Fatalf("Failed to set up journal: %v", err)
+		}
+		collected := &telemetry.Collector{}
+		if err := j.Resimulate(s, collected); err != nil {
+			log.Fatalf("Re-simulation failed: %v", err)
+		}
+		baseline = collected.Frames
+	}
+
+	// Candidate: the same inputs on the current engine model with the new tune
+	s, err := j.NewSimulator()
+	if err != nil {
+		log.Fatalf("Failed to set up journal: %v", err)
+	}
+	if ecuPath != "" {
+		setup, err := scenario.LoadECUSetup(ecuPath)
+		if err != nil {
+			log.Fatalf("Failed to load ECU setup: %v", err)
+		}
+		if err := setup.Apply(s); err != nil {
+			log.Fatalf("Failed to apply ECU setup: %v", err)
+		}
+	}
+
+	writer := openOutput(outPath, format)
+	candidate := &telemetry.Collector{}
+	if err := j.Resimulate(s, writer, candidate); err != nil {
+		log.Fatalf("Re-simulation failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		log.Fatalf("Failed to write telemetry: %v", err)
+	}
+
+	log.Printf("Journal %s: %d entries, %d ticks re-simulated in %v",
+		journalPath, len(j.Entries), j.Ticks, time.Since(start))
+
+	// Compare the two traces
+	diffs, compared := telemetry.Diff(baseline, candidate.Frames)
+	telemetry.WriteDiffReport(os.Stderr, diffs, compared)
+
+	if diffPath != "" {
+		f, err := os.Create(diffPath)
+		if err != nil {
+			log.Fatalf("Failed to create diff: %v", err)
+		}
+		defer f.Close()
+
+		if err := telemetry.WriteDiffCSV(f, baseline, candidate.Frames); err != nil {
+			log.Fatalf("Failed to write diff: %v", err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/StevenD2002/ninja650sim/internal/scenario"
+	"github.com/StevenD2002/ninja650sim/internal/telemetry"
+)
+
+func main() {
+	scenarioPath := flag.String("scenario", "", "scenario file to run (JSON)")
+	journalPath := flag.String("journal", "", "input journal to re-simulate instead of a scenario")
+	outPath := flag.String("out", "", "telemetry output file (default stdout)")
+	format := flag.String("format", "", "telemetry format: csv, json, jsonl or bin (default from -out extension, else csv)")
+
+	// Re-simulation options
+	ecuPath := flag.String("ecu", "", "ECU setup (JSON) to load before re-simulating a journal")
+	againstPath := flag.String("against", "", "recorded telemetry to compare the re-simulation with (default: the journal re-simulated unchanged)")
+	diffPath := flag.String("diff", "", "write the per-tick difference between the two traces to this CSV file")
+	flag.Parse()
+
+	if *journalPath != "" {
+		runJournal(*journalPath, *ecuPath, *againstPath, *diffPath, *outPath, *format)
+		return
+	}
+
+	if *scenarioPath == "" && flag.NArg() > 0 {
+		*scenarioPath = flag.Arg(0)
+	}
+	if *scenarioPath == "" {
+		log.Fatalf("Usage: sim -scenario <file> [-out telemetry.csv] [-format csv|json|jsonl|bin]\n" +
+			"       sim -journal <file> [-ecu setup.json] [-against recording.bin] [-diff diff.csv] [-out telemetry.csv]")
+	}
+
+	// Load the scenario
+	sc, err := scenario.Load(*scenarioPath)
+	if err != nil {
+		log.Fatalf("Failed to load scenario: %v", err)
+	}
+
+	writer := openOutput(*outPath, *format)
+
+	// Set up the simulator and the assertions that watch it
+	s, err := sc.NewSimulator()
+	if err != nil {
+		log.Fatalf("Failed to set up scenario: %v", err)
+	}
+	checker := scenario.NewChecker(sc, s)
+
+	// Run headless at full speed
+	result, err := scenario.RunOn(sc, s, writer, checker)
+	if err != nil {
+		log.Fatalf("Scenario failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		log.Fatalf("Failed to write telemetry: %v", err)
+	}
+	checker.Close()
+
+	log.Printf("Scenario %q: %d ticks, %.1fs simulated in %v",
+		sc.Name, result.Ticks, result.SimTime.Seconds(), result.Elapsed)
+
+	// Report assertions and fail the build if any did not hold
+	if len(sc.Assertions) > 0 {
+		scenario.WriteReport(os.Stderr, checker.Results())
+		if !checker.Passed() {
+			os.Exit(1)
+		}
+	}
+}
+
+// openOutput creates the telemetry writer for -out and -format
+func openOutput(outPath, format string) telemetry.Writer {
+	if format == "" && outPath != "" {
+		format = telemetry.FormatFromPath(outPath)
+	}
+	if format == "" {
+		format = telemetry.FormatCSV
+	}
+
+	var (
+		writer telemetry.Writer
+		err    error
+	)
+	if outPath != "" {
+		writer, err = telemetry.CreateFile(outPath, format)
+	} else {
+		writer, err = telemetry.NewWriter(format, os.Stdout)
+	}
+	if err != nil {
+		log.Fatalf("Failed to create telemetry writer: %v", err)
+	}
+
+	return writer
+}
@@ -0,0 +1,149 @@
+package ecu
+
+import (
+	"math"
+)
+
+// ClosedLoop trims fueling from the O2 sensor so the measured lambda follows
+// the target AFR map. The short-term trim reacts within a few cycles; whatever
+// correction it keeps holding is slowly learned into a long-term trim per map
+// cell, which is applied in open loop as well.
+type ClosedLoop struct {
+	Enabled bool
+
+	// PI gains on the lambda error (measured / target - 1)
+	Kp float64 // % trim per unit error
+	Ki float64 // % trim per unit error per second
+
+	// Trim authority, in percent either way
+	STFTLimit float64
+	LTFTLimit float64
+
+	// Fraction of the short-term trim moved into the long-term cell per second
+	LearnRate float64
+
+	// Enable conditions: warm engine, below WOT, steady throttle, valid O2 reading,
+	// and no failsafe holding the loop open
+	MinEngineTemp   float64 // °C
+	MaxThrottle     float64 // %, above this the AFR map is run open loop
+	MaxThrottleRate float64 // %/s, faster changes count as a transient
+	SettleTime      float64 // s of steady state required before closing the loop
+	MinLambda       float64 // O2 readings outside this range are not trusted
+	MaxLambda       float64
+
+	// Long-term fuel trim in percent, on the fuel map axes
+	LTFT Map2D
+
+	// Controller state
+	Active   bool    // Loop currently closed
+	STFT     float64 // Short-term fuel trim, percent
+	LTFTCell float64 // Long-term trim applied this cycle, percent
+
+	integral     float64
+	steadyTime   float64
+	lastThrottle float64
+}
+
+// NewClosedLoop creates an enabled controller with empty long-term trims for the given fuel map
+func NewClosedLoop(fuelMap Map2D) ClosedLoop {
+	return ClosedLoop{
+		Enabled: true,
+
+		Kp: 20.0,
+		Ki: 40.0,
+
+		STFTLimit: 25.0,
+		LTFTLimit: 25.0,
+		LearnRate: 0.2,
+
+		MinEngineTemp:   70.0,
+		MaxThrottle:     85.0,
+		MaxThrottleRate: 40.0,
+		SettleTime:      0.5,
+		MinLambda:       0.6,
+		MaxLambda:       1.6,
+
+		LTFT: emptyMapLike(fuelMap),
+	}
+}
+
+// update runs one controller cycle and returns the total fuel trim to apply, in percent
+func (c *ClosedLoop) update(e *ECU, load, lambdaTarget, dt float64) float64 {
+	// Long-term trim always applies, open loop included
+	c.LTFTCell = c.LTFT.GetValue(e.RPM, load)
+
+	// Track how long the throttle has been steady
+	throttleRate := 0.0
+	if dt > 0 {
+		throttleRate = math.Abs(e.ThrottlePosition-c.lastThrottle) / dt
+	}
+	c.lastThrottle = e.ThrottlePosition
+
+	// Transient corrections upset the mixture on purpose, so wait for them too
+	if throttleRate > c.MaxThrottleRate || e.Transient.Active() {
+		c.steadyTime = 0
+	} else {
+		c.steadyTime += dt
+	}
+
+	if !c.canClose(e, lambdaTarget) {
+		// Open loop: forget the short-term correction
+		c.Active = false
+		c.STFT = 0
+		c.integral = 0
+		return c.LTFTCell
+	}
+	c.Active = true
+
+	// Positive error means lean, which needs more fuel
+	lambdaError := e.O2Reading/lambdaTarget - 1.0
+
+	c.integral += c.Ki * lambdaError * dt
+	c.integral = clamp(c.integral, -c.STFTLimit, c.STFTLimit)
+	c.STFT = clamp(c.Kp*lambdaError+c.integral, -c.STFTLimit, c.STFTLimit)
+
+	// Learn: move part of the held correction into the long-term cells around
+	// the operating point, taking out of the integral whatever they absorbed
+	learned := c.integral * c.LearnRate * dt
+	c.integral -= c.LTFT.AddInterpolated(e.RPM, load, learned, -c.LTFTLimit, c.LTFTLimit)
+
+	return c.STFT + c.LTFTCell
+}
+
+// canClose reports whether the operating point allows closed-loop control
+func (c *ClosedLoop) canClose(e *ECU, lambdaTarget float64) bool {
+	return c.Enabled &&
+		!e.Failsafe.OpenLoop() &&
+		e.EngineTemp >= c.MinEngineTemp &&
+		e.ThrottlePosition <= c.MaxThrottle &&
+		c.steadyTime >= c.SettleTime &&
+		lambdaTarget > 0 &&
+		e.O2Reading >= c.MinLambda && e.O2Reading <= c.MaxLambda
+}
+
+// ResetTrims clears the short- and long-term fuel trims
+func (c *ClosedLoop) ResetTrims() {
+	c.STFT = 0
+	c.integral = 0
+	c.LTFTCell = 0
+	c.LTFT = emptyMapLike(c.LTFT)
+}
+
+// emptyMapLike returns a zero-filled map with the same breakpoints as m
+func emptyMapLike(m Map2D) Map2D {
+	values := make([][]float64, len(m.RPMBreakpoints))
+	for i := range values {
+		values[i] = make([]float64, len(m.LoadBreakpoints))
+	}
+
+	return Map2D{
+		RPMBreakpoints:  append([]float64(nil), m.RPMBreakpoints...),
+		LoadBreakpoints: append([]float64(nil), m.LoadBreakpoints...),
+		Values:          values,
+	}
+}
+
+// clamp limits v to the range [lo, hi]
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
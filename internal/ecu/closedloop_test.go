@@ -0,0 +1,106 @@
+package ecu
+
+import (
+	"math"
+	"testing"
+)
+
+// cruise sets up a warm ECU holding a steady part throttle
+func cruise() *ECU {
+	e := NewECU()
+	e.EngineTemp = 90
+	e.ThrottlePosition = 30
+	e.RPM = 4000
+	return e
+}
+
+// runClosedLoop runs the controller against a fuel system that delivers error
+// more fuel than asked for (negative runs lean), and returns the last trim
+func runClosedLoop(e *ECU, fuelError, seconds float64) float64 {
+	const dt, load, lambdaTarget = 0.05, 30.0, 1.0
+
+	trim := 0.0
+	for t := 0.0; t < seconds; t += dt {
+		e.O2Reading = lambdaTarget / ((1 + fuelError) * (1 + trim/100))
+		trim = e.ClosedLoop.update(e, load, lambdaTarget, dt)
+	}
+	return trim
+}
+
+func TestClosedLoopConverges(t *testing.T) {
+	e := cruise()
+	trim := runClosedLoop(e, -0.08, 60)
+
+	// 8% too little fuel needs about 8.7% more
+	if want := 100 * (1/0.92 - 1); math.Abs(trim-want) > 0.5 {
+		t.Errorf("total trim = %.2f%%, want %.2f%%", trim, want)
+	}
+	if !e.ClosedLoop.Active {
+		t.Error("loop is open at a warm steady cruise")
+	}
+	if lambda := e.O2Reading; math.Abs(lambda-1) > 0.01 {
+		t.Errorf("lambda = %.3f, want 1", lambda)
+	}
+
+	// Given time, the correction moves into the long-term trim
+	if e.ClosedLoop.LTFTCell < 0.8*trim {
+		t.Errorf("long-term trim %.2f%% holds too little of the %.2f%% correction", e.ClosedLoop.LTFTCell, trim)
+	}
+	if math.Abs(e.ClosedLoop.STFT) > 1 {
+		t.Errorf("short-term trim = %.2f%%, want it back near 0", e.ClosedLoop.STFT)
+	}
+}
+
+func TestClosedLoopLimits(t *testing.T) {
+	e := cruise()
+	runClosedLoop(e, -0.35, 120)
+
+	c := &e.ClosedLoop
+	if c.STFT > c.STFTLimit {
+		t.Errorf("short-term trim %.2f%% beyond its %g%% limit", c.STFT, c.STFTLimit)
+	}
+	if c.LTFTCell > c.LTFTLimit+1e-9 {
+		t.Errorf("long-term trim %.2f%% beyond its %g%% limit", c.LTFTCell, c.LTFTLimit)
+	}
+	for _, row := range c.LTFT.Values {
+		for _, v := range row {
+			if math.Abs(v) > c.LTFTLimit+1e-9 {
+				t.Fatalf("long-term trim cell %.2f%% beyond its %g%% limit", v, c.LTFTLimit)
+			}
+		}
+	}
+	if c.STFT < c.STFTLimit-1e-9 || c.LTFTCell < c.LTFTLimit-1e-9 {
+		t.Errorf("trims %.2f%% and %.2f%% should both be pinned at their limits", c.STFT, c.LTFTCell)
+	}
+}
+
+func TestClosedLoopOpensOutsideItsRegion(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(e *ECU)
+	}{
+		{"cold engine", func(e *ECU) { e.EngineTemp = 40 }},
+		{"wide open throttle", func(e *ECU) { e.ThrottlePosition = 100 }},
+		{"disabled", func(e *ECU) { e.ClosedLoop.Enabled = false }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := cruise()
+			tt.modify(e)
+			runClosedLoop(e, -0.1, 5)
+
+			if e.ClosedLoop.Active || e.ClosedLoop.STFT != 0 {
+				t.Errorf("loop active %v with a short-term trim of %.2f%%, want it open", e.ClosedLoop.Active, e.ClosedLoop.STFT)
+			}
+		})
+	}
+
+	// A learned trim still applies with the loop open
+	e := cruise()
+	runClosedLoop(e, -0.1, 60)
+	e.EngineTemp = 40
+	if trim := runClosedLoop(e, -0.1, 1); trim < 5 {
+		t.Errorf("open-loop trim = %.2f%%, want the learned long-term trim", trim)
+	}
+}
@@ -0,0 +1,78 @@
+package ecu
+
+import (
+	"math"
+
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+)
+
+// Compensation table types, addressed like the maps
+const (
+	MapTypeIATFuel     = "iat_fuel"     // Fuel correction by intake air temperature, %
+	MapTypeIATIgnition = "iat_ignition" // Ignition correction by intake air temperature, degrees
+	MapTypeBaroFuel    = "baro_fuel"    // Fuel correction by barometric pressure, %
+	MapTypeDeadTime    = "dead_time"    // Injector dead time by battery voltage, ms
+)
+
+// Compensation corrects the maps for conditions they were not calibrated in.
+// Alpha-N estimates airflow from throttle position at 25°C and sea level, so
+// its fuel needs correcting for air density; speed-density measures density
+// through MAP and IAT and skips the fuel corrections. The injector opens
+// slower on a low battery, and the dead time is added to every pulse.
+type Compensation struct {
+	IATFuel     Map1D // % fuel by intake air temperature, °C
+	IATIgnition Map1D // Degrees added by intake air temperature, °C
+	BaroFuel    Map1D // % fuel by barometric pressure, kPa
+	DeadTime    Map1D // Injector dead time in ms by battery voltage
+
+	// Corrections applied this cycle
+	IATFuelCorrection     float64 // %
+	BaroFuelCorrection    float64 // %
+	IATIgnitionCorrection float64 // Degrees
+	InjectorDeadTime      float64 // ms
+}
+
+// NewCompensation creates compensation tables for the stock air path and injectors
+func NewCompensation() Compensation {
+	return Compensation{
+		IATFuel: Map1D{
+			Breakpoints: []float64{-20, -10, 0, 10, 20, 30, 40, 50, 60},
+			Values:      []float64{8.5, 6.4, 4.5, 2.6, 0.8, -0.8, -2.4, -3.9, -5.4},
+		},
+		IATIgnition: Map1D{
+			Breakpoints: []float64{-20, -10, 0, 10, 20, 30, 40, 50, 60},
+			Values:      []float64{0, 0, 0, 0, 0, 0, -1, -2, -3}, // Hot air knocks sooner
+		},
+		BaroFuel: Map1D{
+			Breakpoints: []float64{50, 60, 70, 80, 90, 100, 110},
+			Values:      []float64{-50.6, -40.8, -30.9, -21.0, -11.2, -1.3, 8.6},
+		},
+		DeadTime: deadTimeTable(engine.DefaultNinja650Injector()),
+	}
+}
+
+// deadTimeTable creates a dead-time table from an injector's data sheet
+func deadTimeTable(inj engine.Injector) Map1D {
+	voltages := []float64{8, 9, 10, 11, 12, 13, 14, 15, 16}
+	values := make([]float64, len(voltages))
+	for i, v := range voltages {
+		values[i] = math.Round(inj.DeadTimeAt(v)*1000) / 1000
+	}
+	return Map1D{Breakpoints: voltages, Values: values}
+}
+
+// update looks up the corrections for the current sensor readings
+func (c *Compensation) update(e *ECU) {
+	// Only the Alpha-N share of the air estimate needs density corrections
+	alphaN := e.alphaNWeight()
+
+	c.IATFuelCorrection = alphaN * c.IATFuel.GetValue(e.AirTemp)
+	c.BaroFuelCorrection = alphaN * c.BaroFuel.GetValue(e.Baro)
+	c.IATIgnitionCorrection = c.IATIgnition.GetValue(e.AirTemp)
+	c.InjectorDeadTime = c.DeadTime.GetValue(e.BatteryVoltage)
+}
+
+// FuelMultiplier returns the air density corrections as a multiplier on fuel
+func (c *Compensation) FuelMultiplier() float64 {
+	return (1.0 + c.IATFuelCorrection/100.0) * (1.0 + c.BaroFuelCorrection/100.0)
+}
@@ -0,0 +1,95 @@
+package ecu
+
+import (
+	"math"
+	"testing"
+
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+)
+
+func TestCompensationLookups(t *testing.T) {
+	e := NewECU()
+	e.AirTemp = 45
+	e.Baro = 85
+	e.BatteryVoltage = 12.5
+
+	c := &e.Compensation
+	c.update(e)
+
+	// Alpha-N takes the full density corrections
+	want := map[string][2]float64{
+		"IAT fuel":     {c.IATFuelCorrection, -3.15},
+		"baro fuel":    {c.BaroFuelCorrection, -16.1},
+		"IAT ignition": {c.IATIgnitionCorrection, -1.5},
+		"dead time":    {c.InjectorDeadTime, (c.DeadTime.GetValue(12) + c.DeadTime.GetValue(13)) / 2},
+	}
+	for name, v := range want {
+		if math.Abs(v[0]-v[1]) > 1e-9 {
+			t.Errorf("%s correction = %g, want %g", name, v[0], v[1])
+		}
+	}
+	if want := (1 - 0.0315) * (1 - 0.161); math.Abs(c.FuelMultiplier()-want) > 1e-9 {
+		t.Errorf("fuel multiplier = %g, want %g", c.FuelMultiplier(), want)
+	}
+}
+
+func TestCompensationFollowsLoadModel(t *testing.T) {
+	e := NewECU()
+	e.AirTemp = 45
+	e.Baro = 85
+	e.ThrottlePosition = 50
+
+	// Speed-density measures the air, so only the ignition correction stays
+	e.LoadModel = LoadModelSpeedDensity
+	e.Compensation.update(e)
+	if c := e.Compensation; c.IATFuelCorrection != 0 || c.BaroFuelCorrection != 0 || c.IATIgnitionCorrection != -1.5 {
+		t.Errorf("speed-density corrections: %+v", c)
+	}
+
+	// Blended takes the Alpha-N share
+	e.LoadModel = LoadModelBlended
+	e.Compensation.update(e)
+	if got := e.Compensation.BaroFuelCorrection; math.Abs(got-0.5*-16.1) > 1e-9 {
+		t.Errorf("blended baro correction at 50%% throttle = %g, want %g", got, 0.5*-16.1)
+	}
+}
+
+func TestDeadTimeRisesOnALowBattery(t *testing.T) {
+	c := NewCompensation()
+
+	// Shorter the higher the voltage, levelling off above 15 V
+	previous := math.Inf(1)
+	for v := 8.0; v <= 16; v++ {
+		deadTime := c.DeadTime.GetValue(v)
+		if deadTime <= 0 || deadTime > previous || (v <= 15 && deadTime == previous) {
+			t.Errorf("dead time at %g V = %g ms after %g ms at %g V", v, deadTime, previous, v-1)
+		}
+		previous = deadTime
+	}
+}
+
+func TestPulseWidthFollowsBatteryVoltage(t *testing.T) {
+	for _, v := range []float64{10, 12, 14} {
+		e := NewECU()
+		out := e.ProcessSensorData(engine.SensorData{
+			RPM:               5000,
+			ThrottlePosition:  30,
+			AirTemperature:    25,
+			EngineTemperature: 90,
+			MAP:               60,
+			O2:                1,
+			Baro:              101.3,
+			BatteryVoltage:    v,
+			Gear:              3,
+			Speed:             80,
+		})
+		if e.FuelMass <= 0 {
+			t.Fatalf("at %g V: no fuel asked for", v)
+		}
+
+		// The engine's injector, opening slower on a low battery, delivers what was asked for
+		if got := e.FuelSystem.DeliveredFuel(out.FuelInjectionTime, v); math.Abs(got-e.FuelMass) > 1e-3*e.FuelSystem.MassFlowRate() {
+			t.Errorf("at %g V: %g ms delivered %g mg, want %g", v, out.FuelInjectionTime, got, e.FuelMass)
+		}
+	}
+}
@@ -12,8 +12,10 @@ const (
 	MaximumAdvance = 45.0 // Maximum safe ignition advance (degrees BTDC)
 
 	// Engine constants
-	MinimumRPM = 800.0   // Minimum stable idle RPM
-	MaximumRPM = 12000.0 // Absolute maximum RPM (hardware limit)
+	MinimumRPM   = 800.0   // Minimum stable idle RPM
+	MaximumRPM   = 12000.0 // Absolute maximum RPM (hardware limit)
+	Displacement = 649.0   // cc, both cylinders
+	Cylinders    = 2
 
 	// Temperature ranges
 	MinEngineTemp = 20.0  // Minimum expected engine temperature (°C)
@@ -65,7 +67,8 @@ type TuningPreset struct {
 	Notes        string
 }
 
-// Sensor limits define the normal operating ranges for sensors
+// Sensor limits define the operating ranges for sensors. A reading outside
+// Min-Max can only come from a faulty sensor and sets a trouble code.
 var SensorLimits = map[string]SensorRange{
 	"RPM": {
 		Min:       0.0,
@@ -84,7 +87,7 @@ var SensorLimits = map[string]SensorRange{
 		Precision: 1,
 	},
 	"EngineTemp": {
-		Min:       0.0,
+		Min:       -40.0,
 		Max:       150.0,
 		LowWarn:   60.0,
 		HighWarn:  110.0,
@@ -100,13 +103,29 @@ var SensorLimits = map[string]SensorRange{
 		Precision: 1,
 	},
 	"O2": {
-		Min:       0.7,
-		Max:       1.3,
+		Min:       0.5,
+		Max:       3.1, // Plain air during a fuel cut
 		LowWarn:   0.8,
 		HighWarn:  1.1,
 		Unit:      "λ",
 		Precision: 2,
 	},
+	"AirTemp": {
+		Min:       -40.0,
+		Max:       120.0,
+		LowWarn:   -10.0,
+		HighWarn:  50.0,
+		Unit:      "°C",
+		Precision: 1,
+	},
+	"BatteryVoltage": {
+		Min:       10.0,
+		Max:       16.0,
+		LowWarn:   12.0,
+		HighWarn:  15.0,
+		Unit:      "V",
+		Precision: 1,
+	},
 }
 
 // SensorRange defines the operating range for a sensor
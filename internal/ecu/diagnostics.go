@@ -0,0 +1,367 @@
+package ecu
+
+import (
+	"math"
+	"sort"
+)
+
+// Trouble code statuses
+const (
+	DTCPending   = "pending"   // Fault seen, not confirmed yet
+	DTCConfirmed = "confirmed" // Fault lasted or came back; lights the FI lamp while present
+)
+
+// FreezeFrame is a snapshot of the sensors taken when a trouble code is first set
+type FreezeFrame struct {
+	Timestamp        int64   `json:"timestamp"` // Unix nanoseconds
+	RPM              float64 `json:"rpm"`
+	ThrottlePosition float64 `json:"throttle_position"`
+	EngineTemp       float64 `json:"engine_temp"`
+	AirTemp          float64 `json:"air_temp"`
+	MAP              float64 `json:"map"`
+	O2               float64 `json:"o2"`
+	Baro             float64 `json:"baro"`
+	BatteryVoltage   float64 `json:"battery_voltage"`
+	Speed            float64 `json:"speed"`
+	Gear             int     `json:"gear"`
+	Load             float64 `json:"load"`
+	STFT             float64 `json:"stft"`
+	LTFT             float64 `json:"ltft"`
+}
+
+// DTC is a stored diagnostic trouble code
+type DTC struct {
+	Code        string      `json:"code"`
+	Description string      `json:"description"`
+	Status      string      `json:"status"`      // DTCPending or DTCConfirmed
+	Active      bool        `json:"active"`      // Fault present this cycle
+	Occurrences int         `json:"occurrences"` // Times the fault has been set
+	FirstSeen   int64       `json:"first_seen"`  // Unix nanoseconds
+	LastSeen    int64       `json:"last_seen"`   // Unix nanoseconds
+	FreezeFrame FreezeFrame `json:"freeze_frame"`
+}
+
+// Diagnostics watches the sensors for faults, stores trouble codes with a
+// freeze frame, and lights the FI lamp while a confirmed fault is present.
+// A fault has to last Debounce before it sets a pending code; shorter faults
+// count as glitches, and enough of them set an intermittent code. A pending
+// code is confirmed once the fault has lasted ConfirmTime more, or comes back
+// before the code heals. Confirmed codes are kept until they are cleared.
+type Diagnostics struct {
+	Enabled bool
+
+	Debounce           float64 // s an out-of-range reading has to last to set a pending code
+	ConfirmTime        float64 // s more a fault has to last to be confirmed
+	HealTime           float64 // s without the fault before a pending code is dropped
+	IntermittentWindow float64 // s glitches are counted over
+	IntermittentCount  int     // Glitches in the window that set an intermittent code
+
+	Lamp bool // FI warning lamp
+
+	monitors []*monitor
+	codes    map[string]*DTC
+	time     float64 // s since the ECU started
+}
+
+// monitor checks one fault condition
+type monitor struct {
+	code        string
+	description string
+	debounce    float64 // s the fault has to last; 0 sets the code at once
+	check       func(e *ECU, m *monitor) (fault, enabled bool)
+
+	faultFor float64   // s the fault has been present
+	okFor    float64   // s since the fault was last present
+	glitches []float64 // Times of faults that cleared before the debounce
+	last     float64   // Previous reading, for stuck sensor checks
+}
+
+// NewDiagnostics creates the diagnostics with the stock set of monitors
+func NewDiagnostics() Diagnostics {
+	d := Diagnostics{
+		Enabled: true,
+
+		Debounce:           0.5,
+		ConfirmTime:        3.0,
+		HealTime:           10.0,
+		IntermittentWindow: 10.0,
+		IntermittentCount:  3,
+
+		codes: make(map[string]*DTC),
+	}
+
+	throttle := func(e *ECU) float64 { return e.ThrottlePosition }
+	manifold := func(e *ECU) float64 { return e.MAP }
+	coolant := func(e *ECU) float64 { return e.EngineTemp }
+	intake := func(e *ECU) float64 { return e.AirTemp }
+	oxygen := func(e *ECU) float64 { return e.O2Reading }
+	battery := func(e *ECU) float64 { return e.BatteryVoltage }
+	crank := func(e *ECU) float64 { return e.RPM }
+
+	// The O2 sensor only reads the mixture once the engine runs on its own
+	running := func(e *ECU) bool { return e.RPM >= MinimumRPM/2 }
+
+	// Readings outside the sensor's range
+	d.addRange("ThrottlePosition", throttle, nil, "P0122", "Throttle position sensor circuit low", "P0123", "Throttle position sensor circuit high")
+	d.addRange("MAP", manifold, nil, "P0107", "Manifold pressure sensor circuit low", "P0108", "Manifold pressure sensor circuit high")
+	// Thermistor and O2 voltages fall as the reading rises, so a reading below
+	// range is a circuit high and one above range a circuit low
+	d.addRange("EngineTemp", coolant, nil, "P0118", "Engine temperature sensor circuit high", "P0117", "Engine temperature sensor circuit low")
+	d.addRange("AirTemp", intake, nil, "P0113", "Intake air temperature sensor circuit high", "P0112", "Intake air temperature sensor circuit low")
+	d.addRange("O2", oxygen, running, "P0132", "O2 sensor circuit high", "P0131", "O2 sensor circuit low")
+	d.addRange("BatteryVoltage", battery, nil, "P0562", "System voltage low", "P0563", "System voltage high")
+	d.add(&monitor{
+		code: "P0336", description: "Crankshaft position sensor range/performance", debounce: d.Debounce,
+		check: func(e *ECU, m *monitor) (bool, bool) {
+			return crank(e) > SensorLimits["RPM"].Max, true
+		},
+	})
+	d.add(&monitor{
+		code: "P0335", description: "Crankshaft position sensor circuit", debounce: d.Debounce,
+		check: func(e *ECU, m *monitor) (bool, bool) {
+			// No crank signal while the manifold pulls vacuum, which only a
+			// turning engine can do
+			return crank(e) == 0 && e.MAP > 0 && e.MAP < 0.8*e.Baro, true
+		},
+	})
+
+	// Readings that stop moving while they should
+	d.add(&monitor{
+		code: "P0134", description: "O2 sensor no activity", debounce: 5.0,
+		check: stuck(oxygen, 1e-4, running),
+	})
+	d.add(&monitor{
+		code: "P0116", description: "Engine temperature sensor range/performance", debounce: 30.0,
+		check: stuck(coolant, 1e-6, func(e *ECU) bool { return e.RPM > 0 && e.EngineTemp < 70 }),
+	})
+
+	// Readings that disagree with each other
+	d.add(&monitor{
+		code: "P0106", description: "Manifold pressure/barometric pressure range/performance", debounce: 1.0,
+		check: func(e *ECU, m *monitor) (bool, bool) {
+			// Above the air outside, or off the air outside with the engine stopped
+			return e.MAP > e.Baro+10 || (e.RPM == 0 && math.Abs(e.MAP-e.Baro) > 10), true
+		},
+	})
+	d.add(&monitor{
+		code: "P0121", description: "Throttle position sensor range/performance", debounce: 1.0,
+		check: func(e *ECU, m *monitor) (bool, bool) {
+			// An open throttle with deep vacuum, or a closed one with none
+			open := e.ThrottlePosition > 60 && e.RPM > 2500 && e.MAP < 0.5*e.Baro
+			closed := e.ThrottlePosition < 1 && e.RPM > 3000 && e.MAP > 0.9*e.Baro
+			return open || closed, true
+		},
+	})
+
+	// Range faults that come and go too quickly to set a code
+	d.addIntermittent("P0124", "Throttle position sensor circuit intermittent", "P0122", "P0123")
+	d.addIntermittent("P0109", "Manifold pressure sensor circuit intermittent", "P0107", "P0108")
+	d.addIntermittent("P0119", "Engine temperature sensor circuit intermittent", "P0117", "P0118")
+	d.addIntermittent("P0114", "Intake air temperature sensor circuit intermittent", "P0112", "P0113")
+
+	return d
+}
+
+// add registers a monitor
+func (d *Diagnostics) add(m *monitor) {
+	d.monitors = append(d.monitors, m)
+}
+
+// addRange registers low and high monitors for a sensor against its
+// SensorLimits range, checked whenever enabled is nil or returns true
+func (d *Diagnostics) addRange(sensor string, read func(e *ECU) float64, enabled func(e *ECU) bool, lowCode, lowDescription, highCode, highDescription string) {
+	limits := SensorLimits[sensor]
+	isEnabled := func(e *ECU) bool { return enabled == nil || enabled(e) }
+
+	d.add(&monitor{
+		code: lowCode, description: lowDescription, debounce: d.Debounce,
+		check: func(e *ECU, m *monitor) (bool, bool) { return read(e) < limits.Min, isEnabled(e) },
+	})
+	d.add(&monitor{
+		code: highCode, description: highDescription, debounce: d.Debounce,
+		check: func(e *ECU, m *monitor) (bool, bool) { return read(e) > limits.Max, isEnabled(e) },
+	})
+}
+
+// addIntermittent registers a monitor that counts the glitches of other monitors
+func (d *Diagnostics) addIntermittent(code, description string, sources ...string) {
+	d.add(&monitor{
+		code: code, description: description,
+		check: func(e *ECU, m *monitor) (bool, bool) {
+			return e.Diagnostics.glitchCount(sources) >= e.Diagnostics.IntermittentCount, true
+		},
+	})
+}
+
+// stuck returns a check for a reading that has not moved by more than
+// tolerance since the last cycle, while enabled says it should be moving
+func stuck(read func(e *ECU) float64, tolerance float64, enabled func(e *ECU) bool) func(e *ECU, m *monitor) (bool, bool) {
+	return func(e *ECU, m *monitor) (bool, bool) {
+		value := read(e)
+		unchanged := math.Abs(value-m.last) <= tolerance
+		m.last = value
+		return unchanged, enabled(e)
+	}
+}
+
+// glitchCount returns how many glitches the monitors for the given codes had in the window
+func (d *Diagnostics) glitchCount(codes []string) int {
+	count := 0
+	for _, m := range d.monitors {
+		for _, code := range codes {
+			if m.code == code {
+				count += len(m.glitches)
+			}
+		}
+	}
+	return count
+}
+
+// update runs every monitor for this cycle and sets or heals codes
+func (d *Diagnostics) update(e *ECU, dt float64) {
+	if !d.Enabled {
+		d.Lamp = false
+		return
+	}
+	d.time += dt
+
+	for _, m := range d.monitors {
+		// Forget glitches that have left the window
+		for len(m.glitches) > 0 && d.time-m.glitches[0] > d.IntermittentWindow {
+			m.glitches = m.glitches[1:]
+		}
+
+		fault, enabled := m.check(e, m)
+		if !enabled {
+			m.faultFor = 0
+			continue
+		}
+
+		if fault {
+			m.faultFor += dt
+			m.okFor = 0
+			if m.faultFor >= m.debounce {
+				d.set(e, m)
+			}
+			continue
+		}
+
+		if m.faultFor > 0 && m.faultFor < m.debounce {
+			m.glitches = append(m.glitches, d.time)
+		}
+		m.faultFor = 0
+		m.okFor += dt
+		d.heal(m)
+	}
+
+	d.Lamp = false
+	for _, code := range d.codes {
+		if code.Status == DTCConfirmed && code.Active {
+			d.Lamp = true
+		}
+	}
+}
+
+// set stores or refreshes the code for a monitor whose fault has lasted its debounce
+func (d *Diagnostics) set(e *ECU, m *monitor) {
+	now := e.lastUpdateTime.UnixNano()
+
+	code, ok := d.codes[m.code]
+	switch {
+	case !ok:
+		code = &DTC{
+			Code:        m.code,
+			Description: m.description,
+			Status:      DTCPending,
+			Occurrences: 1,
+			FirstSeen:   now,
+			FreezeFrame: e.freezeFrame(),
+		}
+		d.codes[m.code] = code
+	case !code.Active:
+		// The fault came back
+		code.Occurrences++
+		code.Status = DTCConfirmed
+	}
+
+	code.Active = true
+	code.LastSeen = now
+	if m.faultFor >= m.debounce+d.ConfirmTime {
+		code.Status = DTCConfirmed
+	}
+}
+
+// heal marks a monitor's code inactive, dropping it once a pending fault has
+// stayed away for HealTime
+func (d *Diagnostics) heal(m *monitor) {
+	code, ok := d.codes[m.code]
+	if !ok {
+		return
+	}
+
+	code.Active = false
+	if code.Status == DTCPending && m.okFor >= d.HealTime {
+		delete(d.codes, m.code)
+	}
+}
+
+// Codes returns a copy of the stored trouble codes, sorted by code
+func (d *Diagnostics) Codes() []DTC {
+	codes := make([]DTC, 0, len(d.codes))
+	for _, code := range d.codes {
+		codes = append(codes, *code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return codes
+}
+
+// active reports whether the fault behind any of the given codes is present
+func (d *Diagnostics) active(codes ...string) bool {
+	if !d.Enabled {
+		return false
+	}
+	for _, c := range codes {
+		if code, ok := d.codes[c]; ok && code.Active {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of stored trouble codes
+func (d *Diagnostics) Count() int {
+	return len(d.codes)
+}
+
+// Clear erases every stored code and turns the FI lamp off. Faults still
+// present set their codes again.
+func (d *Diagnostics) Clear() {
+	d.codes = make(map[string]*DTC)
+	for _, m := range d.monitors {
+		m.faultFor = 0
+		m.okFor = 0
+		m.glitches = nil
+	}
+	d.Lamp = false
+}
+
+// freezeFrame captures the sensors as read this cycle, with the load and fuel
+// trims worked out on the last one
+func (e *ECU) freezeFrame() FreezeFrame {
+	return FreezeFrame{
+		Timestamp:        e.lastUpdateTime.UnixNano(),
+		RPM:              e.RPM,
+		ThrottlePosition: e.ThrottlePosition,
+		EngineTemp:       e.EngineTemp,
+		AirTemp:          e.AirTemp,
+		MAP:              e.MAP,
+		O2:               e.O2Reading,
+		Baro:             e.Baro,
+		BatteryVoltage:   e.BatteryVoltage,
+		Speed:            e.Speed,
+		Gear:             e.Gear,
+		Load:             e.Load,
+		STFT:             e.ClosedLoop.STFT,
+		LTFT:             e.ClosedLoop.LTFTCell,
+	}
+}
@@ -0,0 +1,192 @@
+package ecu
+
+import (
+	"testing"
+	"time"
+)
+
+// diagStep is the cycle time of the diagnostics tests, exact in binary so the
+// debounce and confirm times land on a cycle
+const diagStep = 0.25
+
+// healthyECU returns an ECU with every sensor reading a warm engine at a
+// steady part throttle
+func healthyECU() *ECU {
+	e := NewECU()
+	e.RPM = 3000
+	e.ThrottlePosition = 20
+	e.MAP = 60
+	e.Baro = 101.3
+	e.EngineTemp = 90
+	e.AirTemp = 25
+	e.O2Reading = 1
+	e.BatteryVoltage = 14
+	e.lastUpdateTime = time.Unix(1000, 0)
+	return e
+}
+
+// runDiagnostics runs the diagnostics for n cycles, moving the clock and
+// keeping the O2 sensor switching as a healthy one does
+func runDiagnostics(e *ECU, n int) {
+	for range n {
+		e.lastUpdateTime = e.lastUpdateTime.Add(time.Duration(diagStep * float64(time.Second)))
+		if e.O2Reading == 1 {
+			e.O2Reading = 1.01
+		} else if e.O2Reading == 1.01 {
+			e.O2Reading = 1
+		}
+		e.Diagnostics.update(e, diagStep)
+	}
+}
+
+// findDTC returns the stored trouble code, or nil if it is not set
+func findDTC(e *ECU, c string) *DTC {
+	for _, dtc := range e.Diagnostics.Codes() {
+		if dtc.Code == c {
+			return &dtc
+		}
+	}
+	return nil
+}
+
+func TestDTCSetsAndConfirms(t *testing.T) {
+	e := healthyECU()
+	d := &e.Diagnostics
+
+	runDiagnostics(e, 40)
+	if d.Count() != 0 || d.Lamp {
+		t.Fatalf("healthy engine set %v", d.Codes())
+	}
+
+	// Throttle sensor above its range: nothing until the debounce has passed
+	e.ThrottlePosition = 120
+	runDiagnostics(e, 1)
+	if d.Count() != 0 {
+		t.Fatalf("code set before the debounce: %v", d.Codes())
+	}
+	runDiagnostics(e, 1)
+	setAt := e.lastUpdateTime.UnixNano()
+
+	dtc := findDTC(e, "P0123")
+	if dtc == nil || dtc.Status != DTCPending || !dtc.Active || dtc.Occurrences != 1 || d.Lamp {
+		t.Fatalf("after the debounce: %+v, lamp %v; want an active pending P0123 with the lamp off", dtc, d.Lamp)
+	}
+	if d.Count() != 1 {
+		t.Errorf("codes = %v, want only P0123", d.Codes())
+	}
+
+	// The freeze frame holds the readings when it was set
+	ff := dtc.FreezeFrame
+	if ff.Timestamp != setAt || dtc.FirstSeen != setAt || ff.ThrottlePosition != 120 || ff.RPM != 3000 || ff.MAP != 60 || ff.EngineTemp != 90 {
+		t.Errorf("freeze frame = %+v, want the readings at %d", ff, setAt)
+	}
+
+	// Confirmed, lighting the lamp, once it has lasted ConfirmTime more
+	runDiagnostics(e, int(d.ConfirmTime/diagStep)-1)
+	if dtc := findDTC(e, "P0123"); dtc.Status != DTCPending {
+		t.Fatalf("confirmed early: %+v", dtc)
+	}
+	runDiagnostics(e, 1)
+	if dtc := findDTC(e, "P0123"); dtc.Status != DTCConfirmed || !d.Lamp {
+		t.Fatalf("after the confirm time: %+v, lamp %v", dtc, d.Lamp)
+	}
+
+	// Fixed: the lamp goes out, but the code and its freeze frame stay
+	e.ThrottlePosition = 20
+	e.RPM = 5000
+	runDiagnostics(e, int(d.HealTime/diagStep)+4)
+	dtc = findDTC(e, "P0123")
+	if dtc == nil || dtc.Active || dtc.Status != DTCConfirmed || d.Lamp {
+		t.Fatalf("after the fault went: %+v, lamp %v", dtc, d.Lamp)
+	}
+	if dtc.FreezeFrame != ff {
+		t.Errorf("freeze frame changed to %+v", dtc.FreezeFrame)
+	}
+}
+
+func TestPendingDTCHealsOrConfirms(t *testing.T) {
+	e := healthyECU()
+	d := &e.Diagnostics
+
+	// A pending code dropped once the fault stays away for HealTime
+	e.BatteryVoltage = 9
+	runDiagnostics(e, 2)
+	e.BatteryVoltage = 14
+	runDiagnostics(e, int(d.HealTime/diagStep)-1)
+	if dtc := findDTC(e, "P0562"); dtc == nil || dtc.Active {
+		t.Fatalf("before the heal time: %+v", dtc)
+	}
+	runDiagnostics(e, 1)
+	if dtc := findDTC(e, "P0562"); dtc != nil {
+		t.Fatalf("pending code kept after the heal time: %+v", dtc)
+	}
+
+	// Coming back before then confirms it
+	e.BatteryVoltage = 9
+	runDiagnostics(e, 2)
+	e.BatteryVoltage = 14
+	runDiagnostics(e, 4)
+	e.BatteryVoltage = 9
+	runDiagnostics(e, 2)
+	if dtc := findDTC(e, "P0562"); dtc == nil || dtc.Status != DTCConfirmed || dtc.Occurrences != 2 || !d.Lamp {
+		t.Errorf("fault came back: %+v, lamp %v; want confirmed after 2 occurrences", dtc, d.Lamp)
+	}
+}
+
+func TestIntermittentDTC(t *testing.T) {
+	e := healthyECU()
+	d := &e.Diagnostics
+
+	// Glitches shorter than the debounce set no range code, but enough of them
+	// in the window set the intermittent one
+	for i := range d.IntermittentCount {
+		e.ThrottlePosition = -5
+		runDiagnostics(e, 1)
+		e.ThrottlePosition = 20
+		runDiagnostics(e, 4)
+
+		if dtc := findDTC(e, "P0124"); (dtc != nil) != (i == d.IntermittentCount-1) {
+			t.Fatalf("after %d glitches: P0124 %+v", i+1, dtc)
+		}
+	}
+	if findDTC(e, "P0122") != nil {
+		t.Error("glitches set the range code")
+	}
+}
+
+func TestClearDTCs(t *testing.T) {
+	e := healthyECU()
+	d := &e.Diagnostics
+
+	e.EngineTemp = 200
+	runDiagnostics(e, 20)
+	if findDTC(e, "P0117") == nil || !d.Lamp {
+		t.Fatalf("codes %v lamp %v, want P0117 confirmed", d.Codes(), d.Lamp)
+	}
+
+	d.Clear()
+	if d.Count() != 0 || d.Lamp {
+		t.Fatalf("after clearing: codes %v lamp %v", d.Codes(), d.Lamp)
+	}
+
+	// A fault still present sets its code again after a fresh debounce
+	runDiagnostics(e, 1)
+	if d.Count() != 0 {
+		t.Errorf("code set again without the debounce: %v", d.Codes())
+	}
+	runDiagnostics(e, 1)
+	if dtc := findDTC(e, "P0117"); dtc == nil || dtc.Status != DTCPending || dtc.Occurrences != 1 {
+		t.Errorf("code after clearing = %+v, want a new pending P0117", dtc)
+	}
+}
+
+func TestDiagnosticsDisabled(t *testing.T) {
+	e := healthyECU()
+	e.Diagnostics.Enabled = false
+	e.ThrottlePosition = 120
+
+	runDiagnostics(e, 40)
+	if e.Diagnostics.Count() != 0 || e.Diagnostics.Lamp || e.Diagnostics.active("P0123") {
+		t.Errorf("disabled diagnostics set %v", e.Diagnostics.Codes())
+	}
+}
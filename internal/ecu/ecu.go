@@ -1,6 +1,8 @@
 package ecu
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"time"
 
@@ -14,19 +16,64 @@ type ECU struct {
 	IgnitionMap  IgnitionMap
 	TargetAFRMap AFRMap
 
+	// Optional 3D versions of the maps, indexed by gear or engine temperature;
+	// while one is enabled it is read instead of its 2D map
+	FuelMap3D     Map3D
+	IgnitionMap3D Map3D
+	AFRMap3D      Map3D
+
 	// ECU Settings
-	IdleRPM  float64
-	RevLimit float64
+	IdleRPM   float64
+	RevLimit  float64
+	LoadModel string // How load is worked out for map lookups, one of the LoadModel constants
 
 	// Tuning parameters
+	Preset       string  // Name of the last applied TuningPreset, empty if none
 	FuelTrim     float64 // Global fuel adjustment (-100% to +100%)
 	IgnitionTrim float64 // Global ignition adjustment (-10 to +10 degrees)
 
+	// Description of the tune last loaded, zero for the stock calibration
+	TuneInfo TuneInfo
+
 	// Exhaust settings
 	ExhaustType string
 
-	// Engine temperature compensation
+	// Injector data pulse widths are worked out from
+	FuelSystem engine.FuelSystem
+
+	// Corrections for intake air temperature, barometric pressure and battery voltage
+	Compensation Compensation
+
+	// Engine temperature compensation, using the warm-up tables
 	TempCompensation bool
+	WarmUp           WarmUp
+
+	// Throttle body calibration, used to estimate airflow from throttle position
+	Intake engine.Intake
+
+	// Closed-loop lambda control and learned fuel trims
+	ClosedLoop ClosedLoop
+
+	// Knock detection and learned ignition retard
+	KnockControl KnockControl
+
+	// Acceleration enrichment, wall wetting compensation and decel fuel cut
+	Transient TransientFueling
+
+	// Rev limiter strategy, per-gear limits and launch control
+	RevLimiter RevLimiter
+
+	// Trouble codes and the FI lamp
+	Diagnostics Diagnostics
+
+	// Overheat protection, sensor substitution and limp mode
+	Failsafe Failsafe
+
+	// Load used for the last map lookups, percent
+	Load float64
+
+	// Fuel asked of each injection in the last cycle, mg
+	FuelMass float64
 
 	// Statistics for analysis
 	KnockCount   int
@@ -39,27 +86,75 @@ type ECU struct {
 	AirTemp          float64
 	MAP              float64
 	O2Reading        float64
+	KnockIntensity   float64
+	Baro             float64 // kPa
+	BatteryVoltage   float64 // V
+	Speed            float64 // km/h
+	Gear             int     // 0 = neutral
+	ClutchPosition   float64 // 0 = engaged, 1 = pulled in
 
 	// Last update time for internal timing
 	lastUpdateTime time.Time
+	deltaTime      float64 // Seconds since the previous sensor update
+}
+
+// ErrInvalidSettings is returned for settings with a value out of range
+var ErrInvalidSettings = errors.New("invalid ECU settings")
+
+// Settings holds the user adjustable ECU parameters
+type Settings struct {
+	FuelTrim         float64 `json:"fuel_trim"`
+	IgnitionTrim     float64 `json:"ignition_trim"`
+	IdleRPM          float64 `json:"idle_rpm"`
+	RevLimit         float64 `json:"rev_limit"`
+	TempCompensation bool    `json:"temp_compensation"`
+	LoadModel        string  `json:"load_model,omitempty"` // Empty keeps the current model
+
+	RevLimiter    string    `json:"rev_limiter,omitempty"`     // Empty keeps the current strategy
+	GearRevLimits []float64 `json:"gear_rev_limits,omitempty"` // Per gear from first; nil keeps the current limits, 0 uses RevLimit
+	LaunchControl bool      `json:"launch_control"`
+	LaunchRPM     float64   `json:"launch_rpm,omitempty"` // 0 keeps the current launch limit
+
+	// Third axis of the 3D maps, one of the Axis3D constants; empty keeps the
+	// current axis and a new axis starts from a copy of the 2D map
+	FuelMapAxis     string `json:"fuel_map_axis,omitempty"`
+	IgnitionMapAxis string `json:"ignition_map_axis,omitempty"`
+	AFRMapAxis      string `json:"afr_map_axis,omitempty"`
 }
 
 // NewECU creates a new ECU with default maps for a Ninja 650
 func NewECU() *ECU {
+	fuelMap := DefaultNinja650FuelMap()
+	ignitionMap := DefaultNinja650IgnitionMap()
+
 	return &ECU{
-		FuelMap:      DefaultNinja650FuelMap(),
-		IgnitionMap:  DefaultNinja650IgnitionMap(),
+		FuelMap:      fuelMap,
+		IgnitionMap:  ignitionMap,
 		TargetAFRMap: DefaultNinja650AFRMap(),
 
-		IdleRPM:  900,
-		RevLimit: 11000,
+		IdleRPM:   900,
+		RevLimit:  11000,
+		LoadModel: LoadModelAlphaN,
 
 		FuelTrim:     0.0, // No adjustment
 		IgnitionTrim: 0.0, // No adjustment
 
 		ExhaustType: "Yoshimura Alpha 2",
 
+		FuelSystem:   engine.DefaultNinja650FuelSystem(),
+		Compensation: NewCompensation(),
+
 		TempCompensation: true,
+		WarmUp:           NewWarmUp(),
+
+		Intake: engine.DefaultNinja650Intake(),
+
+		ClosedLoop:   NewClosedLoop(fuelMap.Map2D),
+		KnockControl: NewKnockControl(ignitionMap.Map2D),
+		Transient:    NewTransientFueling(),
+		RevLimiter:   NewRevLimiter(),
+		Diagnostics:  NewDiagnostics(),
+		Failsafe:     NewFailsafe(),
 
 		KnockCount:   0,
 		AFRDeviation: 0.0,
@@ -70,9 +165,269 @@ func NewECU() *ECU {
 		AirTemp:          0.0,
 		MAP:              0.0,
 		O2Reading:        0.0,
+		KnockIntensity:   0.0,
+	}
+}
+
+// Settings returns a copy of the current ECU settings
+func (e *ECU) Settings() Settings {
+	return Settings{
+		FuelTrim:         e.FuelTrim,
+		IgnitionTrim:     e.IgnitionTrim,
+		IdleRPM:          e.IdleRPM,
+		RevLimit:         e.RevLimit,
+		TempCompensation: e.TempCompensation,
+		LoadModel:        e.LoadModel,
+
+		RevLimiter:    e.RevLimiter.Strategy,
+		GearRevLimits: append([]float64(nil), e.RevLimiter.GearLimits...),
+		LaunchControl: e.RevLimiter.LaunchControl,
+		LaunchRPM:     e.RevLimiter.LaunchRPM,
+
+		FuelMapAxis:     mapAxis(&e.FuelMap3D),
+		IgnitionMapAxis: mapAxis(&e.IgnitionMap3D),
+		AFRMapAxis:      mapAxis(&e.AFRMap3D),
+	}
+}
+
+// ApplySettings replaces the ECU settings. Nothing changes if the load model,
+// rev limiter strategy or a 3D map axis is unknown, or a value is out of range.
+func (e *ECU) ApplySettings(settings Settings) error {
+	if err := e.checkSettings(settings); err != nil {
+		return err
+	}
+	if settings.LoadModel != "" && !validLoadModel(settings.LoadModel) {
+		return fmt.Errorf("%w %q", ErrUnknownLoadModel, settings.LoadModel)
+	}
+	if settings.RevLimiter != "" && !validLimiter(settings.RevLimiter) {
+		return fmt.Errorf("%w %q", ErrUnknownLimiter, settings.RevLimiter)
+	}
+	for _, axis := range []string{settings.FuelMapAxis, settings.IgnitionMapAxis, settings.AFRMapAxis} {
+		if axis != "" && !validAxis3D(axis) {
+			return fmt.Errorf("%w %q", ErrUnknownAxis, axis)
+		}
+	}
+
+	e.FuelTrim = settings.FuelTrim
+	e.IgnitionTrim = settings.IgnitionTrim
+	e.IdleRPM = settings.IdleRPM
+	e.RevLimit = settings.RevLimit
+	e.TempCompensation = settings.TempCompensation
+	if settings.LoadModel != "" {
+		e.LoadModel = settings.LoadModel
+	}
+
+	if settings.RevLimiter != "" {
+		e.RevLimiter.Strategy = settings.RevLimiter
+	}
+	if settings.GearRevLimits != nil {
+		e.RevLimiter.GearLimits = append([]float64(nil), settings.GearRevLimits...)
+	}
+	e.RevLimiter.LaunchControl = settings.LaunchControl
+	if settings.LaunchRPM > 0 {
+		e.RevLimiter.LaunchRPM = settings.LaunchRPM
+	}
+
+	setMapAxis(&e.FuelMap3D, settings.FuelMapAxis, e.FuelMap.Map2D)
+	setMapAxis(&e.IgnitionMap3D, settings.IgnitionMapAxis, e.IgnitionMap.Map2D)
+	setMapAxis(&e.AFRMap3D, settings.AFRMapAxis, e.TargetAFRMap.Map2D)
+	return nil
+}
+
+// checkSettings checks the numbers in settings: the trims have to leave some
+// fuel, and the rev limit, per-gear limits and launch limit must sit between
+// the idle speed and, for the launch limit, the rev limit
+func (e *ECU) checkSettings(settings Settings) error {
+	for _, v := range []float64{settings.FuelTrim, settings.IgnitionTrim, settings.IdleRPM, settings.RevLimit, settings.LaunchRPM} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%w: %g is not a finite value", ErrInvalidSettings, v)
+		}
+	}
+	if settings.FuelTrim <= -100 {
+		return fmt.Errorf("%w: fuel trim %g%% leaves no fuel", ErrInvalidSettings, settings.FuelTrim)
+	}
+	if settings.IdleRPM <= 0 {
+		return fmt.Errorf("%w: idle speed %g RPM must be positive", ErrInvalidSettings, settings.IdleRPM)
+	}
+	if settings.RevLimit <= settings.IdleRPM {
+		return fmt.Errorf("%w: rev limit %g RPM must be above the idle speed of %g RPM", ErrInvalidSettings, settings.RevLimit, settings.IdleRPM)
+	}
+
+	for i, limit := range settings.GearRevLimits {
+		if math.IsNaN(limit) || math.IsInf(limit, 0) || limit < 0 || (limit > 0 && limit <= settings.IdleRPM) {
+			return fmt.Errorf("%w: gear %d rev limit %g RPM must be 0 or above the idle speed", ErrInvalidSettings, i+1, limit)
+		}
+	}
+
+	launchRPM := e.RevLimiter.LaunchRPM
+	if settings.LaunchRPM != 0 {
+		launchRPM = settings.LaunchRPM
+	}
+	if settings.LaunchRPM < 0 || (settings.LaunchControl && (launchRPM <= settings.IdleRPM || launchRPM > settings.RevLimit)) {
+		return fmt.Errorf("%w: launch limit %g RPM must be between the idle speed and the rev limit", ErrInvalidSettings, launchRPM)
+	}
+	return nil
+}
 
-		lastUpdateTime: time.Now(),
+// mapAxis returns the third axis of a 3D map, or Axis3DNone while it is off
+func mapAxis(m *Map3D) string {
+	if !m.Enabled() {
+		return Axis3DNone
 	}
+	return m.Axis
+}
+
+// setMapAxis switches a 3D map to the given axis. An empty or unchanged axis
+// keeps the map; a new axis starts again from a copy of the 2D map.
+func setMapAxis(m *Map3D, axis string, base Map2D) {
+	switch {
+	case axis == "" || axis == mapAxis(m):
+		return
+	case axis == Axis3DNone:
+		*m = Map3D{}
+	default:
+		*m, _ = NewMap3D(axis, base)
+	}
+}
+
+// RescaleForInjector switches the ECU's injector data to a new injector so the
+// tune keeps the same mixture once it is fitted. Pulse widths follow from the
+// new flow rate, and the dead-time table is scaled by the new dead time, keeping
+// any edits to its shape. The fuel maps correct the fuel mass, not the pulse
+// width, and carry over unchanged.
+func (e *ECU) RescaleForInjector(inj engine.Injector) error {
+	if err := inj.Validate(); err != nil {
+		return err
+	}
+
+	old := e.FuelSystem.Injector
+	if old.DeadTime > 0 {
+		for i := range e.Compensation.DeadTime.Values {
+			e.Compensation.DeadTime.Values[i] *= inj.DeadTime / old.DeadTime
+		}
+	} else {
+		e.Compensation.DeadTime = deadTimeTable(inj)
+	}
+
+	e.FuelSystem.Injector = inj
+	return nil
+}
+
+// ApplyPreset loads the trims from one of the TuningPresets
+func (e *ECU) ApplyPreset(name string) error {
+	preset, ok := TuningPresets[name]
+	if !ok {
+		return fmt.Errorf("unknown tuning preset %q", name)
+	}
+
+	e.Preset = name
+	e.FuelTrim = preset.FuelTrim
+	e.IgnitionTrim = preset.IgnitionTrim
+	return nil
+}
+
+// Map returns the 2D map with the given type name
+func (e *ECU) Map(mapType string) (*Map2D, error) {
+	switch mapType {
+	case MapTypeFuel:
+		return &e.FuelMap.Map2D, nil
+	case MapTypeIgnition:
+		return &e.IgnitionMap.Map2D, nil
+	case MapTypeAFR:
+		return &e.TargetAFRMap.Map2D, nil
+	case MapTypeLTFT:
+		return &e.ClosedLoop.LTFT, nil
+	case MapTypeKnock:
+		return &e.KnockControl.Learned, nil
+	default:
+		return nil, ErrUnknownMap
+	}
+}
+
+// Map3D returns the 3D map with the given type name, whether or not it is enabled
+func (e *ECU) Map3D(mapType string) (*Map3D, error) {
+	switch mapType {
+	case MapTypeFuel3D:
+		return &e.FuelMap3D, nil
+	case MapTypeIgnition3D:
+		return &e.IgnitionMap3D, nil
+	case MapTypeAFR3D:
+		return &e.AFRMap3D, nil
+	default:
+		return nil, ErrUnknownMap
+	}
+}
+
+// SetMap3DValue sets the cell nearest to rpm and load in the layer of the
+// named 3D map nearest to z. Values the map cannot hold are refused.
+func (e *ECU) SetMap3DValue(mapType string, z, rpm, load, value float64) error {
+	m, err := e.Map3D(mapType)
+	if err != nil {
+		return err
+	}
+	if !m.Enabled() {
+		return ErrMap3DOff
+	}
+	if err := checkCells(mapType, []float64{value}); err != nil {
+		return err
+	}
+
+	m.SetValue(z, rpm, load, value)
+	return nil
+}
+
+// Table returns the 1D table with the given type name
+func (e *ECU) Table(mapType string) (*Map1D, error) {
+	switch mapType {
+	case MapTypeWarmup:
+		return &e.WarmUp.Enrichment, nil
+	case MapTypeAfterStart:
+		return &e.WarmUp.AfterStart, nil
+	case MapTypeColdRetard:
+		return &e.WarmUp.IgnitionRetard, nil
+	case MapTypeColdIdleRPM:
+		return &e.WarmUp.IdleRPM, nil
+	case MapTypeIATFuel:
+		return &e.Compensation.IATFuel, nil
+	case MapTypeIATIgnition:
+		return &e.Compensation.IATIgnition, nil
+	case MapTypeBaroFuel:
+		return &e.Compensation.BaroFuel, nil
+	case MapTypeDeadTime:
+		return &e.Compensation.DeadTime, nil
+	default:
+		return nil, ErrUnknownMap
+	}
+}
+
+// SetTableValue sets the entry nearest to x in the named 1D table. Values the
+// table cannot hold are refused.
+func (e *ECU) SetTableValue(mapType string, x, value float64) error {
+	t, err := e.Table(mapType)
+	if err != nil {
+		return err
+	}
+	if err := checkCells(mapType, []float64{value}); err != nil {
+		return err
+	}
+
+	t.SetValue(x, value)
+	return nil
+}
+
+// SetMapValue sets the cell nearest to rpm and load in the named map. Values
+// the map cannot hold, such as NaN or a zero AFR target, are refused.
+func (e *ECU) SetMapValue(mapType string, rpm, load, value float64) error {
+	m, err := e.Map(mapType)
+	if err != nil {
+		return err
+	}
+	if err := checkCells(mapType, []float64{value}); err != nil {
+		return err
+	}
+
+	m.SetValue(rpm, load, value)
+	return nil
 }
 
 // UpdateSensors updates the ECU's internal sensor readings from engine state
@@ -83,6 +438,20 @@ func (e *ECU) UpdateSensors(engineState engine.SensorData) {
 	e.AirTemp = engineState.AirTemperature
 	e.MAP = engineState.MAP
 	e.O2Reading = engineState.O2
+	e.KnockIntensity = engineState.KnockIntensity
+	e.Baro = engineState.Baro
+	e.BatteryVoltage = engineState.BatteryVoltage
+	e.Speed = engineState.Speed
+	e.Gear = engineState.Gear
+	e.ClutchPosition = engineState.ClutchPosition
+
+	// Follow the engine's clock rather than the wall clock so runs are reproducible
+	now := time.Unix(0, engineState.Timestamp)
+	e.deltaTime = 0
+	if !e.lastUpdateTime.IsZero() {
+		e.deltaTime = math.Max(0, now.Sub(e.lastUpdateTime).Seconds())
+	}
+	e.lastUpdateTime = now
 }
 
 // ProcessSensorData processes current sensor readings and returns ECU outputs
@@ -90,32 +459,37 @@ func (e *ECU) ProcessSensorData(sensors engine.SensorData) engine.ECUOutputs {
 	// Update internal sensor state
 	e.UpdateSensors(sensors)
 
-	// Calculate load (simplified - using throttle position as load)
-	load := e.ThrottlePosition
+	// Check the raw readings for faults, then stand in for any sensor that failed
+	e.Diagnostics.update(e, e.deltaTime)
+	e.Failsafe.substitute(e)
+	e.Failsafe.protect(e)
+
+	// Work out load with the selected model; it is the load axis of every map
+	load := e.calculateLoad()
+	e.Load = load
 
 	// Get base values from maps
-	baseFuel := e.FuelMap.GetValue(e.RPM, load)
-	baseIgnition := e.IgnitionMap.GetValue(e.RPM, load)
-	targetAFR := e.TargetAFRMap.GetValue(e.RPM, load)
+	baseFuel := e.lookup(&e.FuelMap.Map2D, &e.FuelMap3D, load)
+	baseIgnition := e.lookup(&e.IgnitionMap.Map2D, &e.IgnitionMap3D, load)
+	targetAFR := e.lookup(&e.TargetAFRMap.Map2D, &e.AFRMap3D, load)
 
 	// Apply global trims
 	fuelMultiplier := baseFuel * (1.0 + (e.FuelTrim / 100.0))
 	ignitionAdjusted := baseIgnition + e.IgnitionTrim
 
-	// Apply temperature compensation if enabled
-	if e.TempCompensation {
-		// Cold engine needs more fuel
-		if e.EngineTemp < 80.0 {
-			coldFactor := 1.0 + (80.0-e.EngineTemp)*0.01
-			fuelMultiplier *= coldFactor
-		}
+	// Cold engine needs more fuel and less timing advance
+	e.WarmUp.update(e, e.deltaTime)
+	fuelMultiplier *= e.WarmUp.FuelMultiplier()
+	ignitionAdjusted -= e.WarmUp.Retard
 
-		// Cold engine needs less timing advance
-		if e.EngineTemp < 60.0 {
-			coldRetard := (60.0 - e.EngineTemp) * 0.1
-			ignitionAdjusted -= coldRetard
-		}
-	}
+	// Correct for air density and battery voltage
+	e.Compensation.update(e)
+	fuelMultiplier *= e.Compensation.FuelMultiplier()
+	ignitionAdjusted += e.Compensation.IATIgnitionCorrection
+
+	// Protect an overheating engine
+	fuelMultiplier *= e.Failsafe.FuelMultiplier()
+	ignitionAdjusted -= e.Failsafe.Retard
 
 	// Apply modifications for aftermarket exhaust
 	if e.ExhaustType != "Stock" {
@@ -125,89 +499,63 @@ func (e *ECU) ProcessSensorData(sensors engine.SensorData) engine.ECUOutputs {
 		}
 	}
 
-	// Calculate final fuel injection time (ms)
-	// This is simplified - real ECUs use complex algorithms
-	// Base injection time (arbitrary value for simulation)
-	baseInjectionTime := 2.5 // ms at stoichiometric mixture, 100% VE
+	// Convert target AFR to lambda (lambda = AFR / 14.7 for gasoline)
+	lambdaTarget := targetAFR / StoichiometricAFR
 
-	// Adjust for RPM and load
-	// Higher RPM = less time for injection
-	rpmFactor := math.Sqrt(5000.0 / math.Max(1000.0, e.RPM))
+	// Correct fueling from the O2 sensor
+	fuelMultiplier *= 1.0 + e.ClosedLoop.update(e, load, lambdaTarget, e.deltaTime)/100.0
 
-	// Calculate volumetric efficiency (simplified)
-	volumetricEfficiency := calculateVolumetricEfficiency(e.RPM, load)
+	// Calculate final fuel injection time (ms): fuel the estimated air charge
+	// for the target AFR, with the fuel map and trims correcting the estimate
+	fuelMass := e.estimateAirMass() / targetAFR * fuelMultiplier // mg
 
-	// Calculate final injection time
-	fuelInjectionTime := baseInjectionTime * volumetricEfficiency * fuelMultiplier * rpmFactor
+	// Correct for the throttle moving: enrichment, wall film and fuel cut
+	fuelMass = e.Transient.update(e, fuelMass, e.deltaTime)
+	e.FuelMass = fuelMass
 
-	// Convert target AFR to lambda (lambda = AFR / 14.7 for gasoline)
-	lambdaTarget := targetAFR / 14.7
+	// Convert to a pulse width; the injector only flows once it has opened
+	fuelInjectionTime := 0.0
+	if fuelMass > 0 {
+		fuelInjectionTime = fuelMass/e.FuelSystem.MassFlowRate() + e.Compensation.InjectorDeadTime
+	}
 
 	// Calculate AFR deviation for statistics
 	currentAFR := 14.7 * e.O2Reading // Convert lambda to AFR
 	e.AFRDeviation = math.Abs(currentAFR - targetAFR)
 
-	// Check for potential knock conditions (simplified)
-	knockRisk := checkKnockRisk(e.RPM, load, ignitionAdjusted, e.EngineTemp)
-	if knockRisk > 0.7 {
-		// Reduce timing if knock risk is high
-		ignitionAdjusted -= (knockRisk - 0.7) * 10.0
-		e.KnockCount++
-	}
+	// Pull timing if the knock sensor heard detonation
+	ignitionAdjusted -= e.KnockControl.update(e, load, e.KnockIntensity, e.deltaTime)
+
+	// Hold the engine under the rev limit in force
+	e.RevLimiter.update(e)
+	ignitionAdjusted -= e.RevLimiter.Retard
 
 	// Create and return ECU outputs
 	return engine.ECUOutputs{
 		FuelInjectionTime: fuelInjectionTime,
 		IgnitionAdvance:   ignitionAdjusted,
-		TargetIdleRPM:     e.IdleRPM,
+		TargetIdleRPM:     e.WarmUp.IdleTarget,
 		LambdaTarget:      lambdaTarget,
+		SparkCut:          e.RevLimiter.SparkCut,
+		FuelCut:           math.Max(e.RevLimiter.FuelCut, e.Failsafe.FuelCut),
 	}
 }
 
-// calculateVolumetricEfficiency calculates how efficiently the engine breathes
-// This is a simplified model - real engines have complex VE curves
-func calculateVolumetricEfficiency(rpm, load float64) float64 {
-	// Baseline efficiency
-	baseVE := 0.85
-
-	// VE peaks in mid-RPM range for most engines
-	rpmFactor := 1.0 - 0.3*math.Pow((rpm-5500)/5500, 2)
-
-	// VE generally increases with load (throttle opening)
-	loadFactor := 0.6 + 0.4*(load/100.0)
-
-	return baseVE * rpmFactor * loadFactor
-}
-
-// checkKnockRisk evaluates the risk of engine knock
-func checkKnockRisk(rpm, load, ignitionAdvance, engineTemp float64) float64 {
-	// Higher load = higher knock risk
-	loadFactor := math.Pow(load/100.0, 2)
-
-	// Higher RPM generally means less knock risk up to a point
-	rpmFactor := 0.0
-	if rpm < 2500 {
-		rpmFactor = 0.5 - 0.5*(rpm/2500.0) // Higher risk at very low RPM
-	} else if rpm > 7500 {
-		rpmFactor = (rpm - 7500) / 3500 // Higher risk at very high RPM
-	}
-
-	// More ignition advance = more knock risk
-	timingFactor := 0.0
-	if ignitionAdvance > 30 {
-		timingFactor = (ignitionAdvance - 30) / 10.0
+// lookup reads a map at the current RPM and load, through its 3D version when
+// that is enabled
+func (e *ECU) lookup(m *Map2D, m3 *Map3D, load float64) float64 {
+	if !m3.Enabled() {
+		return m.GetValue(e.RPM, load)
 	}
 
-	// Higher engine temp = more knock risk
-	tempFactor := 0.0
-	if engineTemp > 90 {
-		tempFactor = (engineTemp - 90) / 30.0
+	var z float64
+	switch m3.Axis {
+	case Axis3DGear:
+		z = float64(e.Gear)
+	case Axis3DEngineTemp:
+		z = e.EngineTemp
 	}
-
-	// Combine factors (weighted)
-	knockRisk := 0.5*loadFactor + 0.15*rpmFactor + 0.25*timingFactor + 0.1*tempFactor
-
-	return math.Min(1.0, math.Max(0.0, knockRisk))
+	return m3.GetValue(z, e.RPM, load)
 }
 
 // ResetStatistics resets the ECU statistics
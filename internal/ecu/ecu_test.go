@@ -0,0 +1,99 @@
+package ecu
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSetValueRejectsUnusable(t *testing.T) {
+	tests := []struct {
+		mapType string
+		value   float64
+	}{
+		{MapTypeFuel, math.NaN()},
+		{MapTypeIgnition, math.Inf(1)},
+		{MapTypeAFR, 0},
+		{MapTypeAFR, -14.7},
+		{MapTypeAFR3D, 0},
+		{MapTypeWarmup, math.Inf(-1)},
+		{MapTypeWarmup, -100},
+		{MapTypeIATFuel, -150},
+		{MapTypeColdIdleRPM, 0},
+	}
+
+	for _, tt := range tests {
+		e := NewECU()
+		settings := e.Settings()
+		settings.AFRMapAxis = Axis3DGear
+		if err := e.ApplySettings(settings); err != nil {
+			t.Fatal(err)
+		}
+
+		var err error
+		switch {
+		case tt.mapType == MapTypeAFR3D:
+			err = e.SetMap3DValue(tt.mapType, 2, 4000, 50, tt.value)
+		case tt.mapType == MapTypeWarmup || tt.mapType == MapTypeIATFuel || tt.mapType == MapTypeColdIdleRPM:
+			err = e.SetTableValue(tt.mapType, 20, tt.value)
+		default:
+			err = e.SetMapValue(tt.mapType, 4000, 50, tt.value)
+		}
+		if !errors.Is(err, ErrInvalidMap) {
+			t.Errorf("set %s to %g: got %v, want %v", tt.mapType, tt.value, err, ErrInvalidMap)
+		}
+	}
+
+	// Corrections may still take fuel away, just not all of it
+	e := NewECU()
+	if err := e.SetTableValue(MapTypeIATFuel, 60, -20); err != nil {
+		t.Errorf("set a negative IAT fuel correction: %v", err)
+	}
+	if err := e.SetMapValue(MapTypeAFR, 4000, 50, 12.5); err != nil {
+		t.Errorf("set an AFR target: %v", err)
+	}
+}
+
+func TestApplySettingsRejectsOutOfRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Settings)
+	}{
+		{"zero rev limit", func(s *Settings) { s.RevLimit = 0 }},
+		{"rev limit below idle", func(s *Settings) { s.RevLimit = 800 }},
+		{"NaN rev limit", func(s *Settings) { s.RevLimit = math.NaN() }},
+		{"negative idle", func(s *Settings) { s.IdleRPM = -900 }},
+		{"fuel trim removing all fuel", func(s *Settings) { s.FuelTrim = -100 }},
+		{"infinite ignition trim", func(s *Settings) { s.IgnitionTrim = math.Inf(1) }},
+		{"negative gear limit", func(s *Settings) { s.GearRevLimits = []float64{9000, -1} }},
+		{"gear limit below idle", func(s *Settings) { s.GearRevLimits = []float64{500} }},
+		{"negative launch limit", func(s *Settings) { s.LaunchRPM = -6000 }},
+		{"launch limit above the rev limit", func(s *Settings) { s.LaunchControl, s.LaunchRPM = true, 12000 }},
+		{"kept launch limit above a new rev limit", func(s *Settings) { s.LaunchControl, s.RevLimit = true, 5000 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewECU()
+			before := e.Settings()
+			settings := e.Settings()
+			tt.modify(&settings)
+
+			if err := e.ApplySettings(settings); !errors.Is(err, ErrInvalidSettings) {
+				t.Fatalf("got %v, want %v", err, ErrInvalidSettings)
+			}
+			if after := e.Settings(); !reflect.DeepEqual(after, before) {
+				t.Errorf("refused settings changed the ECU: %+v", after)
+			}
+		})
+	}
+
+	e := NewECU()
+	settings := e.Settings()
+	settings.GearRevLimits = []float64{8000, 0, 9500}
+	settings.LaunchControl, settings.LaunchRPM = true, 7000
+	if err := e.ApplySettings(settings); err != nil {
+		t.Errorf("apply usable limits: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package ecu
+
+import (
+	"strings"
+)
+
+// Failsafe strategies, as reported in telemetry
+const (
+	FailsafeOverheat    = "overheat"     // Power reduced to bring the engine temperature down
+	FailsafeCylinderCut = "cylinder_cut" // One cylinder cut to protect an overheating engine
+	FailsafeTPS         = "tps_default"  // Throttle position replaced by its default
+	FailsafeMAP         = "map_default"  // Manifold pressure replaced by its default
+	FailsafeECT         = "ect_default"  // Engine temperature replaced by its default
+	FailsafeIAT         = "iat_default"  // Intake air temperature replaced by its default
+	FailsafeOpenLoop    = "open_loop"    // O2 sensor ignored, fueling from the maps alone
+	FailsafeLimp        = "limp"         // Rev limit lowered to get the rider home
+)
+
+// Failsafe keeps the engine running, and in one piece, when it overheats or a
+// sensor fails. A sensor counts as failed while its reading is out of range or
+// one of its trouble codes is active; its reading is then replaced by a
+// default, and fueling moves to a load model that does not need it. An O2
+// failure drops fueling to open loop. Overheating first richens the mixture
+// and pulls timing, then cuts a cylinder. Limp mode lowers the rev limit while
+// a critical sensor has failed or a cylinder is cut.
+type Failsafe struct {
+	Enabled bool
+
+	// Readings substituted for failed sensors
+	DefaultTPS        float64 // %
+	DefaultMAP        float64 // kPa
+	DefaultEngineTemp float64 // °C
+	DefaultAirTemp    float64 // °C
+
+	// Overheat protection
+	OverheatTemp       float64 // °C at which power reduction starts
+	CylinderCutTemp    float64 // °C at which a cylinder is cut
+	CoolDown           float64 // °C the engine has to cool below CylinderCutTemp before the cut ends
+	OverheatEnrichment float64 // % extra fuel at CylinderCutTemp, to cool the combustion chamber
+	OverheatRetard     float64 // Degrees pulled at CylinderCutTemp
+
+	// Rev limit in limp mode
+	LimpRevLimit float64
+
+	// Failsafe state
+	TPSFailed, MAPFailed, ECTFailed, IATFailed, O2Failed bool
+
+	Overheat    float64 // How far into overheat protection the engine is, 0-1
+	CylinderCut bool    // One cylinder cut
+	Limp        bool    // Limp mode rev limit in force
+	Enrichment  float64 // % extra fuel this cycle
+	Retard      float64 // Degrees pulled this cycle
+	FuelCut     float64 // Share of injections cut, 0-1
+}
+
+// NewFailsafe creates enabled failsafe strategies for the stock engine
+func NewFailsafe() Failsafe {
+	return Failsafe{
+		Enabled: true,
+
+		DefaultTPS:        10.0,
+		DefaultMAP:        60.0,
+		DefaultEngineTemp: 80.0,
+		DefaultAirTemp:    25.0,
+
+		OverheatTemp:       MaxEngineTemp - 10.0,
+		CylinderCutTemp:    MaxEngineTemp,
+		CoolDown:           5.0,
+		OverheatEnrichment: 10.0,
+		OverheatRetard:     8.0,
+
+		LimpRevLimit: 5000.0,
+	}
+}
+
+// substitute checks the sensors and replaces the readings of any that have
+// failed with their defaults. Diagnostics must already have seen the raw
+// readings this cycle.
+func (f *Failsafe) substitute(e *ECU) {
+	if !f.Enabled {
+		f.TPSFailed, f.MAPFailed, f.ECTFailed, f.IATFailed, f.O2Failed = false, false, false, false, false
+		return
+	}
+
+	d := &e.Diagnostics
+	f.TPSFailed = failed(e.ThrottlePosition, "ThrottlePosition") || d.active("P0121", "P0122", "P0123", "P0124")
+	f.MAPFailed = failed(e.MAP, "MAP") || d.active("P0106", "P0107", "P0108", "P0109")
+	f.ECTFailed = failed(e.EngineTemp, "EngineTemp") || d.active("P0116", "P0117", "P0118", "P0119")
+	f.IATFailed = failed(e.AirTemp, "AirTemp") || d.active("P0112", "P0113", "P0114")
+	f.O2Failed = d.active("P0131", "P0132", "P0134")
+
+	if f.TPSFailed {
+		e.ThrottlePosition = f.DefaultTPS
+	}
+	if f.MAPFailed {
+		e.MAP = f.DefaultMAP
+	}
+	if f.ECTFailed {
+		e.EngineTemp = f.DefaultEngineTemp
+	}
+	if f.IATFailed {
+		e.AirTemp = f.DefaultAirTemp
+	}
+}
+
+// failed reports whether a reading is outside its sensor's range
+func failed(value float64, sensor string) bool {
+	limits := SensorLimits[sensor]
+	return value < limits.Min || value > limits.Max
+}
+
+// loadModel returns the load model to fuel with: the selected one, unless it
+// relies on a failed sensor and the other one still works
+func (f *Failsafe) loadModel(selected string) string {
+	switch {
+	case !f.Enabled:
+		return selected
+	case f.MAPFailed:
+		// With both sensors gone Alpha-N runs on the default throttle position
+		return LoadModelAlphaN
+	case f.TPSFailed:
+		return LoadModelSpeedDensity
+	default:
+		return selected
+	}
+}
+
+// protect works out the overheat protection and limp mode for this cycle
+func (f *Failsafe) protect(e *ECU) {
+	f.Enrichment = 0
+	f.Retard = 0
+	f.FuelCut = 0
+	f.Overheat = 0
+	f.Limp = false
+	if !f.Enabled {
+		f.CylinderCut = false
+		return
+	}
+
+	// Reduce power progressively through the overheat range
+	if f.CylinderCutTemp > f.OverheatTemp {
+		f.Overheat = clamp((e.EngineTemp-f.OverheatTemp)/(f.CylinderCutTemp-f.OverheatTemp), 0, 1)
+	}
+	f.Enrichment = f.OverheatEnrichment * f.Overheat
+	f.Retard = f.OverheatRetard * f.Overheat
+
+	// Cut a cylinder at the limit and keep it cut until the engine has cooled
+	if e.EngineTemp >= f.CylinderCutTemp {
+		f.CylinderCut = true
+	} else if e.EngineTemp < f.CylinderCutTemp-f.CoolDown {
+		f.CylinderCut = false
+	}
+	if f.CylinderCut {
+		f.FuelCut = 1.0 / Cylinders
+	}
+
+	f.Limp = f.CylinderCut || f.TPSFailed || f.MAPFailed || f.ECTFailed
+}
+
+// OpenLoop reports whether closed-loop fueling has to stay open: the O2 sensor
+// has failed, or a cut cylinder pumps air past it
+func (f *Failsafe) OpenLoop() bool {
+	return f.Enabled && (f.O2Failed || f.CylinderCut)
+}
+
+// Active lists the failsafe strategies in force, comma separated
+func (f *Failsafe) Active() string {
+	var modes []string
+	add := func(on bool, mode string) {
+		if on {
+			modes = append(modes, mode)
+		}
+	}
+
+	add(f.Overheat > 0, FailsafeOverheat)
+	add(f.CylinderCut, FailsafeCylinderCut)
+	add(f.TPSFailed, FailsafeTPS)
+	add(f.MAPFailed, FailsafeMAP)
+	add(f.ECTFailed, FailsafeECT)
+	add(f.IATFailed, FailsafeIAT)
+	add(f.OpenLoop(), FailsafeOpenLoop)
+	add(f.Limp, FailsafeLimp)
+	return strings.Join(modes, ",")
+}
+
+// FuelMultiplier returns the overheat enrichment as a multiplier on fuel
+func (f *Failsafe) FuelMultiplier() float64 {
+	return 1.0 + f.Enrichment/100.0
+}
+
+// limit lowers a rev limit to the limp mode limit when that is lower
+func (f *Failsafe) limit(revLimit float64) float64 {
+	if !f.Limp || (revLimit > 0 && revLimit < f.LimpRevLimit) {
+		return revLimit
+	}
+	return f.LimpRevLimit
+}
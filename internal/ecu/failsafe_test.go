@@ -0,0 +1,181 @@
+package ecu
+
+import (
+	"testing"
+)
+
+func TestFailsafeSubstitutesFailedSensors(t *testing.T) {
+	tests := []struct {
+		name      string
+		fail      func(e *ECU)
+		failed    func(f *Failsafe) bool
+		read      func(e *ECU) float64
+		want      float64
+		loadModel string
+	}{
+		{
+			"TPS", func(e *ECU) { e.ThrottlePosition = 120 },
+			func(f *Failsafe) bool { return f.TPSFailed },
+			func(e *ECU) float64 { return e.ThrottlePosition }, 10, LoadModelSpeedDensity,
+		},
+		{
+			"MAP", func(e *ECU) { e.MAP = -5 },
+			func(f *Failsafe) bool { return f.MAPFailed },
+			func(e *ECU) float64 { return e.MAP }, 60, LoadModelAlphaN,
+		},
+		{
+			"ECT", func(e *ECU) { e.EngineTemp = 200 },
+			func(f *Failsafe) bool { return f.ECTFailed },
+			func(e *ECU) float64 { return e.EngineTemp }, 80, LoadModelBlended,
+		},
+		{
+			"IAT", func(e *ECU) { e.AirTemp = -60 },
+			func(f *Failsafe) bool { return f.IATFailed },
+			func(e *ECU) float64 { return e.AirTemp }, 25, LoadModelBlended,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := healthyECU()
+			e.LoadModel = LoadModelBlended
+			tt.fail(e)
+
+			e.Failsafe.substitute(e)
+			if !tt.failed(&e.Failsafe) {
+				t.Fatal("sensor not marked failed")
+			}
+			if got := tt.read(e); got != tt.want {
+				t.Errorf("substituted reading = %g, want %g", got, tt.want)
+			}
+			if got := e.activeLoadModel(); got != tt.loadModel {
+				t.Errorf("load model = %q, want %q", got, tt.loadModel)
+			}
+		})
+	}
+
+	// Healthy sensors are left alone
+	e := healthyECU()
+	e.Failsafe.substitute(e)
+	if e.Failsafe.Active() != "" || e.ThrottlePosition != 20 || e.MAP != 60 {
+		t.Errorf("healthy engine: failsafe %q, tps %g, map %g", e.Failsafe.Active(), e.ThrottlePosition, e.MAP)
+	}
+}
+
+func TestFailsafeFollowsTroubleCodes(t *testing.T) {
+	e := healthyECU()
+
+	// Wide open throttle with deep vacuum: the reading is in range, but P0121
+	// says it cannot be trusted
+	e.ThrottlePosition = 70
+	e.MAP = 40
+	runDiagnostics(e, 5)
+	if findDTC(e, "P0121") == nil {
+		t.Fatalf("codes %v, want P0121", e.Diagnostics.Codes())
+	}
+
+	e.Failsafe.substitute(e)
+	if !e.Failsafe.TPSFailed || e.ThrottlePosition != e.Failsafe.DefaultTPS {
+		t.Errorf("TPS failed %v reading %g, want the default %g", e.Failsafe.TPSFailed, e.ThrottlePosition, e.Failsafe.DefaultTPS)
+	}
+}
+
+func TestFailsafeOpenLoopOnO2Failure(t *testing.T) {
+	e := cruise()
+	runClosedLoop(e, -0.08, 10)
+	if !e.ClosedLoop.Active {
+		t.Fatal("loop did not close")
+	}
+
+	// An O2 sensor reading rich past its range sets P0131
+	e.O2Reading = 0.3
+	e.RPM = 3000
+	runDiagnostics(e, 2)
+	e.Failsafe.substitute(e)
+	if !e.Failsafe.O2Failed || !e.Failsafe.OpenLoop() {
+		t.Fatalf("O2 failed %v open loop %v", e.Failsafe.O2Failed, e.Failsafe.OpenLoop())
+	}
+
+	e.ClosedLoop.update(e, 30, 1, 0.05)
+	if e.ClosedLoop.Active {
+		t.Error("loop still closed on a failed O2 sensor")
+	}
+	if e.Failsafe.Active() != FailsafeOpenLoop {
+		t.Errorf("failsafe = %q, want %q", e.Failsafe.Active(), FailsafeOpenLoop)
+	}
+}
+
+func TestLimpMode(t *testing.T) {
+	e := limiterECU(LimiterSparkCut)
+	e.ThrottlePosition = 120
+	e.RPM = 6000
+
+	e.Failsafe.substitute(e)
+	e.Failsafe.protect(e)
+	e.RevLimiter.update(e)
+	if !e.Failsafe.Limp || e.RevLimiter.Limit != e.Failsafe.LimpRevLimit || e.RevLimiter.SparkCut != 1 {
+		t.Fatalf("failed TPS: limp %v limit %g cut %g, want held at %g", e.Failsafe.Limp, e.RevLimiter.Limit, e.RevLimiter.SparkCut, e.Failsafe.LimpRevLimit)
+	}
+
+	// A lower per-gear limit still applies
+	e.RevLimiter.GearLimits = []float64{0, 0, 4500}
+	e.RevLimiter.update(e)
+	if e.RevLimiter.Limit != 4500 {
+		t.Errorf("limit with a 4500 RPM gear limit = %g", e.RevLimiter.Limit)
+	}
+	e.RevLimiter.GearLimits = nil
+
+	// An IAT failure alone is not worth limping for
+	e = limiterECU(LimiterSparkCut)
+	e.AirTemp = 150
+	e.Failsafe.substitute(e)
+	e.Failsafe.protect(e)
+	e.RevLimiter.update(e)
+	if e.Failsafe.Limp || e.RevLimiter.Limit != e.RevLimit {
+		t.Errorf("failed IAT: limp %v limit %g", e.Failsafe.Limp, e.RevLimiter.Limit)
+	}
+}
+
+func TestOverheatProtection(t *testing.T) {
+	e := healthyECU()
+	f := &e.Failsafe
+
+	for _, tt := range []struct {
+		temp        float64
+		overheat    float64
+		cylinderCut bool
+	}{
+		{100, 0, false},
+		{115, 0.5, false},
+		{120, 1, true},
+		{117, 0.7, true}, // Still cut until it has cooled down
+		{114, 0.4, false},
+	} {
+		e.EngineTemp = tt.temp
+		f.substitute(e)
+		f.protect(e)
+
+		if f.Overheat != tt.overheat || f.Enrichment != tt.overheat*f.OverheatEnrichment || f.Retard != tt.overheat*f.OverheatRetard {
+			t.Errorf("at %g°C: overheat %g enrichment %g%% retard %g°, want overheat %g", tt.temp, f.Overheat, f.Enrichment, f.Retard, tt.overheat)
+		}
+		if f.CylinderCut != tt.cylinderCut || f.Limp != tt.cylinderCut || f.OpenLoop() != tt.cylinderCut {
+			t.Errorf("at %g°C: cylinder cut %v limp %v open loop %v, want %v", tt.temp, f.CylinderCut, f.Limp, f.OpenLoop(), tt.cylinderCut)
+		}
+		if want := map[bool]float64{true: 0.5}[tt.cylinderCut]; f.FuelCut != want {
+			t.Errorf("at %g°C: fuel cut %g, want %g", tt.temp, f.FuelCut, want)
+		}
+	}
+}
+
+func TestFailsafeDisabled(t *testing.T) {
+	e := healthyECU()
+	e.Failsafe.Enabled = false
+	e.ThrottlePosition = 120
+	e.EngineTemp = 130
+
+	e.Failsafe.substitute(e)
+	e.Failsafe.protect(e)
+	if e.ThrottlePosition != 120 || e.Failsafe.Active() != "" || e.Failsafe.FuelMultiplier() != 1 {
+		t.Errorf("disabled failsafe: tps %g, active %q", e.ThrottlePosition, e.Failsafe.Active())
+	}
+}
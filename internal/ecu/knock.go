@@ -0,0 +1,98 @@
+package ecu
+
+import (
+	"math"
+)
+
+// KnockControl listens to the knock sensor and pulls ignition timing when it
+// hears detonation. Each knock event retards timing by a fixed step, which is
+// given back gradually once the engine is quiet again. Retard that keeps being
+// needed is learned into a per-cell table so the next visit to that part of
+// the map starts out safe; learned retard is slowly re-advanced while no knock
+// is heard.
+type KnockControl struct {
+	Enabled bool
+
+	Threshold    float64 // Sensor signal counted as a knock event
+	RetardStep   float64 // Degrees pulled per knock event
+	MaxRetard    float64 // Limit of the fast retard, degrees
+	RecoveryRate float64 // Degrees of fast retard given back per second without knock
+
+	LearnRate    float64 // Fraction of the fast retard learned into the table per second
+	MaxLearned   float64 // Limit of a learned cell, degrees
+	RelearnRate  float64 // Degrees per second a learned cell re-advances without knock
+	RelearnDelay float64 // Seconds without knock before learned retard re-advances
+
+	// Learned retard in degrees, on the ignition map axes
+	Learned Map2D
+
+	// Controller state
+	Knocking    bool    // Knock heard this cycle
+	Retard      float64 // Fast retard, degrees
+	LearnedCell float64 // Learned retard applied this cycle, degrees
+
+	quietDuration float64
+}
+
+// NewKnockControl creates an enabled controller with an empty learned table for the given ignition map
+func NewKnockControl(ignitionMap Map2D) KnockControl {
+	return KnockControl{
+		Enabled: true,
+
+		Threshold:    KnockThreshold,
+		RetardStep:   2.0,
+		MaxRetard:    10.0,
+		RecoveryRate: 1.0,
+
+		LearnRate:    0.2,
+		MaxLearned:   8.0,
+		RelearnRate:  0.05,
+		RelearnDelay: 5.0,
+
+		Learned: emptyMapLike(ignitionMap),
+	}
+}
+
+// update runs one controller cycle and returns the total retard to apply, in degrees
+func (k *KnockControl) update(e *ECU, load, knockIntensity, dt float64) float64 {
+	if !k.Enabled {
+		// Ignore the sensor entirely
+		k.Knocking = false
+		k.Retard = 0
+		k.LearnedCell = 0
+		return 0
+	}
+
+	k.LearnedCell = k.Learned.GetValue(e.RPM, load)
+	k.Knocking = knockIntensity > k.Threshold
+
+	if k.Knocking {
+		// React straight away, and count the event
+		k.Retard = math.Min(k.MaxRetard, k.Retard+k.RetardStep)
+		k.quietDuration = 0
+		e.KnockCount++
+	} else {
+		k.Retard = math.Max(0, k.Retard-k.RecoveryRate*dt)
+		k.quietDuration += dt
+	}
+
+	// Learn: move part of the fast retard into the cells around the operating point
+	if k.Retard > 0 {
+		learned := k.Retard * k.LearnRate * dt
+		k.Retard -= k.Learned.AddInterpolated(e.RPM, load, learned, 0, k.MaxLearned)
+	} else if k.quietDuration >= k.RelearnDelay {
+		// Quiet for a while: try giving some learned timing back
+		k.Learned.AddInterpolated(e.RPM, load, -k.RelearnRate*dt, 0, k.MaxLearned)
+	}
+
+	return k.Retard + k.LearnedCell
+}
+
+// ResetLearned clears the fast and learned knock retard
+func (k *KnockControl) ResetLearned() {
+	k.Knocking = false
+	k.Retard = 0
+	k.LearnedCell = 0
+	k.quietDuration = 0
+	k.Learned = emptyMapLike(k.Learned)
+}
@@ -0,0 +1,100 @@
+package ecu
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKnockRetardSteps(t *testing.T) {
+	e := NewECU()
+	e.RPM = 5000
+	k := &e.KnockControl
+
+	// With no time passing nothing is learned or recovered, so each event adds a step
+	for i := 1; i <= 8; i++ {
+		retard := k.update(e, 60, KnockThreshold*2, 0)
+		want := math.Min(float64(i)*k.RetardStep, k.MaxRetard)
+		if retard != want || !k.Knocking {
+			t.Fatalf("after %d knocks: retard %g knocking %v, want %g", i, retard, k.Knocking, want)
+		}
+	}
+	if e.KnockCount != 8 {
+		t.Errorf("knock count = %d, want 8", e.KnockCount)
+	}
+}
+
+func TestKnockRetardRecovers(t *testing.T) {
+	e := NewECU()
+	e.RPM = 5000
+	k := &e.KnockControl
+	k.LearnRate = 0
+
+	k.update(e, 60, KnockThreshold*2, 0)
+	k.update(e, 60, KnockThreshold*2, 0)
+
+	const dt = 0.05
+	for range 20 {
+		k.update(e, 60, 0, dt)
+	}
+	if want := 2*k.RetardStep - k.RecoveryRate; math.Abs(k.Retard-want) > 1e-9 {
+		t.Errorf("retard after 1 s quiet = %g, want %g", k.Retard, want)
+	}
+
+	for range 100 {
+		k.update(e, 60, 0, dt)
+	}
+	if k.Retard != 0 || k.Knocking {
+		t.Errorf("retard after 6 s quiet = %g, want 0", k.Retard)
+	}
+}
+
+func TestKnockLearnsPerCell(t *testing.T) {
+	e := NewECU()
+	k := &e.KnockControl
+	const dt, load = 0.05, 60.0
+
+	// Knock on and off at 5000 RPM for a while
+	e.RPM = 5000
+	for i := range 400 {
+		intensity := 0.0
+		if i%4 == 0 {
+			intensity = KnockThreshold * 2
+		}
+		k.update(e, load, intensity, dt)
+	}
+
+	learned := k.Learned.GetValue(5000, load)
+	if learned <= 1 {
+		t.Fatalf("learned retard at 5000 RPM = %g, want it to build up", learned)
+	}
+	if learned > k.MaxLearned+1e-9 {
+		t.Errorf("learned retard %g beyond its %g limit", learned, k.MaxLearned)
+	}
+	if other := k.Learned.GetValue(1500, 10); other != 0 {
+		t.Errorf("learned retard at an unvisited cell = %g, want 0", other)
+	}
+
+	// A fresh visit to the same cell starts out retarded
+	k.Retard = 0
+	if retard := k.update(e, load, 0, dt); retard < learned-0.1 {
+		t.Errorf("retard on return = %g, want the learned %g", retard, learned)
+	}
+
+	// Quiet long enough and the learned timing is given back
+	for range 400 {
+		k.update(e, load, 0, dt)
+	}
+	if after := k.Learned.GetValue(5000, load); after >= learned {
+		t.Errorf("learned retard after 20 s quiet = %g, want less than %g", after, learned)
+	}
+}
+
+func TestKnockControlDisabled(t *testing.T) {
+	e := NewECU()
+	e.RPM = 5000
+	e.KnockControl.Enabled = false
+
+	if retard := e.KnockControl.update(e, 60, KnockThreshold*2, 0.05); retard != 0 || e.KnockCount != 0 {
+		t.Errorf("disabled control pulled %g° and counted %d knocks", retard, e.KnockCount)
+	}
+}
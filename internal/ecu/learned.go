@@ -0,0 +1,97 @@
+package ecu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrLearnedShape is returned when learned tables do not match the ECU's map breakpoints
+var ErrLearnedShape = errors.New("learned table breakpoints do not match the ECU maps")
+
+// Learned holds the adaptive tables the ECU builds up while running. Like an
+// ECU's keep-alive memory it outlives a single session when persisted.
+type Learned struct {
+	FuelTrim    Map2D `json:"fuel_trim"`    // Long-term fuel trim, percent
+	KnockRetard Map2D `json:"knock_retard"` // Learned ignition retard, degrees
+}
+
+// Learned returns a copy of the ECU's adaptive tables
+func (e *ECU) Learned() Learned {
+	return Learned{
+		FuelTrim:    e.ClosedLoop.LTFT.Clone(),
+		KnockRetard: e.KnockControl.Learned.Clone(),
+	}
+}
+
+// LoadLearned replaces the ECU's adaptive tables. A table missing from l, as
+// in files saved before it existed, starts out empty.
+func (e *ECU) LoadLearned(l Learned) error {
+	fuelTrim, err := learnedTable(e.ClosedLoop.LTFT, l.FuelTrim)
+	if err != nil {
+		return fmt.Errorf("fuel trim: %w", err)
+	}
+	knockRetard, err := learnedTable(e.KnockControl.Learned, l.KnockRetard)
+	if err != nil {
+		return fmt.Errorf("knock retard: %w", err)
+	}
+
+	e.ClosedLoop.LTFT = fuelTrim
+	e.KnockControl.Learned = knockRetard
+	return nil
+}
+
+// learnedTable checks a loaded table against the one it replaces
+func learnedTable(current, loaded Map2D) (Map2D, error) {
+	if loaded.Values == nil {
+		return emptyMapLike(current), nil
+	}
+	if !current.sameShape(loaded) {
+		return Map2D{}, ErrLearnedShape
+	}
+	return loaded.Clone(), nil
+}
+
+// LoadLearnedFile reads adaptive tables saved with SaveLearnedFile
+func LoadLearnedFile(path string) (*Learned, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var l Learned
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("parse learned tables %s: %w", path, err)
+	}
+	return &l, nil
+}
+
+// SaveLearnedFile writes adaptive tables to path. The file is replaced
+// atomically so a concurrent reader never sees half a table.
+func SaveLearnedFile(path string, l Learned) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,137 @@
+package ecu
+
+import (
+	"errors"
+	"math"
+)
+
+// Rev limiter strategies
+const (
+	LimiterSoft     = "soft"      // Retard ignition approaching the limit, cut sparks past it
+	LimiterSparkCut = "spark_cut" // Cut every spark at the limit
+	LimiterFuelCut  = "fuel_cut"  // Cut every injection at the limit
+	LimiterStaged   = "staged"    // Retard, then cut a growing share of sparks up to the limit
+)
+
+// ErrUnknownLimiter is returned when a rev limiter strategy name is not recognized
+var ErrUnknownLimiter = errors.New("unknown rev limiter strategy")
+
+// RevLimiter keeps the engine under the rev limit. The limit is the ECU's
+// RevLimit, lowered by a per-gear limit when one is set, the limp mode limit
+// while the failsafe is in limp mode, or the launch control limit while the
+// bike is stationary with the clutch pulled in.
+type RevLimiter struct {
+	Strategy string // One of the Limiter constants
+
+	SoftWindow float64 // RPM below the limit where soft and staged limiting start
+	MaxRetard  float64 // Degrees of retard at the limit
+	Hysteresis float64 // RPM the engine has to drop below the limit before a hard cut ends
+
+	// Per-gear limits, index 0 is first gear; 0 or a missing entry uses RevLimit
+	GearLimits []float64
+
+	// Launch control: a lower limit while waiting to pull away
+	LaunchControl  bool
+	LaunchRPM      float64
+	LaunchMaxSpeed float64 // km/h, faster counts as moving
+	LaunchClutch   float64 // Clutch position at or above which the clutch counts as pulled in
+
+	// Limiter state
+	Limit    float64 // RPM limit in force this cycle
+	Launch   bool    // Launch control limit in force
+	Active   bool    // Limiter is retarding or cutting
+	Retard   float64 // Degrees pulled by the limiter
+	SparkCut float64 // Share of sparks cut, 0-1
+	FuelCut  float64 // Share of injections cut, 0-1
+
+	cutting bool // Hard cut latched until the RPM drops through the hysteresis
+}
+
+// NewRevLimiter creates a hard spark cut limiter with launch control turned off
+func NewRevLimiter() RevLimiter {
+	return RevLimiter{
+		Strategy: LimiterSparkCut,
+
+		SoftWindow: 300.0,
+		MaxRetard:  15.0,
+		Hysteresis: 150.0,
+
+		LaunchControl:  false,
+		LaunchRPM:      6000.0,
+		LaunchMaxSpeed: 3.0,
+		LaunchClutch:   0.9,
+	}
+}
+
+// validLimiter reports whether name is one of the rev limiter strategies
+func validLimiter(name string) bool {
+	switch name {
+	case LimiterSoft, LimiterSparkCut, LimiterFuelCut, LimiterStaged:
+		return true
+	default:
+		return false
+	}
+}
+
+// update works out the limit in force and how hard to limit this cycle
+func (r *RevLimiter) update(e *ECU) {
+	r.Limit = e.RevLimit
+	if e.Gear > 0 && e.Gear <= len(r.GearLimits) && r.GearLimits[e.Gear-1] > 0 {
+		r.Limit = math.Min(r.Limit, r.GearLimits[e.Gear-1])
+	}
+	r.Limit = e.Failsafe.limit(r.Limit)
+
+	r.Launch = r.LaunchControl && e.Speed < r.LaunchMaxSpeed && e.ClutchPosition >= r.LaunchClutch
+	if r.Launch {
+		r.Limit = math.Min(r.Limit, r.LaunchRPM)
+	}
+
+	r.Retard = 0
+	r.SparkCut = 0
+	r.FuelCut = 0
+
+	if r.Limit <= 0 {
+		// No limit set
+		r.Active = false
+		r.cutting = false
+		return
+	}
+
+	// How far into the soft window the engine is, 0 at its start and 1 at the limit
+	window := clamp((e.RPM-(r.Limit-r.SoftWindow))/r.SoftWindow, 0, 1)
+
+	switch r.Strategy {
+	case LimiterSoft:
+		// Retard softens the approach; a spark cut holds the limit
+		r.Retard = r.MaxRetard * window
+		r.SparkCut = r.hardCut(e)
+
+	case LimiterStaged:
+		// Retard through the first half of the window, then cut more and more sparks
+		r.Retard = r.MaxRetard * math.Min(1, window*2)
+		r.SparkCut = clamp(window*2-1, 0, 1)
+
+	case LimiterFuelCut:
+		r.FuelCut = r.hardCut(e)
+
+	default:
+		r.SparkCut = r.hardCut(e)
+	}
+
+	r.Active = r.Retard > 0 || r.SparkCut > 0 || r.FuelCut > 0
+}
+
+// hardCut returns 1 from the moment the engine reaches the limit until it has
+// fallen through the hysteresis, and 0 otherwise
+func (r *RevLimiter) hardCut(e *ECU) float64 {
+	if e.RPM >= r.Limit {
+		r.cutting = true
+	} else if e.RPM < r.Limit-r.Hysteresis {
+		r.cutting = false
+	}
+
+	if r.cutting {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,154 @@
+package ecu
+
+import (
+	"math"
+	"testing"
+)
+
+// limiterECU returns an ECU using the given limiter strategy, riding in third
+func limiterECU(strategy string) *ECU {
+	e := NewECU()
+	e.RevLimiter.Strategy = strategy
+	e.Gear = 3
+	e.Speed = 80
+	return e
+}
+
+func TestLimiterStrategies(t *testing.T) {
+	type state struct {
+		retard, sparkCut, fuelCut float64
+	}
+
+	// RPM below the limit: outside the soft window, halfway into it, and at the limit
+	const limit = 11000.0
+	rpms := []float64{limit - 1000, limit - 150, limit}
+
+	tests := []struct {
+		strategy string
+		want     []state
+	}{
+		{LimiterSparkCut, []state{{}, {}, {sparkCut: 1}}},
+		{LimiterFuelCut, []state{{}, {}, {fuelCut: 1}}},
+		{LimiterSoft, []state{{}, {retard: 7.5}, {retard: 15, sparkCut: 1}}},
+		{LimiterStaged, []state{{}, {retard: 15}, {retard: 15, sparkCut: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			for i, rpm := range rpms {
+				e := limiterECU(tt.strategy)
+				e.RPM = rpm
+				e.RevLimiter.update(e)
+
+				r := e.RevLimiter
+				got := state{r.Retard, r.SparkCut, r.FuelCut}
+				if math.Abs(got.retard-tt.want[i].retard) > 1e-9 || got.sparkCut != tt.want[i].sparkCut || got.fuelCut != tt.want[i].fuelCut {
+					t.Errorf("at %g RPM: %+v, want %+v", rpm, got, tt.want[i])
+				}
+				if r.Active != (tt.want[i] != state{}) {
+					t.Errorf("at %g RPM: active %v", rpm, r.Active)
+				}
+			}
+		})
+	}
+
+	// Staged cuts a growing share of sparks through the second half of the window
+	e := limiterECU(LimiterStaged)
+	e.RPM = limit - 75
+	e.RevLimiter.update(e)
+	if math.Abs(e.RevLimiter.SparkCut-0.5) > 1e-9 {
+		t.Errorf("staged spark cut three quarters into the window = %g, want 0.5", e.RevLimiter.SparkCut)
+	}
+}
+
+func TestLimiterHysteresis(t *testing.T) {
+	e := limiterECU(LimiterSparkCut)
+	r := &e.RevLimiter
+
+	for _, step := range []struct {
+		rpm float64
+		cut bool
+	}{
+		{11000, true},
+		{10900, true}, // Dropped, but not through the hysteresis
+		{10800, false},
+		{10900, false}, // Rising again, still below the limit
+		{11000, true},
+	} {
+		e.RPM = step.rpm
+		r.update(e)
+		if (r.SparkCut == 1) != step.cut {
+			t.Errorf("at %g RPM spark cut %g, want cutting %v", step.rpm, r.SparkCut, step.cut)
+		}
+	}
+}
+
+func TestGearRevLimits(t *testing.T) {
+	e := limiterECU(LimiterSparkCut)
+	e.RevLimiter.GearLimits = []float64{9000, 0, 10000}
+
+	for _, tt := range []struct {
+		gear int
+		want float64
+	}{
+		{0, 11000}, // Neutral
+		{1, 9000},
+		{2, 11000}, // 0 keeps the rev limit
+		{3, 10000},
+		{5, 11000}, // No entry
+	} {
+		e.Gear = tt.gear
+		e.RPM = 8000
+		e.RevLimiter.update(e)
+		if e.RevLimiter.Limit != tt.want {
+			t.Errorf("gear %d limit = %g, want %g", tt.gear, e.RevLimiter.Limit, tt.want)
+		}
+	}
+
+	// A per-gear limit above the rev limit does not raise it
+	e.RevLimiter.GearLimits = []float64{12000}
+	e.Gear = 1
+	e.RevLimiter.update(e)
+	if e.RevLimiter.Limit != 11000 {
+		t.Errorf("limit with a per-gear limit above the rev limit = %g, want 11000", e.RevLimiter.Limit)
+	}
+}
+
+func TestLaunchControl(t *testing.T) {
+	e := limiterECU(LimiterSparkCut)
+	r := &e.RevLimiter
+	r.LaunchControl = true
+	e.Gear = 1
+	e.RPM = r.LaunchRPM + 100
+
+	// Stationary with the clutch in: held at the launch limit
+	e.Speed = 0
+	e.ClutchPosition = 1
+	r.update(e)
+	if !r.Launch || r.Limit != r.LaunchRPM || r.SparkCut != 1 {
+		t.Errorf("stationary with the clutch in: launch %v limit %g cut %g", r.Launch, r.Limit, r.SparkCut)
+	}
+
+	// Letting the clutch out or rolling away ends it
+	for _, tt := range []struct {
+		name          string
+		speed, clutch float64
+	}{
+		{"clutch out", 0, 0},
+		{"rolling", 20, 1},
+	} {
+		e.Speed, e.ClutchPosition = tt.speed, tt.clutch
+		r.update(e)
+		if r.Launch || r.Limit != e.RevLimit {
+			t.Errorf("%s: launch %v limit %g, want the rev limit", tt.name, r.Launch, r.Limit)
+		}
+	}
+
+	// Off, the launch limit never applies
+	r.LaunchControl = false
+	e.Speed, e.ClutchPosition = 0, 1
+	r.update(e)
+	if r.Launch || r.Limit != e.RevLimit {
+		t.Errorf("launch control off: launch %v limit %g", r.Launch, r.Limit)
+	}
+}
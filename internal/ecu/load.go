@@ -0,0 +1,118 @@
+package ecu
+
+import (
+	"errors"
+
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+)
+
+// Load models decide what the load axis of every map means
+const (
+	LoadModelAlphaN       = "alpha_n"       // Throttle position, percent
+	LoadModelSpeedDensity = "speed_density" // Intake air density from MAP and IAT, percent of reference air
+	LoadModelBlended      = "blended"       // Speed-density at part throttle, moving to Alpha-N towards WOT
+)
+
+// ErrUnknownLoadModel is returned when a load model name is not recognized
+var ErrUnknownLoadModel = errors.New("unknown load model")
+
+// Speed-density reference: a manifold full of air at these conditions is 100% load
+const (
+	ReferenceMAP     = 101.3 // kPa
+	ReferenceAirTemp = 25.0  // °C
+)
+
+// Density of the reference air, kg/m³
+var referenceAirDensity = ReferenceMAP * 1000.0 / (engine.DefaultPhysicsConstants().GasConstant * (ReferenceAirTemp + 273.15))
+
+// Throttle range over which the blended model hands over from speed-density to
+// Alpha-N. Near WOT, MAP barely changes with throttle, so TPS resolves load better.
+const (
+	BlendStartThrottle = 30.0 // %
+	BlendEndThrottle   = 70.0 // %
+)
+
+// validLoadModel reports whether name is one of the load models
+func validLoadModel(name string) bool {
+	switch name {
+	case LoadModelAlphaN, LoadModelSpeedDensity, LoadModelBlended:
+		return true
+	default:
+		return false
+	}
+}
+
+// calculateLoad works out engine load from the sensors using the selected load model
+func (e *ECU) calculateLoad() float64 {
+	switch e.activeLoadModel() {
+	case LoadModelSpeedDensity:
+		return e.speedDensityLoad()
+	case LoadModelBlended:
+		alphaNWeight := e.alphaNWeight()
+		return (1-alphaNWeight)*e.speedDensityLoad() + alphaNWeight*e.ThrottlePosition
+	default:
+		return e.ThrottlePosition
+	}
+}
+
+// activeLoadModel returns the load model in use this cycle: the selected one,
+// unless the failsafe has moved off a failed sensor
+func (e *ECU) activeLoadModel() string {
+	return e.Failsafe.loadModel(e.LoadModel)
+}
+
+// alphaNWeight returns how much of the load and air estimate comes from
+// throttle position, from 0 (speed-density) to 1 (Alpha-N)
+func (e *ECU) alphaNWeight() float64 {
+	switch e.activeLoadModel() {
+	case LoadModelSpeedDensity:
+		return 0
+	case LoadModelBlended:
+		return clamp((e.ThrottlePosition-BlendStartThrottle)/(BlendEndThrottle-BlendStartThrottle), 0, 1)
+	default:
+		return 1
+	}
+}
+
+// speedDensityLoad is speedDensityCharge as a load percentage
+func (e *ECU) speedDensityLoad() float64 {
+	return clamp(e.speedDensityCharge()*100.0, 0, 100)
+}
+
+// speedDensityCharge estimates how full each cylinder gets from manifold
+// pressure and intake temperature, relative to the reference conditions. Unlike
+// throttle position it falls with altitude, hot air and a clogged air filter.
+func (e *ECU) speedDensityCharge() float64 {
+	pressureRatio := e.MAP / ReferenceMAP
+	temperatureRatio := (ReferenceAirTemp + 273.15) / (e.AirTemp + 273.15)
+
+	return pressureRatio * temperatureRatio
+}
+
+// throttleCharge estimates how full each cylinder gets from throttle position
+// and RPM alone, using the throttle body calibration at reference conditions.
+// It cannot see altitude, air temperature or a dirty air filter.
+func (e *ECU) throttleCharge() float64 {
+	area := e.Intake.FlowArea(e.ThrottlePosition, 0)
+	ve := engine.BaseVolumetricEfficiency(e.RPM)
+
+	return e.Intake.SteadyStateMAP(area, ReferenceMAP, ReferenceAirTemp, e.RPM, ve, Displacement) / ReferenceMAP
+}
+
+// estimateAirMass works out the air each cylinder takes in per cycle, in mg,
+// as the selected load model sees it
+func (e *ECU) estimateAirMass() float64 {
+	var charge float64
+	switch e.activeLoadModel() {
+	case LoadModelSpeedDensity:
+		charge = e.speedDensityCharge()
+	case LoadModelBlended:
+		alphaNWeight := e.alphaNWeight()
+		charge = (1-alphaNWeight)*e.speedDensityCharge() + alphaNWeight*e.throttleCharge()
+	default:
+		charge = e.throttleCharge()
+	}
+
+	cylinderVolume := Displacement / Cylinders / 1e6 // m³
+	return charge * engine.BaseVolumetricEfficiency(e.RPM) * referenceAirDensity * cylinderVolume * 1e6
+}
@@ -0,0 +1,96 @@
+package ecu
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// loadECU returns an ECU on the given load model with the sensors at a part
+// throttle cruise at sea level
+func loadECU(t *testing.T, model string) *ECU {
+	t.Helper()
+
+	e := NewECU()
+	settings := e.Settings()
+	settings.LoadModel = model
+	if err := e.ApplySettings(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	e.RPM = 5000
+	e.ThrottlePosition = 50
+	e.MAP = ReferenceMAP * 0.6
+	e.AirTemp = ReferenceAirTemp
+	return e
+}
+
+func TestLoadModels(t *testing.T) {
+	tests := []struct {
+		model    string
+		throttle float64
+		want     float64
+	}{
+		{LoadModelAlphaN, 50, 50},
+		{LoadModelSpeedDensity, 50, 60},
+		{LoadModelBlended, 20, 60},              // Speed-density below the blend
+		{LoadModelBlended, 50, 0.5*60 + 0.5*50}, // Halfway through the blend
+		{LoadModelBlended, 80, 80},              // Alpha-N above it
+		{LoadModelBlended, BlendEndThrottle, BlendEndThrottle},
+	}
+
+	for _, tt := range tests {
+		e := loadECU(t, tt.model)
+		e.ThrottlePosition = tt.throttle
+
+		if load := e.calculateLoad(); math.Abs(load-tt.want) > 1e-9 {
+			t.Errorf("%s at %g%% throttle: load %g, want %g", tt.model, tt.throttle, load, tt.want)
+		}
+	}
+}
+
+func TestSpeedDensitySeesTheAir(t *testing.T) {
+	alphaN, speedDensity := loadECU(t, LoadModelAlphaN), loadECU(t, LoadModelSpeedDensity)
+	seaLevelAlphaN, seaLevelSD := alphaN.estimateAirMass(), speedDensity.estimateAirMass()
+
+	// Thinner, hotter air: only speed-density notices
+	for _, e := range []*ECU{alphaN, speedDensity} {
+		e.MAP *= 0.8
+		e.AirTemp = 45
+	}
+
+	if load := alphaN.calculateLoad(); load != 50 {
+		t.Errorf("Alpha-N load changed with the air to %g", load)
+	}
+	if air := alphaN.estimateAirMass(); air != seaLevelAlphaN {
+		t.Errorf("Alpha-N air mass changed with the air: %g, was %g", air, seaLevelAlphaN)
+	}
+
+	wantRatio := 0.8 * (ReferenceAirTemp + 273.15) / (45 + 273.15)
+	if air := speedDensity.estimateAirMass(); math.Abs(air/seaLevelSD-wantRatio) > 1e-9 {
+		t.Errorf("speed-density air mass ratio = %g, want %g", air/seaLevelSD, wantRatio)
+	}
+	if load := speedDensity.calculateLoad(); math.Abs(load-60*wantRatio) > 1e-9 {
+		t.Errorf("speed-density load = %g, want %g", load, 60*wantRatio)
+	}
+
+	// Boosted past the reference the load axis stops at 100%
+	speedDensity.MAP = ReferenceMAP * 1.3
+	speedDensity.AirTemp = ReferenceAirTemp
+	if load := speedDensity.calculateLoad(); load != 100 {
+		t.Errorf("load above the reference = %g, want 100", load)
+	}
+}
+
+func TestUnknownLoadModel(t *testing.T) {
+	e := NewECU()
+	settings := e.Settings()
+	settings.LoadModel = "maf"
+
+	if err := e.ApplySettings(settings); !errors.Is(err, ErrUnknownLoadModel) {
+		t.Errorf("got %v, want %v", err, ErrUnknownLoadModel)
+	}
+	if e.LoadModel != LoadModelAlphaN {
+		t.Errorf("load model = %q after a refused change", e.LoadModel)
+	}
+}
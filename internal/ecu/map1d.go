@@ -0,0 +1,56 @@
+package ecu
+
+import (
+	"math"
+)
+
+// Map1D represents a 1D lookup table, such as a correction against engine temperature
+type Map1D struct {
+	Breakpoints []float64 `json:"breakpoints"`
+	Values      []float64 `json:"values"`
+}
+
+// GetValue retrieves a linearly interpolated value, holding the end values
+// outside the breakpoints
+func (m *Map1D) GetValue(x float64) float64 {
+	last := len(m.Breakpoints) - 1
+	if x <= m.Breakpoints[0] {
+		return m.Values[0]
+	}
+	if x >= m.Breakpoints[last] {
+		return m.Values[last]
+	}
+
+	for i := 0; i < last; i++ {
+		if x >= m.Breakpoints[i] && x <= m.Breakpoints[i+1] {
+			factor := (x - m.Breakpoints[i]) / (m.Breakpoints[i+1] - m.Breakpoints[i])
+			return m.Values[i] + factor*(m.Values[i+1]-m.Values[i])
+		}
+	}
+
+	return m.Values[last]
+}
+
+// SetValue sets the value at the nearest breakpoint
+func (m *Map1D) SetValue(x, value float64) {
+	nearestIdx := 0
+	smallestDiff := math.Abs(x - m.Breakpoints[0])
+
+	for i, breakpoint := range m.Breakpoints {
+		diff := math.Abs(x - breakpoint)
+		if diff < smallestDiff {
+			smallestDiff = diff
+			nearestIdx = i
+		}
+	}
+
+	m.Values[nearestIdx] = value
+}
+
+// Clone returns a deep copy of the table
+func (m *Map1D) Clone() Map1D {
+	return Map1D{
+		Breakpoints: append([]float64(nil), m.Breakpoints...),
+		Values:      append([]float64(nil), m.Values...),
+	}
+}
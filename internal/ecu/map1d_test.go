@@ -0,0 +1,45 @@
+package ecu
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestMap1DGetValue(t *testing.T) {
+	m := Map1D{
+		Breakpoints: []float64{0, 10, 30},
+		Values:      []float64{5, 15, -5},
+	}
+
+	for _, tt := range []struct{ x, want float64 }{
+		{-10, 5}, // Held below the first breakpoint
+		{0, 5},
+		{4, 9},
+		{10, 15},
+		{25, 0},
+		{30, -5},
+		{100, -5}, // Held above the last
+	} {
+		if got := m.GetValue(tt.x); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("GetValue(%g) = %g, want %g", tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestMap1DSetValue(t *testing.T) {
+	m := Map1D{
+		Breakpoints: []float64{0, 10, 30},
+		Values:      []float64{5, 15, -5},
+	}
+	clone := m.Clone()
+
+	m.SetValue(18, 40) // Nearest is 10
+	m.SetValue(-50, 1) // Nearest is 0
+	if want := []float64{1, 40, -5}; !slices.Equal(m.Values, want) {
+		t.Errorf("values = %v, want %v", m.Values, want)
+	}
+	if want := []float64{5, 15, -5}; !slices.Equal(clone.Values, want) {
+		t.Errorf("clone changed with the original: %v", clone.Values)
+	}
+}
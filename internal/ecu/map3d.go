@@ -0,0 +1,130 @@
+package ecu
+
+import (
+	"errors"
+	"math"
+)
+
+// Third axes a 3D map can be indexed by
+const (
+	Axis3DNone       = "none"        // No third axis, the 2D map is used
+	Axis3DGear       = "gear"        // Gear, 1-6; neutral reads the first gear layer
+	Axis3DEngineTemp = "engine_temp" // Engine temperature, °C
+)
+
+// 3D map type names, each stacking copies of the 2D map with the same base name
+const (
+	MapTypeFuel3D     = "fuel_3d"
+	MapTypeIgnition3D = "ignition_3d"
+	MapTypeAFR3D      = "afr_3d"
+)
+
+// map3DSuffix turns a 2D map type into the type of its 3D map
+const map3DSuffix = "_3d"
+
+// Errors returned when addressing 3D maps
+var (
+	ErrUnknownAxis = errors.New("unknown 3D map axis")
+	ErrMap3DOff    = errors.New("3D map is not enabled")
+)
+
+// Map3D stacks RPM/load maps along a third axis, such as gear or engine
+// temperature. Lookups interpolate between the layers either side.
+type Map3D struct {
+	Axis        string    `json:"axis"`        // One of the Axis3D constants
+	Breakpoints []float64 `json:"breakpoints"` // Third axis value of each layer
+	Layers      []Map2D   `json:"layers"`
+}
+
+// NewMap3D creates a 3D map on the given axis with every layer a copy of base,
+// so it reads the same as base until a layer is edited
+func NewMap3D(axis string, base Map2D) (Map3D, error) {
+	var breakpoints []float64
+	switch axis {
+	case Axis3DGear:
+		breakpoints = []float64{1, 2, 3, 4, 5, 6}
+	case Axis3DEngineTemp:
+		breakpoints = []float64{0, 20, 40, 60, 80, 100}
+	default:
+		return Map3D{}, ErrUnknownAxis
+	}
+
+	layers := make([]Map2D, len(breakpoints))
+	for i := range layers {
+		layers[i] = base.Clone()
+	}
+	return Map3D{Axis: axis, Breakpoints: breakpoints, Layers: layers}, nil
+}
+
+// validAxis3D reports whether name is one of the 3D map axes
+func validAxis3D(name string) bool {
+	switch name {
+	case Axis3DNone, Axis3DGear, Axis3DEngineTemp:
+		return true
+	default:
+		return false
+	}
+}
+
+// Enabled reports whether the map has any layers to read
+func (m *Map3D) Enabled() bool {
+	return len(m.Layers) > 0
+}
+
+// GetValue retrieves a value interpolated between the layers either side of z,
+// holding the end layers outside the breakpoints
+func (m *Map3D) GetValue(z, rpm, load float64) float64 {
+	last := len(m.Breakpoints) - 1
+	if z <= m.Breakpoints[0] {
+		return m.Layers[0].GetValue(rpm, load)
+	}
+	if z >= m.Breakpoints[last] {
+		return m.Layers[last].GetValue(rpm, load)
+	}
+
+	for i := 0; i < last; i++ {
+		if z >= m.Breakpoints[i] && z <= m.Breakpoints[i+1] {
+			factor := (z - m.Breakpoints[i]) / (m.Breakpoints[i+1] - m.Breakpoints[i])
+			low := m.Layers[i].GetValue(rpm, load)
+			high := m.Layers[i+1].GetValue(rpm, load)
+			return low + factor*(high-low)
+		}
+	}
+
+	return m.Layers[last].GetValue(rpm, load)
+}
+
+// SetValue sets the cell nearest to rpm and load in the layer nearest to z
+func (m *Map3D) SetValue(z, rpm, load, value float64) {
+	m.Layers[m.nearestLayer(z)].SetValue(rpm, load, value)
+}
+
+// nearestLayer returns the index of the layer whose breakpoint is closest to z
+func (m *Map3D) nearestLayer(z float64) int {
+	nearestIdx := 0
+	smallestDiff := math.Abs(z - m.Breakpoints[0])
+
+	for i, breakpoint := range m.Breakpoints {
+		diff := math.Abs(z - breakpoint)
+		if diff < smallestDiff {
+			smallestDiff = diff
+			nearestIdx = i
+		}
+	}
+
+	return nearestIdx
+}
+
+// Clone returns a deep copy of the map
+func (m *Map3D) Clone() Map3D {
+	layers := make([]Map2D, len(m.Layers))
+	for i := range m.Layers {
+		layers[i] = m.Layers[i].Clone()
+	}
+
+	return Map3D{
+		Axis:        m.Axis,
+		Breakpoints: append([]float64(nil), m.Breakpoints...),
+		Layers:      layers,
+	}
+}
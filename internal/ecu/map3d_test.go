@@ -0,0 +1,185 @@
+package ecu
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewMap3D(t *testing.T) {
+	base := NewECU().FuelMap.Map2D
+
+	for _, tt := range []struct {
+		axis        string
+		breakpoints []float64
+	}{
+		{Axis3DGear, []float64{1, 2, 3, 4, 5, 6}},
+		{Axis3DEngineTemp, []float64{0, 20, 40, 60, 80, 100}},
+	} {
+		m, err := NewMap3D(tt.axis, base)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.axis, err)
+		}
+		if len(m.Breakpoints) != len(tt.breakpoints) || len(m.Layers) != len(tt.breakpoints) {
+			t.Fatalf("%s: %d breakpoints and %d layers, want %d", tt.axis, len(m.Breakpoints), len(m.Layers), len(tt.breakpoints))
+		}
+		for i, bp := range tt.breakpoints {
+			if m.Breakpoints[i] != bp {
+				t.Errorf("%s breakpoint %d = %g, want %g", tt.axis, i, m.Breakpoints[i], bp)
+			}
+		}
+
+		// Each layer reads the same as the base until it is edited, and is its own copy
+		if got, want := m.GetValue(tt.breakpoints[2]+1, 5000, 60), base.GetValue(5000, 60); got != want {
+			t.Errorf("%s: unedited map reads %g, want the base %g", tt.axis, got, want)
+		}
+		m.Layers[0].SetValue(5000, 60, 99)
+		if base.GetValue(5000, 60) == 99 || m.Layers[1].GetValue(5000, 60) == 99 {
+			t.Errorf("%s: editing a layer changed the base or another layer", tt.axis)
+		}
+	}
+
+	if _, err := NewMap3D(Axis3DNone, base); !errors.Is(err, ErrUnknownAxis) {
+		t.Errorf("no axis: got %v, want %v", err, ErrUnknownAxis)
+	}
+	if _, err := NewMap3D("throttle", base); !errors.Is(err, ErrUnknownAxis) {
+		t.Errorf("unknown axis: got %v, want %v", err, ErrUnknownAxis)
+	}
+}
+
+func TestMap3DInterpolatesLayers(t *testing.T) {
+	m, _ := NewMap3D(Axis3DEngineTemp, NewECU().FuelMap.Map2D)
+	const rpm, load = 5000.0, 60.0
+	for i := range m.Layers {
+		m.Layers[i].SetValue(rpm, load, float64(10*(i+1)))
+	}
+
+	for _, tt := range []struct {
+		temp, want float64
+	}{
+		{-20, 10}, // Held at the coldest layer
+		{0, 10},
+		{10, 15},
+		{45, 32.5},
+		{100, 60},
+		{130, 60}, // Held at the hottest
+	} {
+		if got := m.GetValue(tt.temp, rpm, load); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("at %g°C: %g, want %g", tt.temp, got, tt.want)
+		}
+	}
+}
+
+func TestMap3DSetValueNearestLayer(t *testing.T) {
+	const rpm, load = 5000.0, 60.0
+
+	for _, tt := range []struct {
+		z     float64
+		layer int
+	}{
+		{-5, 0},
+		{9, 0},
+		{11, 1},
+		{68, 3},
+		{150, 5},
+	} {
+		m, _ := NewMap3D(Axis3DEngineTemp, NewECU().FuelMap.Map2D)
+		m.SetValue(tt.z, rpm, load, 42)
+
+		for i := range m.Layers {
+			if got := m.Layers[i].GetValue(rpm, load); (got == 42) != (i == tt.layer) {
+				t.Errorf("z %g: layer %d reads %g, want only layer %d set", tt.z, i, got, tt.layer)
+			}
+		}
+	}
+}
+
+func TestMap3DFollowsTheAxis(t *testing.T) {
+	const load = 60.0
+
+	// Gear: neutral reads the first gear layer
+	e := withAxis(t, Axis3DGear)
+	e.RPM = 5000
+	for i := range e.FuelMap3D.Layers {
+		e.FuelMap3D.Layers[i].SetValue(e.RPM, load, float64(i+1))
+	}
+	for _, tt := range []struct {
+		gear int
+		want float64
+	}{{0, 1}, {1, 1}, {4, 4}, {6, 6}} {
+		e.Gear = tt.gear
+		if got := e.lookup(&e.FuelMap.Map2D, &e.FuelMap3D, load); got != tt.want {
+			t.Errorf("gear %d: %g, want %g", tt.gear, got, tt.want)
+		}
+	}
+
+	// Engine temperature
+	e = withAxis(t, Axis3DEngineTemp)
+	e.RPM = 5000
+	e.FuelMap3D.Layers[0].SetValue(e.RPM, load, 20)
+	e.FuelMap3D.Layers[1].SetValue(e.RPM, load, 10)
+	e.EngineTemp = 5
+	if got := e.lookup(&e.FuelMap.Map2D, &e.FuelMap3D, load); math.Abs(got-17.5) > 1e-9 {
+		t.Errorf("at 5°C: %g, want 17.5", got)
+	}
+
+	// Off, the 2D map is read
+	e = NewECU()
+	e.RPM = 5000
+	if got, want := e.lookup(&e.FuelMap.Map2D, &e.FuelMap3D, load), e.FuelMap.GetValue(e.RPM, load); got != want {
+		t.Errorf("3D map off: %g, want the 2D %g", got, want)
+	}
+}
+
+func TestMap3DAxisSettings(t *testing.T) {
+	e := withAxis(t, Axis3DGear)
+	if axis := e.Settings().FuelMapAxis; axis != Axis3DGear {
+		t.Fatalf("fuel map axis = %q, want %q", axis, Axis3DGear)
+	}
+	if err := e.SetMap3DValue(MapTypeFuel3D, 3, 5000, 60, 7); err != nil {
+		t.Fatal(err)
+	}
+
+	// Empty or unchanged keeps the edits
+	for _, axis := range []string{"", Axis3DGear} {
+		settings := e.Settings()
+		settings.FuelMapAxis = axis
+		if err := e.ApplySettings(settings); err != nil {
+			t.Fatal(err)
+		}
+		if got := e.FuelMap3D.Layers[2].GetValue(5000, 60); got != 7 {
+			t.Errorf("axis %q: edited cell = %g, want it kept", axis, got)
+		}
+	}
+
+	// A new axis starts again from the 2D map
+	settings := e.Settings()
+	settings.FuelMapAxis = Axis3DEngineTemp
+	if err := e.ApplySettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	if e.FuelMap3D.Axis != Axis3DEngineTemp || e.FuelMap3D.Layers[2].GetValue(5000, 60) != e.FuelMap.GetValue(5000, 60) {
+		t.Errorf("switching axis kept the old layers")
+	}
+
+	// An unknown axis is refused and changes nothing
+	settings.FuelMapAxis = "throttle"
+	if err := e.ApplySettings(settings); !errors.Is(err, ErrUnknownAxis) {
+		t.Errorf("unknown axis: got %v, want %v", err, ErrUnknownAxis)
+	}
+	if e.FuelMap3D.Axis != Axis3DEngineTemp {
+		t.Errorf("axis = %q after a refused change", e.FuelMap3D.Axis)
+	}
+
+	// None switches it off
+	settings.FuelMapAxis = Axis3DNone
+	if err := e.ApplySettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	if e.FuelMap3D.Enabled() {
+		t.Error("3D map still enabled with no axis")
+	}
+	if err := e.SetMap3DValue(MapTypeFuel3D, 3, 5000, 60, 7); !errors.Is(err, ErrMap3DOff) {
+		t.Errorf("editing a disabled map: got %v, want %v", err, ErrMap3DOff)
+	}
+}
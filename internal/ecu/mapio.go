@@ -0,0 +1,496 @@
+package ecu
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Map file formats
+const (
+	MapFormatCSV  = "csv"  // Load breakpoints across the first row, RPM breakpoints down the first column
+	MapFormatJSON = "json" // The map's own JSON encoding
+)
+
+// Errors returned when importing or exporting maps
+var (
+	ErrUnknownMapFormat = errors.New("unknown map format")
+	ErrInvalidMap       = errors.New("invalid map")
+)
+
+// csvCorner labels the top-left cell of a 2D map in CSV, naming both axes
+const csvCorner = `rpm\load`
+
+// tableAxes names the axis of each 1D table in the header of its CSV file
+var tableAxes = map[string]string{
+	MapTypeWarmup:      "engine_temp",
+	MapTypeAfterStart:  "engine_temp",
+	MapTypeColdRetard:  "engine_temp",
+	MapTypeColdIdleRPM: "engine_temp",
+	MapTypeIATFuel:     "air_temp",
+	MapTypeIATIgnition: "air_temp",
+	MapTypeBaroFuel:    "baro",
+	MapTypeDeadTime:    "battery_voltage",
+}
+
+// MapFormatFromPath guesses the map format from a file extension
+func MapFormatFromPath(path string) string {
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+}
+
+// Validate checks that the map has at least two strictly increasing breakpoints
+// on each axis, a finite value for every cell, and nothing more
+func (m *Map2D) Validate() error {
+	if err := validBreakpoints("RPM", m.RPMBreakpoints); err != nil {
+		return err
+	}
+	if err := validBreakpoints("load", m.LoadBreakpoints); err != nil {
+		return err
+	}
+
+	if len(m.Values) != len(m.RPMBreakpoints) {
+		return fmt.Errorf("%w: %d rows of values for %d RPM breakpoints", ErrInvalidMap, len(m.Values), len(m.RPMBreakpoints))
+	}
+	for i, row := range m.Values {
+		if len(row) != len(m.LoadBreakpoints) {
+			return fmt.Errorf("%w: %g RPM row has %d values for %d load breakpoints", ErrInvalidMap, m.RPMBreakpoints[i], len(row), len(m.LoadBreakpoints))
+		}
+		if err := validValues(row); err != nil {
+			return fmt.Errorf("%g RPM row: %w", m.RPMBreakpoints[i], err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the table has at least two strictly increasing
+// breakpoints and a finite value for each
+func (m *Map1D) Validate() error {
+	if err := validBreakpoints("table", m.Breakpoints); err != nil {
+		return err
+	}
+	if len(m.Values) != len(m.Breakpoints) {
+		return fmt.Errorf("%w: %d values for %d breakpoints", ErrInvalidMap, len(m.Values), len(m.Breakpoints))
+	}
+	return validValues(m.Values)
+}
+
+// Validate checks the 3D map's axis and layer breakpoints, and every layer
+func (m *Map3D) Validate() error {
+	if m.Axis == Axis3DNone || !validAxis3D(m.Axis) {
+		return fmt.Errorf("%w %q", ErrUnknownAxis, m.Axis)
+	}
+	if err := validBreakpoints(m.Axis, m.Breakpoints); err != nil {
+		return err
+	}
+	if len(m.Layers) != len(m.Breakpoints) {
+		return fmt.Errorf("%w: %d layers for %d breakpoints", ErrInvalidMap, len(m.Layers), len(m.Breakpoints))
+	}
+	for i := range m.Layers {
+		if err := m.Layers[i].Validate(); err != nil {
+			return fmt.Errorf("layer %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validBreakpoints checks that an axis has at least two breakpoints, each
+// finite and above the one before
+func validBreakpoints(axis string, breakpoints []float64) error {
+	if len(breakpoints) < 2 {
+		return fmt.Errorf("%w: %s axis needs at least 2 breakpoints", ErrInvalidMap, axis)
+	}
+	if err := validValues(breakpoints); err != nil {
+		return fmt.Errorf("%s axis: %w", axis, err)
+	}
+	for i := 1; i < len(breakpoints); i++ {
+		if breakpoints[i] <= breakpoints[i-1] {
+			return fmt.Errorf("%w: %s breakpoints must increase, %g follows %g", ErrInvalidMap, axis, breakpoints[i], breakpoints[i-1])
+		}
+	}
+	return nil
+}
+
+// validValues checks that every value is a finite number
+func validValues(values []float64) error {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%w: %g is not a finite value", ErrInvalidMap, v)
+		}
+	}
+	return nil
+}
+
+// cellFloor is the value each cell of these map types has to stay above. AFR
+// targets and idle speeds divide or set the engine's speed, and the percent
+// fuel corrections multiply fuel by 1 + value/100, so anything at or below the
+// floor leaves the ECU with no fuel, or none it can compute.
+var cellFloor = map[string]float64{
+	MapTypeAFR:         0,
+	MapTypeAFR3D:       0,
+	MapTypeLTFT:        -100,
+	MapTypeWarmup:      -100,
+	MapTypeAfterStart:  -100,
+	MapTypeColdIdleRPM: 0,
+	MapTypeIATFuel:     -100,
+	MapTypeBaroFuel:    -100,
+}
+
+// checkCells checks rows of cell values against what a map of the given type
+// can hold: finite, and above the type's floor if it has one
+func checkCells(mapType string, rows ...[]float64) error {
+	floor, hasFloor := cellFloor[mapType]
+	for _, row := range rows {
+		if err := validValues(row); err != nil {
+			return fmt.Errorf("%s map: %w", mapType, err)
+		}
+		for _, v := range row {
+			if hasFloor && v <= floor {
+				return fmt.Errorf("%w: %s values must be above %g, got %g", ErrInvalidMap, mapType, floor, v)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteMap2DCSV writes a 2D map as CSV: load breakpoints across the first row,
+// then one row per RPM breakpoint starting with the breakpoint
+func WriteMap2DCSV(w io.Writer, m Map2D) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{csvCorner}
+	for _, load := range m.LoadBreakpoints {
+		header = append(header, formatCell(load))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i, rpm := range m.RPMBreakpoints {
+		row := []string{formatCell(rpm)}
+		for _, v := range m.Values[i] {
+			row = append(row, formatCell(v))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadMap2DCSV reads a 2D map written by WriteMap2DCSV or laid out the same
+// way in a spreadsheet. The top-left cell is ignored.
+func ReadMap2DCSV(r io.Reader) (Map2D, error) {
+	rows, err := readCSV(r)
+	if err != nil {
+		return Map2D{}, err
+	}
+	if len(rows) < 2 {
+		return Map2D{}, fmt.Errorf("%w: need a header row and at least one row of values", ErrInvalidMap)
+	}
+
+	var m Map2D
+	if m.LoadBreakpoints, err = parseCells(rows[0][1:]); err != nil {
+		return Map2D{}, fmt.Errorf("header: %w", err)
+	}
+	for i, row := range rows[1:] {
+		cells, err := parseCells(row)
+		if err != nil {
+			return Map2D{}, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		m.RPMBreakpoints = append(m.RPMBreakpoints, cells[0])
+		m.Values = append(m.Values, cells[1:])
+	}
+
+	return m, m.Validate()
+}
+
+// WriteMap1DCSV writes a 1D table as CSV: a header naming the axis, then one
+// row per breakpoint with its value
+func WriteMap1DCSV(w io.Writer, m Map1D, axis string) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{axis, "value"}); err != nil {
+		return err
+	}
+	for i, x := range m.Breakpoints {
+		if err := cw.Write([]string{formatCell(x), formatCell(m.Values[i])}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadMap1DCSV reads a 1D table written by WriteMap1DCSV. The header row is ignored.
+func ReadMap1DCSV(r io.Reader) (Map1D, error) {
+	rows, err := readCSV(r)
+	if err != nil {
+		return Map1D{}, err
+	}
+	if len(rows) < 2 {
+		return Map1D{}, fmt.Errorf("%w: need a header row and at least one row of values", ErrInvalidMap)
+	}
+
+	var m Map1D
+	for i, row := range rows[1:] {
+		if len(row) != 2 {
+			return Map1D{}, fmt.Errorf("%w: row %d has %d cells, want a breakpoint and a value", ErrInvalidMap, i+2, len(row))
+		}
+		cells, err := parseCells(row)
+		if err != nil {
+			return Map1D{}, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		m.Breakpoints = append(m.Breakpoints, cells[0])
+		m.Values = append(m.Values, cells[1])
+	}
+
+	return m, m.Validate()
+}
+
+// readCSV reads every non-empty row, leaving the row lengths to the caller
+func readCSV(r io.Reader) ([][]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var rows [][]string
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidMap, err)
+		}
+
+		// Spreadsheets pad short rows with empty cells; drop them
+		for len(row) > 0 && strings.TrimSpace(row[len(row)-1]) == "" {
+			row = row[:len(row)-1]
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+}
+
+// parseCells parses a row of numbers
+func parseCells(row []string) ([]float64, error) {
+	values := make([]float64, len(row))
+	for i, cell := range row {
+		v, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cell %d: %q is not a number", ErrInvalidMap, i+1, cell)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// formatCell formats a number with as few digits as it needs
+func formatCell(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// ExportMap encodes the named map or table in the given format. 3D maps only
+// export to JSON.
+func (e *ECU) ExportMap(mapType, format string) ([]byte, error) {
+	if format != MapFormatCSV && format != MapFormatJSON {
+		return nil, fmt.Errorf("%w %q", ErrUnknownMapFormat, format)
+	}
+
+	var buf bytes.Buffer
+	if m, err := e.Map(mapType); err == nil {
+		if format == MapFormatJSON {
+			return marshalMap(m)
+		}
+		err := WriteMap2DCSV(&buf, *m)
+		return buf.Bytes(), err
+	}
+	if t, err := e.Table(mapType); err == nil {
+		if format == MapFormatJSON {
+			return marshalMap(t)
+		}
+		err := WriteMap1DCSV(&buf, *t, tableAxes[mapType])
+		return buf.Bytes(), err
+	}
+	if m3, err := e.Map3D(mapType); err == nil {
+		if !m3.Enabled() {
+			return nil, ErrMap3DOff
+		}
+		if format != MapFormatJSON {
+			return nil, fmt.Errorf("%w %q for a 3D map, use %s", ErrUnknownMapFormat, format, MapFormatJSON)
+		}
+		return marshalMap(m3)
+	}
+	return nil, ErrUnknownMap
+}
+
+// ImportMap replaces the named map or table with one decoded from data. The
+// new map is checked before anything changes. A fuel, ignition or AFR map may
+// have new breakpoints; see replaceMap for what follows it. A 3D map has to be
+// enabled on the axis the imported one was saved with.
+func (e *ECU) ImportMap(mapType, format string, data []byte) error {
+	if format != MapFormatCSV && format != MapFormatJSON {
+		return fmt.Errorf("%w %q", ErrUnknownMapFormat, format)
+	}
+
+	if m, err := e.Map(mapType); err == nil {
+		var loaded Map2D
+		if format == MapFormatJSON {
+			err = unmarshalMap(data, &loaded, loaded.Validate)
+		} else {
+			loaded, err = ReadMap2DCSV(bytes.NewReader(data))
+		}
+		if err == nil {
+			err = checkCells(mapType, loaded.Values...)
+		}
+		if err != nil {
+			return err
+		}
+		return e.replaceMap(mapType, m, loaded)
+	}
+	if t, err := e.Table(mapType); err == nil {
+		var loaded Map1D
+		if format == MapFormatJSON {
+			err = unmarshalMap(data, &loaded, loaded.Validate)
+		} else {
+			loaded, err = ReadMap1DCSV(bytes.NewReader(data))
+		}
+		if err == nil {
+			err = checkCells(mapType, loaded.Values)
+		}
+		if err != nil {
+			return err
+		}
+		*t = loaded
+		return nil
+	}
+	if m3, err := e.Map3D(mapType); err == nil {
+		if format != MapFormatJSON {
+			return fmt.Errorf("%w %q for a 3D map, use %s", ErrUnknownMapFormat, format, MapFormatJSON)
+		}
+		var loaded Map3D
+		if err := unmarshalMap(data, &loaded, func() error {
+			return e.checkMap3D(mapType, &loaded, mapAxis(m3), ErrInvalidMap)
+		}); err != nil {
+			return err
+		}
+		*m3 = loaded
+		return nil
+	}
+	return ErrUnknownMap
+}
+
+// replaceMap swaps in a new 2D map. When the breakpoints change, the layers of
+// its 3D map are resampled onto the new ones, and the learned table built on
+// its cells starts again. The learned tables themselves cannot be reshaped.
+func (e *ECU) replaceMap(mapType string, m *Map2D, loaded Map2D) error {
+	if m.sameShape(loaded) {
+		*m = loaded
+		return nil
+	}
+
+	switch mapType {
+	case MapTypeLTFT, MapTypeKnock:
+		return fmt.Errorf("%w: %s breakpoints must match the map it is learned on", ErrInvalidMap, mapType)
+	case MapTypeFuel:
+		e.ClosedLoop.LTFT = emptyMapLike(loaded)
+	case MapTypeIgnition:
+		e.KnockControl.Learned = emptyMapLike(loaded)
+	}
+
+	if m3, err := e.Map3D(mapType + map3DSuffix); err == nil {
+		for i := range m3.Layers {
+			m3.Layers[i] = m3.Layers[i].resample(loaded)
+		}
+	}
+
+	*m = loaded
+	return nil
+}
+
+// checkMap3D checks a 3D map before it replaces mapType: it has to be valid,
+// on the axis the settings select, and stack layers with the breakpoints of
+// the ECU's 2D map. Imports and tunes both go through it, and say with
+// mismatch what a wrong axis or breakpoints mean to them.
+func (e *ECU) checkMap3D(mapType string, m *Map3D, axis string, mismatch error) error {
+	if _, err := e.Map3D(mapType); err != nil {
+		return fmt.Errorf("%w %q", ErrUnknownMap, mapType)
+	}
+	if err := m.Validate(); err != nil {
+		return fmt.Errorf("%s map: %w", mapType, err)
+	}
+	for i := range m.Layers {
+		if err := checkCells(mapType, m.Layers[i].Values...); err != nil {
+			return err
+		}
+	}
+	if m.Axis != axis {
+		return fmt.Errorf("%w: %s map is on the %s axis but the settings select %q", mismatch, mapType, m.Axis, axis)
+	}
+
+	baseType := strings.TrimSuffix(mapType, map3DSuffix)
+	base, _ := e.Map(baseType)
+	for i := range m.Layers {
+		if !base.sameShape(m.Layers[i]) {
+			return fmt.Errorf("%w: %s layer %d breakpoints differ from the %s map's", mismatch, mapType, i+1, baseType)
+		}
+	}
+	return nil
+}
+
+// marshalMap encodes a map as indented JSON
+func marshalMap(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// unmarshalMap decodes a map from JSON, rejecting unknown fields, and validates it
+func unmarshalMap(data []byte, v any, validate func() error) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMap, err)
+	}
+	return validate()
+}
+
+// ExportMapFile writes the named map or table to path, in the format its
+// extension names
+func (e *ECU) ExportMapFile(mapType, path string) error {
+	data, err := e.ExportMap(mapType, MapFormatFromPath(path))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ImportMapFile replaces the named map or table with the one in the file at
+// path, in the format its extension names
+func (e *ECU) ImportMapFile(mapType, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := e.ImportMap(mapType, MapFormatFromPath(path), data); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package ecu
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// withAxis returns an ECU with the fuel 3D map enabled on the given axis
+func withAxis(t *testing.T, axis string) *ECU {
+	t.Helper()
+
+	e := NewECU()
+	settings := e.Settings()
+	settings.FuelMapAxis = axis
+	if err := e.ApplySettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+// lookup returns the map, table or 3D map of the given type
+func lookup(t *testing.T, e *ECU, mapType string) any {
+	t.Helper()
+
+	if m, err := e.Map(mapType); err == nil {
+		return m
+	}
+	if m, err := e.Table(mapType); err == nil {
+		return m
+	}
+	if m, err := e.Map3D(mapType); err == nil {
+		return m
+	}
+	t.Fatalf("no map %q", mapType)
+	return nil
+}
+
+func TestMapRoundTrip(t *testing.T) {
+	tests := []struct {
+		mapType string
+		formats []string
+	}{
+		{MapTypeFuel, []string{MapFormatCSV, MapFormatJSON}},
+		{MapTypeIgnition, []string{MapFormatCSV, MapFormatJSON}},
+		{MapTypeLTFT, []string{MapFormatCSV, MapFormatJSON}},
+		{MapTypeWarmup, []string{MapFormatCSV, MapFormatJSON}},
+		{MapTypeDeadTime, []string{MapFormatCSV, MapFormatJSON}},
+		{MapTypeFuel3D, []string{MapFormatJSON}},
+	}
+
+	for _, tt := range tests {
+		for _, format := range tt.formats {
+			t.Run(tt.mapType+"/"+format, func(t *testing.T) {
+				src, dst := withAxis(t, Axis3DGear), withAxis(t, Axis3DGear)
+
+				// Change a cell with an awkward value so the import is not a no-op
+				switch m := lookup(t, src, tt.mapType).(type) {
+				case *Map2D:
+					m.Values[1][2] = 1.0 / 3.0
+				case *Map1D:
+					m.Values[1] = 1.0 / 3.0
+				case *Map3D:
+					m.Layers[2].Values[1][2] = 1.0 / 3.0
+				}
+
+				data, err := src.ExportMap(tt.mapType, format)
+				if err != nil {
+					t.Fatalf("export: %v", err)
+				}
+				if err := dst.ImportMap(tt.mapType, format, data); err != nil {
+					t.Fatalf("import: %v\n%s", err, data)
+				}
+
+				if got, want := lookup(t, dst, tt.mapType), lookup(t, src, tt.mapType); !reflect.DeepEqual(got, want) {
+					t.Errorf("imported map differs from the exported one:\ngot  %+v\nwant %+v", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestImportMapRejectsInvalid(t *testing.T) {
+	// 3D fuel map whose layers do not match the 2D fuel map
+	small := Map2D{
+		RPMBreakpoints:  []float64{1000, 8000},
+		LoadBreakpoints: []float64{0, 50, 100},
+		Values:          [][]float64{{2, 3, 4}, {5, 6, 7}},
+	}
+	smallLayers, _ := NewMap3D(Axis3DGear, small)
+	smallLayersJSON, _ := marshalMap(smallLayers)
+
+	// 3D fuel map on the engine temperature axis, while the ECU uses gear
+	e := NewECU()
+	tempAxis, _ := NewMap3D(Axis3DEngineTemp, e.FuelMap.Map2D)
+	tempAxisJSON, _ := marshalMap(tempAxis)
+
+	tests := []struct {
+		name    string
+		mapType string
+		format  string
+		data    string
+		want    error
+	}{
+		{"unknown format", MapTypeFuel, "xlsx", "", ErrUnknownMapFormat},
+		{"unknown map", "boost", MapFormatCSV, "rpm\\load,0,100\n1000,1,2\n2000,3,4\n", ErrUnknownMap},
+		{"RPM breakpoints not increasing", MapTypeFuel, MapFormatCSV, "rpm\\load,0,100\n2000,1,2\n1000,3,4\n", ErrInvalidMap},
+		{"load breakpoints not increasing", MapTypeFuel, MapFormatCSV, "rpm\\load,100,0\n1000,1,2\n2000,3,4\n", ErrInvalidMap},
+		{"single load breakpoint", MapTypeFuel, MapFormatCSV, "rpm\\load,0\n1000,1\n2000,3\n", ErrInvalidMap},
+		{"ragged row", MapTypeFuel, MapFormatCSV, "rpm\\load,0,100\n1000,1,2\n2000,3\n", ErrInvalidMap},
+		{"non-numeric cell", MapTypeFuel, MapFormatCSV, "rpm\\load,0,100\n1000,1,rich\n2000,3,4\n", ErrInvalidMap},
+		{"non-finite cell", MapTypeFuel, MapFormatCSV, "rpm\\load,0,100\n1000,1,NaN\n2000,3,4\n", ErrInvalidMap},
+		{"AFR target of zero", MapTypeAFR, MapFormatCSV, "rpm\\load,0,100\n1000,14.7,0\n2000,13,12.5\n", ErrInvalidMap},
+		{"header only", MapTypeFuel, MapFormatCSV, "rpm\\load,0,100\n", ErrInvalidMap},
+		{"unknown JSON field", MapTypeFuel, MapFormatJSON, `{"rpm_breakpoints":[1000,2000],"load_breakpoints":[0,100],"values":[[1,2],[3,4]],"boost":1}`, ErrInvalidMap},
+		{"JSON rows missing", MapTypeFuel, MapFormatJSON, `{"rpm_breakpoints":[1000,2000],"load_breakpoints":[0,100],"values":[[1,2]]}`, ErrInvalidMap},
+		{"table row with extra cells", MapTypeWarmup, MapFormatCSV, "engine_temp,value\n0,30,1\n80,0\n", ErrInvalidMap},
+		{"learned table reshaped", MapTypeLTFT, MapFormatCSV, "rpm\\load,0,100\n1000,0,0\n2000,0,0\n", ErrInvalidMap},
+		{"3D map as CSV", MapTypeFuel3D, MapFormatCSV, "rpm\\load,0,100\n1000,1,2\n2000,3,4\n", ErrUnknownMapFormat},
+		{"3D map on another axis", MapTypeFuel3D, MapFormatJSON, string(tempAxisJSON), ErrInvalidMap},
+		{"3D layers off the 2D breakpoints", MapTypeFuel3D, MapFormatJSON, string(smallLayersJSON), ErrInvalidMap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := withAxis(t, Axis3DGear)
+			before, _ := e.ExportMap(tt.mapType, MapFormatJSON)
+
+			err := e.ImportMap(tt.mapType, tt.format, []byte(tt.data))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+
+			if after, _ := e.ExportMap(tt.mapType, MapFormatJSON); !bytes.Equal(before, after) {
+				t.Error("refused import changed the map")
+			}
+		})
+	}
+}
+
+func TestImportMap3DNeedsEnabledMap(t *testing.T) {
+	src := withAxis(t, Axis3DGear)
+	data, err := src.ExportMap(MapTypeFuel3D, MapFormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewECU().ImportMap(MapTypeFuel3D, MapFormatJSON, data); !errors.Is(err, ErrInvalidMap) {
+		t.Errorf("import into a disabled 3D map: got %v, want %v", err, ErrInvalidMap)
+	}
+}
+
+func TestImportMapReshape(t *testing.T) {
+	e := withAxis(t, Axis3DGear)
+
+	csv := "rpm\\load,0,50,100\n1000,2,3,4\n9000,5,6,7\n"
+	if err := e.ImportMap(MapTypeFuel, MapFormatCSV, []byte(csv)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Everything built on the fuel map's cells follows its new breakpoints
+	for i, layer := range e.FuelMap3D.Layers {
+		if !e.FuelMap.sameShape(layer) {
+			t.Errorf("fuel_3d layer %d kept the old breakpoints", i+1)
+		}
+	}
+	if !e.FuelMap.sameShape(e.ClosedLoop.LTFT) {
+		t.Error("LTFT kept the old breakpoints")
+	}
+
+	// The session has to be able to reload its own tune
+	tune, err := e.Tune(TuneInfo{Name: "reshaped"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bike := Hardware{ExhaustType: e.ExhaustType, Injector: e.FuelSystem.Injector}
+	if err := e.LoadTune(tune, bike); err != nil {
+		t.Fatalf("reload own tune after a reshape: %v", err)
+	}
+}
@@ -1,14 +1,27 @@
 package ecu
 
 import (
+	"errors"
 	"math"
 )
 
+// Map type names used to address the ECU maps
+const (
+	MapTypeFuel     = "fuel"
+	MapTypeIgnition = "ignition"
+	MapTypeAFR      = "afr"
+	MapTypeLTFT     = "ltft"  // Learned long-term fuel trim
+	MapTypeKnock    = "knock" // Learned knock retard
+)
+
+// ErrUnknownMap is returned when a map type name is not recognized
+var ErrUnknownMap = errors.New("unknown map type")
+
 // Map2D represents a 2D lookup table with RPM and load breakpoints
 type Map2D struct {
-	RPMBreakpoints  []float64
-	LoadBreakpoints []float64
-	Values          [][]float64
+	RPMBreakpoints  []float64   `json:"rpm_breakpoints"`
+	LoadBreakpoints []float64   `json:"load_breakpoints"`
+	Values          [][]float64 `json:"values"` // Rows are RPM, columns are load
 }
 
 // FuelMap represents the fuel map of the ECU
@@ -28,14 +41,28 @@ type AFRMap struct {
 
 // GetValue retrieves an interpolated value from a 2D map
 func (m *Map2D) GetValue(rpm, load float64) float64 {
+	rpmLowIdx, rpmHighIdx, loadLowIdx, loadHighIdx, rpmFactor, loadFactor := m.bracket(rpm, load)
+
+	// Get the four corner values
+	v1 := m.Values[rpmLowIdx][loadLowIdx]
+	v2 := m.Values[rpmHighIdx][loadLowIdx]
+	v3 := m.Values[rpmLowIdx][loadHighIdx]
+	v4 := m.Values[rpmHighIdx][loadHighIdx]
+
+	// Bilinear interpolation
+	v12 := v1 + rpmFactor*(v2-v1)
+	v34 := v3 + rpmFactor*(v4-v3)
+
+	return v12 + loadFactor*(v34-v12)
+}
+
+// bracket finds the breakpoints either side of rpm and load, and how far
+// between them (0-1) the point lies
+func (m *Map2D) bracket(rpm, load float64) (rpmLowIdx, rpmHighIdx, loadLowIdx, loadHighIdx int, rpmFactor, loadFactor float64) {
 	// Ensure RPM and load are within bounds
 	rpm = math.Max(m.RPMBreakpoints[0], math.Min(rpm, m.RPMBreakpoints[len(m.RPMBreakpoints)-1]))
 	load = math.Max(m.LoadBreakpoints[0], math.Min(load, m.LoadBreakpoints[len(m.LoadBreakpoints)-1]))
 
-	// Find the indices for interpolation
-	rpmLowIdx, rpmHighIdx := 0, 0
-	loadLowIdx, loadHighIdx := 0, 0
-
 	// Find RPM indices
 	for i := 0; i < len(m.RPMBreakpoints)-1; i++ {
 		if rpm >= m.RPMBreakpoints[i] && rpm <= m.RPMBreakpoints[i+1] {
@@ -54,33 +81,55 @@ func (m *Map2D) GetValue(rpm, load float64) float64 {
 		}
 	}
 
-	// Get the four corner values
-	v1 := m.Values[rpmLowIdx][loadLowIdx]
-	v2 := m.Values[rpmHighIdx][loadLowIdx]
-	v3 := m.Values[rpmLowIdx][loadHighIdx]
-	v4 := m.Values[rpmHighIdx][loadHighIdx]
-
 	// Calculate interpolation factors
-	rpmFactor := 0.0
 	if m.RPMBreakpoints[rpmHighIdx] != m.RPMBreakpoints[rpmLowIdx] {
 		rpmFactor = (rpm - m.RPMBreakpoints[rpmLowIdx]) / (m.RPMBreakpoints[rpmHighIdx] - m.RPMBreakpoints[rpmLowIdx])
 	}
-
-	loadFactor := 0.0
 	if m.LoadBreakpoints[loadHighIdx] != m.LoadBreakpoints[loadLowIdx] {
 		loadFactor = (load - m.LoadBreakpoints[loadLowIdx]) / (m.LoadBreakpoints[loadHighIdx] - m.LoadBreakpoints[loadLowIdx])
 	}
 
-	// Bilinear interpolation
-	v12 := v1 + rpmFactor*(v2-v1)
-	v34 := v3 + rpmFactor*(v4-v3)
+	return rpmLowIdx, rpmHighIdx, loadLowIdx, loadHighIdx, rpmFactor, loadFactor
+}
 
-	return v12 + loadFactor*(v34-v12)
+// AddInterpolated spreads delta over the four cells around rpm and load in
+// proportion to their interpolation weights, keeping every cell within
+// [lo, hi]. It returns how much the interpolated value at the point changed.
+func (m *Map2D) AddInterpolated(rpm, load, delta, lo, hi float64) float64 {
+	r0, r1, l0, l1, rf, lf := m.bracket(rpm, load)
+
+	cells := [4]struct {
+		i, j   int
+		weight float64
+	}{
+		{r0, l0, (1 - rf) * (1 - lf)},
+		{r1, l0, rf * (1 - lf)},
+		{r0, l1, (1 - rf) * lf},
+		{r1, l1, rf * lf},
+	}
+
+	applied := 0.0
+	for _, c := range cells {
+		if c.weight == 0 {
+			continue
+		}
+		current := m.Values[c.i][c.j]
+		updated := math.Max(lo, math.Min(hi, current+delta*c.weight))
+		m.Values[c.i][c.j] = updated
+		applied += (updated - current) * c.weight
+	}
+
+	return applied
 }
 
 // SetValue sets a value in the map at the nearest breakpoints
 func (m *Map2D) SetValue(rpm, load, value float64) {
-	// Find the nearest RPM and load breakpoints
+	i, j := m.nearestCell(rpm, load)
+	m.Values[i][j] = value
+}
+
+// nearestCell returns the row and column of the breakpoints closest to rpm and load
+func (m *Map2D) nearestCell(rpm, load float64) (int, int) {
 	nearestRPMIdx := 0
 	nearestLoadIdx := 0
 
@@ -103,8 +152,59 @@ func (m *Map2D) SetValue(rpm, load, value float64) {
 		}
 	}
 
-	// Set the value
-	m.Values[nearestRPMIdx][nearestLoadIdx] = value
+	return nearestRPMIdx, nearestLoadIdx
+}
+
+// sameShape reports whether other has the same breakpoints as m
+func (m *Map2D) sameShape(other Map2D) bool {
+	if len(m.RPMBreakpoints) != len(other.RPMBreakpoints) || len(m.LoadBreakpoints) != len(other.LoadBreakpoints) {
+		return false
+	}
+	for i := range m.RPMBreakpoints {
+		if m.RPMBreakpoints[i] != other.RPMBreakpoints[i] {
+			return false
+		}
+	}
+	for i := range m.LoadBreakpoints {
+		if m.LoadBreakpoints[i] != other.LoadBreakpoints[i] {
+			return false
+		}
+	}
+	if len(other.Values) != len(other.RPMBreakpoints) {
+		return false
+	}
+	for _, row := range other.Values {
+		if len(row) != len(other.LoadBreakpoints) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of the map
+func (m *Map2D) Clone() Map2D {
+	values := make([][]float64, len(m.Values))
+	for i, row := range m.Values {
+		values[i] = append([]float64(nil), row...)
+	}
+
+	return Map2D{
+		RPMBreakpoints:  append([]float64(nil), m.RPMBreakpoints...),
+		LoadBreakpoints: append([]float64(nil), m.LoadBreakpoints...),
+		Values:          values,
+	}
+}
+
+// resample returns the map read at the breakpoints of shape, interpolating
+// between its own cells
+func (m *Map2D) resample(shape Map2D) Map2D {
+	out := emptyMapLike(shape)
+	for i, rpm := range out.RPMBreakpoints {
+		for j, load := range out.LoadBreakpoints {
+			out.Values[i][j] = m.GetValue(rpm, load)
+		}
+	}
+	return out
 }
 
 // ModifyRegion modifies values in a region of the map by a percentage or fixed amount
@@ -129,20 +229,22 @@ func DefaultNinja650FuelMap() FuelMap {
 	// Load breakpoints (0 to 100, 10% steps)
 	loadBreakpoints := []float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
 
-	// Create a default fuel map (values are multipliers, 1.0 = baseline)
+	// Create a default fuel map (values are multipliers on the ECU's calculated
+	// fuel, 1.0 = baseline). The stock calibration follows the intake resonance
+	// around 7500 RPM that the ECU's airflow estimate does not model.
 	// Rows are RPM, columns are load
 	values := [][]float64{
-		{0.85, 0.88, 0.90, 0.92, 0.94, 0.96, 0.98, 1.00, 1.02, 1.04, 1.06}, // 1000 RPM
-		{0.86, 0.89, 0.91, 0.93, 0.95, 0.97, 0.99, 1.01, 1.03, 1.05, 1.07}, // 2000 RPM
-		{0.87, 0.90, 0.92, 0.94, 0.96, 0.98, 1.00, 1.02, 1.04, 1.06, 1.08}, // 3000 RPM
-		{0.88, 0.91, 0.93, 0.95, 0.97, 0.99, 1.01, 1.03, 1.05, 1.07, 1.09}, // 4000 RPM
-		{0.89, 0.92, 0.94, 0.96, 0.98, 1.00, 1.02, 1.04, 1.06, 1.08, 1.10}, // 5000 RPM
-		{0.90, 0.93, 0.95, 0.97, 0.99, 1.01, 1.03, 1.05, 1.07, 1.09, 1.11}, // 6000 RPM
-		{0.91, 0.94, 0.96, 0.98, 1.00, 1.02, 1.04, 1.06, 1.08, 1.10, 1.12}, // 7000 RPM
-		{0.92, 0.95, 0.97, 0.99, 1.01, 1.03, 1.05, 1.07, 1.09, 1.11, 1.13}, // 8000 RPM
-		{0.93, 0.96, 0.98, 1.00, 1.02, 1.04, 1.06, 1.08, 1.10, 1.12, 1.14}, // 9000 RPM
-		{0.94, 0.97, 0.99, 1.01, 1.03, 1.05, 1.07, 1.09, 1.11, 1.13, 1.15}, // 10000 RPM
-		{0.95, 0.98, 1.00, 1.02, 1.04, 1.06, 1.08, 1.10, 1.12, 1.14, 1.16}, // 11000 RPM
+		{1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00}, // 1000 RPM
+		{1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00}, // 2000 RPM
+		{1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00}, // 3000 RPM
+		{1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00}, // 4000 RPM
+		{1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00}, // 5000 RPM
+		{1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01}, // 6000 RPM
+		{1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04}, // 7000 RPM
+		{1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04, 1.04}, // 8000 RPM
+		{1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01, 1.01}, // 9000 RPM
+		{1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00}, // 10000 RPM
+		{1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00, 1.00}, // 11000 RPM
 	}
 
 	return FuelMap{
@@ -0,0 +1,147 @@
+package ecu
+
+import (
+	"math"
+
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+)
+
+// TransientFueling corrects the steady-state fuel calculation while the
+// throttle moves. Opening the throttle fast adds a decaying acceleration
+// enrichment; wall wetting compensation tracks the fuel film on the intake
+// ports and injects around it; closing the throttle at speed cuts fuel until
+// the RPM drops to the re-entry point or the throttle opens again.
+type TransientFueling struct {
+	// Acceleration enrichment, driven by how fast TPS and MAP rise
+	Enrichment       bool
+	TPSRateThreshold float64 // %/s, slower changes are ignored
+	TPSGain          float64 // % extra fuel per %/s above the threshold
+	MAPRateThreshold float64 // kPa/s
+	MAPGain          float64 // % extra fuel per kPa/s above the threshold
+	MaxEnrichment    float64 // %
+	DecayTime        float64 // s, time constant the enrichment fades with
+
+	// Wall wetting compensation
+	WallWetting bool
+
+	// Deceleration fuel cut
+	FuelCut           bool
+	CutMaxThrottle    float64 // % throttle at or below which fuel can be cut
+	CutMinRPM         float64 // Fuel is only cut above this RPM
+	ResumeRPM         float64 // Fuel comes back below this RPM; the gap to CutMinRPM stops it toggling
+	CutDelay          float64 // s the throttle has to be closed before cutting
+	closedThrottleFor float64
+
+	// Controller state
+	AccelEnrichment float64 // % extra fuel applied this cycle
+	Cut             bool    // Fuel cut active
+	film            float64 // Estimated port wall film, mg per cylinder
+	lastThrottle    float64
+	lastMAP         float64
+}
+
+// NewTransientFueling creates transient fueling with every correction enabled
+func NewTransientFueling() TransientFueling {
+	return TransientFueling{
+		Enrichment:       true,
+		TPSRateThreshold: 50.0,
+		TPSGain:          0.02,
+		MAPRateThreshold: 50.0,
+		MAPGain:          0.02,
+		MaxEnrichment:    10.0,
+		DecayTime:        0.3,
+
+		WallWetting: true,
+
+		FuelCut:        true,
+		CutMaxThrottle: 1.0,
+		CutMinRPM:      2500.0,
+		ResumeRPM:      1800.0,
+		CutDelay:       0.3,
+	}
+}
+
+// Active reports whether a transient correction is changing the fuel, which
+// makes the O2 reading useless for closed-loop control
+func (t *TransientFueling) Active() bool {
+	return t.Cut || t.AccelEnrichment > 1.0
+}
+
+// update takes the fuel the cylinder should get this cycle (mg) and returns the
+// fuel to inject
+func (t *TransientFueling) update(e *ECU, desired, dt float64) float64 {
+	// Rates of change since the last cycle
+	throttleRate, mapRate := 0.0, 0.0
+	if dt > 0 {
+		throttleRate = (e.ThrottlePosition - t.lastThrottle) / dt
+		mapRate = (e.MAP - t.lastMAP) / dt
+	}
+	t.lastThrottle = e.ThrottlePosition
+	t.lastMAP = e.MAP
+
+	t.updateFuelCut(e, dt)
+	t.updateEnrichment(throttleRate, mapRate, dt)
+
+	fuel := desired * (1.0 + t.AccelEnrichment/100.0)
+	if t.Cut {
+		fuel = 0
+	}
+
+	// Inject around the port wall film
+	fraction, evaporationTime := engine.WallFilmParameters(e.MAP, e.EngineTemp)
+	if dt == 0 {
+		// First cycle: the engine starts out idling, so assume the film has settled
+		t.film = engine.SettledWallFilm(fuel, fraction, evaporationTime, e.RPM)
+	}
+	if t.WallWetting && !t.Cut && e.RPM > 0 && dt > 0 {
+		// The cylinder gets (1 - fraction) of the new fuel, plus what evaporates
+		// off the film, which itself grows with the new fuel
+		cycles := e.RPM / 120.0
+		decay := math.Exp(-dt / evaporationTime)
+		fromFilm := t.film * decay / evaporationTime / cycles
+		fuel = math.Max(0, (fuel-fromFilm)/((1.0-fraction)+fraction*(1.0-decay)))
+	}
+
+	// Follow the film whether or not it is being compensated
+	t.film, _ = engine.UpdateWallFilm(t.film, fuel, fraction, evaporationTime, e.RPM, dt)
+
+	return fuel
+}
+
+// updateFuelCut starts cutting fuel once the throttle has been closed for a
+// moment at high RPM, and resumes it at the re-entry RPM or on throttle
+func (t *TransientFueling) updateFuelCut(e *ECU, dt float64) {
+	if e.ThrottlePosition <= t.CutMaxThrottle {
+		t.closedThrottleFor += dt
+	} else {
+		t.closedThrottleFor = 0
+	}
+
+	switch {
+	case !t.FuelCut || t.closedThrottleFor == 0:
+		t.Cut = false
+	case t.Cut && e.RPM < t.ResumeRPM:
+		t.Cut = false
+	case !t.Cut && e.RPM > t.CutMinRPM && t.closedThrottleFor >= t.CutDelay:
+		t.Cut = true
+	}
+}
+
+// updateEnrichment adds fuel for a fast throttle opening and lets it fade out
+func (t *TransientFueling) updateEnrichment(throttleRate, mapRate, dt float64) {
+	if !t.Enrichment {
+		t.AccelEnrichment = 0
+		return
+	}
+
+	// Fade what is left from earlier
+	if dt > 0 {
+		t.AccelEnrichment *= math.Exp(-dt / t.DecayTime)
+	}
+
+	// A faster opening asks for more
+	demand := t.TPSGain*math.Max(0, throttleRate-t.TPSRateThreshold) +
+		t.MAPGain*math.Max(0, mapRate-t.MAPRateThreshold)
+
+	t.AccelEnrichment = math.Min(t.MaxEnrichment, math.Max(t.AccelEnrichment, demand))
+}
@@ -0,0 +1,147 @@
+package ecu
+
+import (
+	"math"
+	"testing"
+
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+)
+
+// transientECU returns a warm ECU at a steady part throttle, with the first
+// transient cycle run so the rates start from here
+func transientECU() *ECU {
+	e := NewECU()
+	e.EngineTemp = 90
+	e.RPM = 5000
+	e.MAP = 60
+	e.ThrottlePosition = 20
+	e.Transient.WallWetting = false
+	e.Transient.update(e, 10, 0)
+	return e
+}
+
+func TestTipInEnrichment(t *testing.T) {
+	e := transientECU()
+	tr := &e.Transient
+	const dt = 0.05
+
+	// 20% to 60% in one cycle asks for far more than the cap
+	e.ThrottlePosition = 60
+	if fuel := tr.update(e, 10, dt); math.Abs(fuel-10*(1+tr.MaxEnrichment/100)) > 1e-9 {
+		t.Errorf("fuel on tip-in = %g mg, want %g", fuel, 10*(1+tr.MaxEnrichment/100))
+	}
+	if tr.AccelEnrichment != tr.MaxEnrichment || !tr.Active() {
+		t.Errorf("enrichment = %g%%, want the %g%% cap", tr.AccelEnrichment, tr.MaxEnrichment)
+	}
+
+	// Holding the throttle lets it fade with the decay time
+	steps := int(math.Round(tr.DecayTime / dt))
+	for range steps {
+		tr.update(e, 10, dt)
+	}
+	if want := tr.MaxEnrichment * math.Exp(-1); math.Abs(tr.AccelEnrichment-want) > 1e-9 {
+		t.Errorf("enrichment after one decay time = %g%%, want %g%%", tr.AccelEnrichment, want)
+	}
+}
+
+func TestSlowThrottleNoEnrichment(t *testing.T) {
+	e := transientECU()
+	tr := &e.Transient
+	const dt = 0.05
+
+	// 20%/s is below the threshold
+	for range 20 {
+		e.ThrottlePosition += 20 * dt
+		if fuel := tr.update(e, 10, dt); fuel != 10 {
+			t.Fatalf("fuel while rolling on = %g mg, want 10", fuel)
+		}
+	}
+
+	tr.Enrichment = false
+	e.ThrottlePosition = 100
+	if tr.update(e, 10, dt); tr.AccelEnrichment != 0 {
+		t.Errorf("disabled enrichment added %g%%", tr.AccelEnrichment)
+	}
+}
+
+func TestDecelFuelCut(t *testing.T) {
+	e := transientECU()
+	tr := &e.Transient
+	const dt = 0.05
+
+	// Closing the throttle at speed cuts fuel once it has been shut for the delay
+	e.ThrottlePosition = 0
+	for elapsed := dt; elapsed < tr.CutDelay-1e-9; elapsed += dt {
+		if tr.update(e, 10, dt); tr.Cut {
+			t.Fatalf("cut after %g s, before the %g s delay", elapsed, tr.CutDelay)
+		}
+	}
+	if fuel := tr.update(e, 10, dt); !tr.Cut || fuel != 0 {
+		t.Fatalf("cut %v fuel %g after the delay, want no fuel", tr.Cut, fuel)
+	}
+
+	// Still cut between the re-entry and cut RPM, back on below re-entry
+	e.RPM = (tr.ResumeRPM + tr.CutMinRPM) / 2
+	if tr.update(e, 10, dt); !tr.Cut {
+		t.Error("fuel came back above the re-entry RPM")
+	}
+	e.RPM = tr.ResumeRPM - 100
+	if fuel := tr.update(e, 10, dt); tr.Cut || fuel == 0 {
+		t.Error("fuel still cut below the re-entry RPM")
+	}
+
+	// The hysteresis keeps it on until the RPM rises past the cut point again
+	e.RPM = (tr.ResumeRPM + tr.CutMinRPM) / 2
+	if tr.update(e, 10, dt); tr.Cut {
+		t.Error("fuel cut again inside the hysteresis band")
+	}
+
+	// Opening the throttle ends a cut straight away
+	e.RPM = 6000
+	for range 10 {
+		tr.update(e, 10, dt)
+	}
+	if !tr.Cut {
+		t.Fatal("no cut at 6000 RPM with the throttle shut")
+	}
+	e.ThrottlePosition = 5
+	if tr.update(e, 10, dt); tr.Cut {
+		t.Error("fuel still cut with the throttle open")
+	}
+}
+
+func TestWallWettingCompensation(t *testing.T) {
+	e := transientECU()
+	tr := &e.Transient
+	tr.Enrichment = false
+	tr.WallWetting = true
+	const dt = 0.05
+
+	// The engine's port film, started settled like the ECU's
+	fraction, evaporationTime := engine.WallFilmParameters(e.MAP, e.EngineTemp)
+	injected := tr.update(e, 10, 0)
+	film := engine.SettledWallFilm(injected, fraction, evaporationTime, e.RPM)
+
+	// Step the fuel demand: without compensation the cylinder would lag behind
+	var first float64
+	for i := range 20 {
+		injected = tr.update(e, 15, dt)
+		if i == 0 {
+			first = injected
+		}
+
+		var delivered float64
+		film, delivered = engine.UpdateWallFilm(film, injected, fraction, evaporationTime, e.RPM, dt)
+		if math.Abs(delivered-15) > 0.01 {
+			t.Fatalf("cycle %d: %g mg reached the cylinder, want 15", i, delivered)
+		}
+	}
+
+	// Wetting the wall takes extra fuel at first, none once the film has built up
+	if first <= 15 {
+		t.Errorf("first injection after the step = %g mg, want extra for the film", first)
+	}
+	if math.Abs(injected-15) > 0.01 {
+		t.Errorf("settled injection = %g mg, want 15", injected)
+	}
+}
@@ -0,0 +1,301 @@
+package ecu
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+)
+
+// TuneVersion is the tune file format this version of the simulator writes.
+// Tunes from older formats still load; newer ones are refused.
+const TuneVersion = 1
+
+// Errors returned when loading tunes
+var (
+	ErrTuneVersion      = errors.New("unsupported tune file version")
+	ErrTuneChecksum     = errors.New("tune checksum does not match its contents")
+	ErrTuneIncompatible = errors.New("tune is not compatible with this bike")
+	ErrInvalidTune      = errors.New("invalid tune file")
+)
+
+// Map types a tune carries. The learned tables are left out: they adapt to the
+// bike the tune runs on, and start again whenever a tune is loaded.
+var (
+	tuneMaps   = []string{MapTypeFuel, MapTypeIgnition, MapTypeAFR}
+	tuneTables = []string{
+		MapTypeWarmup, MapTypeAfterStart, MapTypeColdRetard, MapTypeColdIdleRPM,
+		MapTypeIATFuel, MapTypeIATIgnition, MapTypeBaroFuel, MapTypeDeadTime,
+	}
+	tuneMaps3D = []string{MapTypeFuel3D, MapTypeIgnition3D, MapTypeAFR3D}
+)
+
+// Hardware is the bike hardware a tune is calibrated for
+type Hardware struct {
+	ExhaustType string          `json:"exhaust_type"`
+	Injector    engine.Injector `json:"injector"`
+}
+
+// TuneInfo describes who made a tune and when
+type TuneInfo struct {
+	Name     string    `json:"name"`
+	Author   string    `json:"author,omitempty"`
+	Notes    string    `json:"notes,omitempty"`
+	Revision int       `json:"revision"` // Counts up each time the tune is saved from a loaded copy
+	Created  time.Time `json:"created"`
+}
+
+// Tune bundles a whole calibration: every map and table, the settings and
+// preset, and the hardware it was built for. The checksum covers everything
+// else in the tune, so a damaged or hand-edited file is caught on loading.
+type Tune struct {
+	Version int `json:"version"` // File format, TuneVersion when written
+	TuneInfo
+
+	Preset   string   `json:"preset,omitempty"`
+	Settings Settings `json:"settings"`
+	Hardware Hardware `json:"hardware"`
+
+	Maps   map[string]Map2D `json:"maps"`              // By map type
+	Tables map[string]Map1D `json:"tables"`            // By table type
+	Maps3D map[string]Map3D `json:"maps_3d,omitempty"` // Enabled 3D maps by map type
+
+	Checksum string `json:"checksum"` // SHA-256 of the tune with an empty checksum, hex encoded
+}
+
+// Tune bundles the ECU's current calibration with the given description
+func (e *ECU) Tune(info TuneInfo) (Tune, error) {
+	t := Tune{
+		Version:  TuneVersion,
+		TuneInfo: info,
+
+		Preset:   e.Preset,
+		Settings: e.Settings(),
+		Hardware: Hardware{ExhaustType: e.ExhaustType, Injector: e.FuelSystem.Injector},
+
+		Maps:   make(map[string]Map2D, len(tuneMaps)),
+		Tables: make(map[string]Map1D, len(tuneTables)),
+		Maps3D: make(map[string]Map3D),
+	}
+
+	for _, mapType := range tuneMaps {
+		m, _ := e.Map(mapType)
+		t.Maps[mapType] = m.Clone()
+	}
+	for _, mapType := range tuneTables {
+		m, _ := e.Table(mapType)
+		t.Tables[mapType] = m.Clone()
+	}
+	for _, mapType := range tuneMaps3D {
+		if m, _ := e.Map3D(mapType); m.Enabled() {
+			t.Maps3D[mapType] = m.Clone()
+		}
+	}
+
+	sum, err := t.sum()
+	if err != nil {
+		return Tune{}, err
+	}
+	t.Checksum = sum
+	return t, nil
+}
+
+// sum works out the tune's checksum
+func (t Tune) sum() (string, error) {
+	t.Checksum = ""
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Verify checks the tune's format version and checksum
+func (t *Tune) Verify() error {
+	if t.Version < 1 || t.Version > TuneVersion {
+		return fmt.Errorf("%w %d, this simulator reads up to %d", ErrTuneVersion, t.Version, TuneVersion)
+	}
+
+	sum, err := t.sum()
+	if err != nil {
+		return err
+	}
+	if sum != t.Checksum {
+		return ErrTuneChecksum
+	}
+	return nil
+}
+
+// Compatible checks that a tune can be loaded into this ECU on a bike with the
+// given hardware: it has to be built for the same exhaust and injectors, and
+// every map and table must have the ECU's breakpoints.
+func (e *ECU) Compatible(t *Tune, bike Hardware) error {
+	if t.Hardware.ExhaustType != bike.ExhaustType {
+		return fmt.Errorf("%w: built for a %s exhaust, the bike has a %s", ErrTuneIncompatible, t.Hardware.ExhaustType, bike.ExhaustType)
+	}
+	if t.Hardware.Injector != bike.Injector {
+		return fmt.Errorf("%w: built for injectors flowing %s, the bike's flow %s", ErrTuneIncompatible, injectorString(t.Hardware.Injector), injectorString(bike.Injector))
+	}
+
+	for mapType, m := range t.Maps {
+		current, err := e.Map(mapType)
+		if err != nil || !slices.Contains(tuneMaps, mapType) {
+			return fmt.Errorf("%w %q in tune", ErrUnknownMap, mapType)
+		}
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("%s map: %w", mapType, err)
+		}
+		if err := checkCells(mapType, m.Values...); err != nil {
+			return err
+		}
+		if !current.sameShape(m) {
+			return fmt.Errorf("%w: %s map breakpoints differ from the ECU's", ErrTuneIncompatible, mapType)
+		}
+	}
+
+	for mapType, m := range t.Tables {
+		current, err := e.Table(mapType)
+		if err != nil {
+			return fmt.Errorf("%w %q in tune", ErrUnknownMap, mapType)
+		}
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("%s table: %w", mapType, err)
+		}
+		if err := checkCells(mapType, m.Values); err != nil {
+			return err
+		}
+		if !slices.Equal(current.Breakpoints, m.Breakpoints) {
+			return fmt.Errorf("%w: %s table breakpoints differ from the ECU's", ErrTuneIncompatible, mapType)
+		}
+	}
+
+	axes := map[string]string{
+		MapTypeFuel3D:     t.Settings.FuelMapAxis,
+		MapTypeIgnition3D: t.Settings.IgnitionMapAxis,
+		MapTypeAFR3D:      t.Settings.AFRMapAxis,
+	}
+	for mapType, m := range t.Maps3D {
+		if err := e.checkMap3D(mapType, &m, axes[mapType], ErrTuneIncompatible); err != nil {
+			return err
+		}
+	}
+	for mapType, axis := range axes {
+		if _, ok := t.Maps3D[mapType]; !ok && axis != "" && axis != Axis3DNone {
+			return fmt.Errorf("%w: settings select a %s axis for %s but the tune has no such map", ErrInvalidTune, axis, mapType)
+		}
+	}
+
+	return nil
+}
+
+// injectorString describes injector data for error messages
+func injectorString(inj engine.Injector) string {
+	return fmt.Sprintf("%g cc/min at %g kPa with %g ms dead time", inj.FlowRate, inj.RatedPressure, inj.DeadTime)
+}
+
+// LoadTune replaces the ECU's calibration with a tune, after checking it is
+// intact and compatible with the bike's hardware. Maps and tables missing from
+// the tune, as in files saved before they existed, keep their current values.
+// The learned fuel trims and knock retard start again, as after a reflash.
+// Nothing changes if the tune is refused.
+func (e *ECU) LoadTune(t Tune, bike Hardware) error {
+	if err := t.Verify(); err != nil {
+		return err
+	}
+	if err := e.Compatible(&t, bike); err != nil {
+		return err
+	}
+	if t.Preset != "" {
+		if _, ok := TuningPresets[t.Preset]; !ok {
+			return fmt.Errorf("%w: unknown tuning preset %q", ErrInvalidTune, t.Preset)
+		}
+	}
+
+	// Settings come first: they check themselves before changing anything, and
+	// switch the 3D maps onto the tune's axes
+	if err := e.ApplySettings(t.Settings); err != nil {
+		return err
+	}
+	e.RevLimiter.GearLimits = append([]float64(nil), t.Settings.GearRevLimits...)
+
+	for mapType, m := range t.Maps {
+		current, _ := e.Map(mapType)
+		*current = m.Clone()
+	}
+	for mapType, m := range t.Tables {
+		current, _ := e.Table(mapType)
+		*current = m.Clone()
+	}
+	for mapType, m := range t.Maps3D {
+		current, _ := e.Map3D(mapType)
+		*current = m.Clone()
+	}
+
+	e.Preset = t.Preset
+	e.ExhaustType = t.Hardware.ExhaustType
+	e.FuelSystem.Injector = t.Hardware.Injector
+
+	e.ClosedLoop.LTFT = emptyMapLike(e.ClosedLoop.LTFT)
+	e.KnockControl.Learned = emptyMapLike(e.KnockControl.Learned)
+
+	e.TuneInfo = t.TuneInfo
+	return nil
+}
+
+// LoadTuneFile reads a tune saved with SaveTuneFile and verifies its checksum
+func LoadTuneFile(path string) (*Tune, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unknown fields would drop out of the checksum, so refuse them
+	var t Tune
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("parse tune %s: %w: %v", path, ErrInvalidTune, err)
+	}
+
+	if err := t.Verify(); err != nil {
+		return nil, fmt.Errorf("tune %s: %w", path, err)
+	}
+	return &t, nil
+}
+
+// SaveTuneFile writes a tune to path, replacing the file atomically
+func SaveTuneFile(path string, t Tune) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
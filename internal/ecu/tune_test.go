@@ -0,0 +1,191 @@
+package ecu
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testTune saves a modified calibration and returns it with the ECU it came from
+func testTune(t *testing.T) (*ECU, Tune) {
+	t.Helper()
+
+	e := withAxis(t, Axis3DGear)
+	e.FuelMap.Values[3][4] = 7.25
+	e.FuelMap3D.Layers[1].Values[2][2] = 6.5
+	e.WarmUp.Enrichment.Values[0] = 42
+	e.FuelTrim = 4
+
+	tune, err := e.Tune(TuneInfo{
+		Name:     "stage1",
+		Author:   "test",
+		Revision: 2,
+		Created:  time.Date(2024, 6, 1, 9, 30, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e, tune
+}
+
+// hardware returns the hardware the ECU is calibrated for
+func hardware(e *ECU) Hardware {
+	return Hardware{ExhaustType: e.ExhaustType, Injector: e.FuelSystem.Injector}
+}
+
+func TestTuneSaveLoad(t *testing.T) {
+	src, tune := testTune(t)
+
+	path := filepath.Join(t.TempDir(), "stage1.tune.json")
+	if err := SaveTuneFile(path, tune); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadTuneFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Verify(); err != nil {
+		t.Fatalf("verify loaded tune: %v", err)
+	}
+	if loaded.Checksum != tune.Checksum || loaded.TuneInfo != tune.TuneInfo {
+		t.Errorf("loaded tune %+v (%s), saved %+v (%s)", loaded.TuneInfo, loaded.Checksum, tune.TuneInfo, tune.Checksum)
+	}
+
+	dst := NewECU()
+	if err := dst.LoadTune(*loaded, hardware(dst)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst.Settings(), src.Settings()) {
+		t.Errorf("settings after loading = %+v, want %+v", dst.Settings(), src.Settings())
+	}
+	for _, mapType := range []string{MapTypeFuel, MapTypeWarmup, MapTypeFuel3D} {
+		if got, want := lookup(t, dst, mapType), lookup(t, src, mapType); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s map after loading differs from the saved one", mapType)
+		}
+	}
+	if dst.TuneInfo != tune.TuneInfo {
+		t.Errorf("ECU tune info = %+v, want %+v", dst.TuneInfo, tune.TuneInfo)
+	}
+}
+
+func TestLoadTuneFileRejectsDamage(t *testing.T) {
+	_, tune := testTune(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stage1.tune.json")
+	if err := SaveTuneFile(path, tune); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		old, new string
+		want     error
+	}{
+		{"edited value", `"fuel_trim": 4`, `"fuel_trim": 5`, ErrTuneChecksum},
+		{"unknown field", `"version": 1,`, `"version": 1, "boost": 1,`, ErrInvalidTune},
+		{"newer version", `"version": 1,`, `"version": 2,`, ErrTuneVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !bytes.Contains(data, []byte(tt.old)) {
+				t.Fatalf("tune file has no %s", tt.old)
+			}
+			damaged := filepath.Join(dir, "damaged.tune.json")
+			if err := os.WriteFile(damaged, bytes.Replace(data, []byte(tt.old), []byte(tt.new), 1), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := LoadTuneFile(damaged); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadTuneHardwareMismatch(t *testing.T) {
+	_, tune := testTune(t)
+
+	otherInjector := NewECU().FuelSystem.Injector
+	otherInjector.FlowRate *= 1.2
+
+	tests := []struct {
+		name   string
+		modify func(*Hardware)
+	}{
+		{"exhaust", func(h *Hardware) { h.ExhaustType = "Stock" }},
+		{"injectors", func(h *Hardware) { h.Injector = otherInjector }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewECU()
+			bike := hardware(e)
+			tt.modify(&bike)
+			before, _ := e.Tune(TuneInfo{})
+
+			if err := e.LoadTune(tune, bike); !errors.Is(err, ErrTuneIncompatible) {
+				t.Fatalf("got %v, want %v", err, ErrTuneIncompatible)
+			}
+
+			if after, _ := e.Tune(TuneInfo{}); after.Checksum != before.Checksum {
+				t.Error("refused tune changed the calibration")
+			}
+		})
+	}
+}
+
+func TestLoadTuneBreakpointMismatch(t *testing.T) {
+	small := Map2D{
+		RPMBreakpoints:  []float64{1000, 9000},
+		LoadBreakpoints: []float64{0, 100},
+		Values:          [][]float64{{10, 20}, {30, 40}},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(e *ECU, tune *Tune)
+	}{
+		{"2D map", func(e *ECU, tune *Tune) {
+			if err := e.ImportMap(MapTypeIgnition, MapFormatCSV, []byte("rpm\\load,0,100\n1000,10,20\n9000,30,40\n")); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"3D map layer", func(e *ECU, tune *Tune) {
+			tune.Maps3D[MapTypeFuel3D].Layers[2] = small
+		}},
+		{"3D map axis", func(e *ECU, tune *Tune) {
+			m := tune.Maps3D[MapTypeFuel3D]
+			m.Axis = Axis3DEngineTemp
+			tune.Maps3D[MapTypeFuel3D] = m
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, tune := testTune(t)
+			e := NewECU()
+			tt.modify(e, &tune)
+
+			// Sign the tune again so only the mismatch can refuse it
+			sum, err := tune.sum()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tune.Checksum = sum
+
+			if err := e.LoadTune(tune, hardware(e)); !errors.Is(err, ErrTuneIncompatible) {
+				t.Errorf("got %v, want %v", err, ErrTuneIncompatible)
+			}
+		})
+	}
+}
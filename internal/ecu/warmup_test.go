@@ -0,0 +1,88 @@
+package ecu
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWarmUpLookups(t *testing.T) {
+	tests := []struct {
+		temp                     float64
+		enrichment, retard, idle float64
+	}{
+		{-40, 30, 8, 1600}, // Held at the coldest breakpoint
+		{15, 17, 4.5, 1300},
+		{55, 6, 0.5, 975},
+		{90, 0, 0, 900},
+		{110, 0, 0, 900}, // Held at the hottest
+	}
+
+	for _, tt := range tests {
+		e := NewECU()
+		e.EngineTemp = tt.temp
+		e.WarmUp.update(e, 0)
+
+		w := e.WarmUp
+		if math.Abs(w.WarmupEnrichment-tt.enrichment) > 1e-9 || math.Abs(w.Retard-tt.retard) > 1e-9 || math.Abs(w.IdleTarget-tt.idle) > 1e-9 {
+			t.Errorf("at %g°C: enrichment %g%% retard %g° idle %g, want %g%% %g° %g",
+				tt.temp, w.WarmupEnrichment, w.Retard, w.IdleTarget, tt.enrichment, tt.retard, tt.idle)
+		}
+	}
+}
+
+func TestAfterStartDecays(t *testing.T) {
+	e := NewECU()
+	e.EngineTemp = 20
+	e.RPM = 1300
+	w := &e.WarmUp
+
+	w.update(e, 0)
+	if w.AfterStartEnrichment != 13 {
+		t.Fatalf("after-start enrichment at start = %g%%, want 13%%", w.AfterStartEnrichment)
+	}
+
+	for range 200 {
+		w.update(e, 0.1)
+	}
+	if want := 13 * math.Exp(-1); math.Abs(w.AfterStartEnrichment-want) > 1e-9 {
+		t.Errorf("after-start enrichment after 20 s = %g%%, want %g%%", w.AfterStartEnrichment, want)
+	}
+	if want := (1 + 0.15) * (1 + w.AfterStartEnrichment/100); math.Abs(w.FuelMultiplier()-want) > 1e-9 {
+		t.Errorf("fuel multiplier = %g, want %g", w.FuelMultiplier(), want)
+	}
+
+	// Stalling starts the clock again
+	e.RPM = 0
+	w.update(e, 0.1)
+	e.RPM = 1300
+	w.update(e, 0)
+	if w.AfterStartEnrichment != 13 {
+		t.Errorf("after-start enrichment on restart = %g%%, want 13%%", w.AfterStartEnrichment)
+	}
+}
+
+func TestWarmUpIdleFloor(t *testing.T) {
+	e := NewECU()
+	e.EngineTemp = 80
+	e.IdleRPM = 1100
+
+	e.WarmUp.update(e, 0)
+	if e.WarmUp.IdleTarget != 1100 {
+		t.Errorf("idle target = %g, want the higher IdleRPM of 1100", e.WarmUp.IdleTarget)
+	}
+}
+
+func TestWarmUpOff(t *testing.T) {
+	e := NewECU()
+	e.EngineTemp = -10
+	e.TempCompensation = false
+
+	e.WarmUp.update(e, 0)
+	w := e.WarmUp
+	if w.WarmupEnrichment != 0 || w.AfterStartEnrichment != 0 || w.Retard != 0 || w.IdleTarget != e.IdleRPM {
+		t.Errorf("corrections with compensation off: %+v", w)
+	}
+	if w.FuelMultiplier() != 1 {
+		t.Errorf("fuel multiplier = %g, want 1", w.FuelMultiplier())
+	}
+}
@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"time"
+)
+
+// Clock supplies the time the engine uses for sensor timestamps
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// Advance is called by the engine after every update with the simulated step
+	Advance(d time.Duration)
+}
+
+// WallClock follows the system clock and ignores simulated steps
+type WallClock struct{}
+
+// Now returns the system time
+func (WallClock) Now() time.Time {
+	return time.Now()
+}
+
+// Advance does nothing, real time moves on its own
+func (WallClock) Advance(time.Duration) {}
+
+// SimClock only moves when the simulation steps, so runs are reproducible
+type SimClock struct {
+	now time.Time
+}
+
+// NewSimClock creates a simulated clock starting at the given time
+func NewSimClock(start time.Time) *SimClock {
+	return &SimClock{now: start}
+}
+
+// Now returns the simulated time
+func (c *SimClock) Now() time.Time {
+	return c.now
+}
+
+// Advance moves the simulated time forward
+func (c *SimClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
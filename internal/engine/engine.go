@@ -15,6 +15,11 @@ type SensorData struct {
 	MAP               float64 // Manifold Absolute Pressure
 	O2                float64 // O2 sensor reading (lambda)
 	Speed             float64
+	KnockIntensity    float64 // Knock sensor signal, 0 = quiet
+	Baro              float64 // Barometric pressure, kPa
+	BatteryVoltage    float64 // V
+	Gear              int     // 0 = neutral
+	ClutchPosition    float64 // Clutch lever switch, 0 = engaged, 1 = pulled in
 	Timestamp         int64
 }
 
@@ -24,12 +29,15 @@ type ECUOutputs struct {
 	IgnitionAdvance   float64 // degrees BTDC
 	TargetIdleRPM     float64 // RPM
 	LambdaTarget      float64 // Target air/fuel ratio
+	SparkCut          float64 // Share of sparks skipped by the rev limiter, 0-1
+	FuelCut           float64 // Share of injections skipped by the rev limiter or failsafe, 0-1
 }
 
 // Engine model representing a Ninja 650 motorcycle
 type Engine struct {
 	// Basic engine specifications
 	Displacement     float64 // cc
+	Cylinders        int
 	CompressionRatio float64
 	MaxRPM           float64
 	IdleRPM          float64
@@ -43,18 +51,31 @@ type Engine struct {
 	EngineTemp       float64 // Celsius
 	AirTemp          float64 // Celsius
 	MAP              float64 // kPa
+	AirMass          float64 // mg of air per cylinder per cycle
+	AirFlow          float64 // g/s of air into the engine
+	FuelFilm         float64 // mg of fuel on each intake port wall
+	Lambda           float64 // Mixture actually burned, from fuel and air mass
+	Torque           float64 // Nm at the crank, negative when engine braking
 	O2Reading        float64 // Lambda value
+	KnockIntensity   float64 // Knock sensor signal from the last combustion event
+	RunTime          float64 // Seconds since the engine last started
 	Speed            float64 // km/h
 	Gear             int     // 0 = neutral, 1-6 = gears
 	BrakeApplied     bool
-	RevLimit         float64 // RPM at which rev limiter kicks in
 
 	// Environment settings
 	AmbientTemp float64 // Celsius
 	Altitude    float64 // meters
 
 	// Configuration
-	ExhaustType string // "Stock" or "Yoshimura Alpha 2", etc.
+	ExhaustType string     // "Stock" or "Yoshimura Alpha 2", etc.
+	Intake      Intake     // Air filter, throttle bodies and manifold
+	FuelSystem  FuelSystem // Fuel pressure and injectors
+
+	// Electrical system
+	BatteryVoltage  float64 // V at the battery, what the injectors see
+	RestVoltage     float64 // V without charging
+	ChargingVoltage float64 // V the regulator holds once the alternator is up to speed
 
 	// Physics parameters
 	Responsiveness    float64 // RPM rise rate
@@ -65,6 +86,11 @@ type Engine struct {
 	// Internal tracking
 	lastUpdateTime time.Time
 
+	// Time and randomness sources, injectable so runs can be reproduced exactly
+	clock Clock
+	src   *rand.PCG
+	rng   *rand.Rand
+
 	// Transmission properties
 	CurrentGear        int       // 0 = neutral, 1-6 = gears
 	GearRatios         []float64 // Gear ratios for each gear
@@ -91,11 +117,16 @@ type Engine struct {
 	FrontalArea float64 // m² for better drag calculation
 }
 
-// NewEngine creates a new engine model with default Ninja 650 parameters
+// NewEngine creates a new engine model with default Ninja 650 parameters.
+// It runs on the wall clock with a random seed; use SetClock and SetSeed
+// for reproducible runs.
 func NewEngine() *Engine {
-	return &Engine{
+	src := rand.NewPCG(rand.Uint64(), rand.Uint64())
+
+	e := &Engine{
 		// Basic specifications
 		Displacement:     649,   // cc
+		Cylinders:        2,     // Parallel twin
 		CompressionRatio: 10.8,  // Compression ratio
 		MaxRPM:           11000, // Max RPM
 		IdleRPM:          900,   // Idle RPM
@@ -109,12 +140,12 @@ func NewEngine() *Engine {
 		ClutchPosition:   1.0,   // Fully disengaged
 		EngineTemp:       90,    // Normal operating temp (C)
 		AirTemp:          25,    // Ambient temperature (C)
-		MAP:              101.3, // kPa (atmospheric at sea level)
+		MAP:              101.3, // kPa, pulled down to idle vacuum below
+		Lambda:           1.0,   // Stoichiometric
 		O2Reading:        1.0,   // Lambda = 1.0 (stoichiometric)
 		Speed:            0,     // Not moving
 		Gear:             0,     // Neutral
 		BrakeApplied:     false, // No brakes
-		RevLimit:         0,     // No rev limit
 
 		// Environment
 		AmbientTemp: 25, // Celsius
@@ -122,6 +153,13 @@ func NewEngine() *Engine {
 
 		// Configuration
 		ExhaustType: "Yoshimura Alpha 2",
+		Intake:      DefaultNinja650Intake(),
+		FuelSystem:  DefaultNinja650FuelSystem(),
+
+		// Electrical system
+		BatteryVoltage:  DefaultRestVoltage,
+		RestVoltage:     DefaultRestVoltage,
+		ChargingVoltage: DefaultChargingVoltage,
 
 		// Physics parameters
 		Responsiveness:    500,  // RPM increase per second at 100% throttle
@@ -132,6 +170,10 @@ func NewEngine() *Engine {
 		// Initialize the last update time
 		lastUpdateTime: time.Now(),
 
+		clock: WallClock{},
+		src:   src,
+		rng:   rand.New(src),
+
 		ClutchSlip: 0.0, // start with no slip
 
 		GearRatios: []float64{
@@ -161,6 +203,13 @@ func NewEngine() *Engine {
 		// Physics
 		FrontalArea: 0.7, // m² this is more of an estimate
 	}
+
+	// Start with the manifold already pulled down to idle vacuum
+	e.MAP = e.Intake.SteadyStateMAP(e.Intake.FlowArea(e.ThrottlePosition, e.AirFilterRestriction),
+		e.AtmosphericPressure, e.AirTemp, e.RPM, e.volumetricEfficiency(), e.Displacement)
+	e.updateBatteryVoltage()
+
+	return e
 }
 
 func (e *Engine) Update(ecuOutputs ECUOutputs, deltaTime float64) {
@@ -170,22 +219,23 @@ func (e *Engine) Update(ecuOutputs ECUOutputs, deltaTime float64) {
 	// Calculate proper air density based on environment
 	airDensity := CalculateAirDensity(e.Altitude, e.AirTemp)
 
-	// Existing torque calculations...
+	// Work out how much air the cylinders take in this cycle
+	e.updateIntake(deltaTime)
+
+	// The baseline curve is full-throttle torque on a full charge of reference
+	// air; scale it by the air actually trapped
 	baselineTorque := e.calculateBaselineTorque()
-	torqueMultiplier := 1.0
+	torqueMultiplier := e.chargeRatio()
 
 	// Apply advanced environmental effects
-	torqueMultiplier = e.applyEnvironmentalEffects(torqueMultiplier, airDensity)
+	torqueMultiplier = e.applyEnvironmentalEffects(torqueMultiplier)
 
-	// Apply ECU fuel enrichment/leaning
-	if ecuOutputs.FuelInjectionTime > 0 {
-		stockInjectionTime := e.calculateStockInjectionTime()
-		torqueMultiplier *= ecuOutputs.FuelInjectionTime / stockInjectionTime
+	// Burn the fuel that made it past the port walls with the air that came in
+	e.Lambda = e.combustionLambda(ecuOutputs.FuelInjectionTime, ecuOutputs.FuelCut, deltaTime)
+	torqueMultiplier *= combustionEfficiency(e.Lambda)
 
-		if torqueMultiplier > 1.3 || torqueMultiplier < 0.8 {
-			torqueMultiplier = math.Max(0.5, math.Min(1.1, torqueMultiplier))
-		}
-	}
+	// Cylinders the rev limiter skipped make no torque
+	torqueMultiplier *= 1.0 - math.Max(ecuOutputs.SparkCut, ecuOutputs.FuelCut)
 
 	// Apply ignition timing effects
 	optimalTiming := e.calculateOptimalTiming()
@@ -200,14 +250,15 @@ func (e *Engine) Update(ecuOutputs ECUOutputs, deltaTime float64) {
 	}
 
 	// Apply octane effects on ignition timing
-	_, powerMultiplier := e.calculateOctaneEffects(ecuOutputs.IgnitionAdvance)
+	knockLimit, powerMultiplier := e.calculateOctaneEffects(ecuOutputs.IgnitionAdvance)
 	torqueMultiplier *= powerMultiplier
 
-	// Apply throttle position
-	torqueMultiplier *= e.ThrottlePosition / 100.0
+	// The knock sensor picks up whatever detonation the timing causes
+	e.KnockIntensity = e.knockSensorSignal(ecuOutputs.IgnitionAdvance, knockLimit)
 
-	// Final engine torque
-	engineTorque := baselineTorque * torqueMultiplier
+	// Final engine torque, less the work of pulling air past a closed throttle
+	engineTorque := baselineTorque*torqueMultiplier - e.pumpingLoss()
+	e.Torque = engineTorque
 
 	// Calculate clutch transfer torque
 	transferTorque := engineTorque
@@ -347,30 +398,61 @@ func (e *Engine) Update(ecuOutputs ECUOutputs, deltaTime float64) {
 				stallProbability := (1000 - e.RPM) / 1000.0
 
 				// Simple stalling model
-				if rand.Float64() < stallProbability*deltaTime*0.5 {
+				if e.rng.Float64() < stallProbability*deltaTime*0.5 {
 					e.RPM = 0 // Engine stalled
 				}
 			}
 		}
 	}
 
-	// Engine rev limiter
-	if e.RPM > e.RevLimit && e.RevLimit > 0 {
-		// Cut spark/fuel when hitting rev limiter
-		e.RPM = e.RevLimit - (rand.Float64() * 200) // Bouncing on limiter
-	}
-
 	// Ensure RPM stays in valid range and above 0
 	e.RPM = math.Max(0, math.Min(e.RPM, e.RedlineRPM*1.05))
 
+	// The alternator charges with RPM
+	e.updateBatteryVoltage()
+
+	// Time since start, restarting whenever the engine stops
+	if e.RPM > 0 {
+		e.RunTime += deltaTime
+	} else {
+		e.RunTime = 0
+	}
+
 	// Simulate engine wear over time
 	e.SimulateEngineWear(deltaTime)
 
 	// Update sensor readings
 	e.updateSensorReadings(ecuOutputs, deltaTime)
 
-	// Update last time
-	e.lastUpdateTime = time.Now()
+	// Advance the clock by the simulated step
+	e.clock.Advance(time.Duration(deltaTime * float64(time.Second)))
+	e.lastUpdateTime = e.clock.Now()
+}
+
+// SetClock replaces the clock used for timestamps
+func (e *Engine) SetClock(clock Clock) {
+	e.clock = clock
+	e.lastUpdateTime = clock.Now()
+}
+
+// Clock returns the clock used for timestamps
+func (e *Engine) Clock() Clock {
+	return e.clock
+}
+
+// SetSeed reseeds the random source used for sensor noise and stalling
+func (e *Engine) SetSeed(seed uint64) {
+	e.src = rand.NewPCG(seed, seed)
+	e.rng = rand.New(e.src)
+}
+
+// fork gives a copied engine its own clock and random source, starting from
+// the same state, so simulating on the copy leaves the original untouched
+func (e *Engine) fork() {
+	src := *e.src
+	e.src = &src
+	e.rng = rand.New(e.src)
+	e.clock = NewSimClock(e.clock.Now())
 }
 
 // SetEnvironmentalConditions allows setting multiple environmental factors
@@ -379,6 +461,9 @@ func (e *Engine) SetEnvironmentalConditions(octane, airFilterRestriction, altitu
 	e.AirFilterRestriction = math.Max(0, math.Min(1, airFilterRestriction))
 	e.Altitude = math.Max(0, math.Min(5000, altitude)) // Up to 5000m altitude
 	e.Humidity = math.Max(0, math.Min(1, humidity))
+
+	// Thinner air up high
+	e.AtmosphericPressure = CalculateBarometricPressure(e.Altitude, e.AmbientTemp)
 }
 
 // SimulateEngineWear advances engine wear over time
@@ -461,42 +546,32 @@ func (e *Engine) GetSensorData() SensorData {
 		MAP:               e.MAP,
 		O2:                e.O2Reading,
 		Speed:             e.Speed,
-		Timestamp:         time.Now().UnixNano(),
+		KnockIntensity:    e.KnockIntensity,
+		Baro:              e.AtmosphericPressure,
+		BatteryVoltage:    e.BatteryVoltage,
+		Gear:              e.Gear,
+		ClutchPosition:    e.ClutchPosition,
+		Timestamp:         e.clock.Now().UnixNano(),
 	}
 }
 
 // Calculate output metrics
 func (e *Engine) CalculatePerformance() (power, torque float64) {
-	// Get baseline torque based on RPM curve
-	torque = e.calculateBaselineTorque()
-
-	// Apply throttle position
-	torque *= (e.ThrottlePosition / 100.0)
-
-	// Apply exhaust effects using the physics model
-	torqueMultiplier, powerMultiplier := CalculateExhaustEffect(e.RPM, e.ExhaustType)
-	torque *= torqueMultiplier
+	// Torque from the last update; engine braking reads as zero
+	torque = math.Max(0, e.Torque)
 
 	// Calculate power
 	// Base power calculation: Power = Torque * RPM / 5252 (in HP)
 	power = torque * e.RPM / 5252.0
 
-	// Apply any additional power multiplier from the exhaust
-	power *= powerMultiplier
-
 	return power, torque
 }
 
 // Apply environmental effects to torque multiplier
-func (e *Engine) applyEnvironmentalEffects(torqueMultiplier, airDensity float64) float64 {
-	// Air filter restriction reduces airflow
-	airflowReduction := 1.0 - (e.AirFilterRestriction * 0.25) // Max 25% reduction
-	torqueMultiplier *= airflowReduction
-
-	// Air density effects (altitude, temperature, humidity)
-	standardAirDensity := 1.225 // kg/m³ at sea level, 15°C
-	densityFactor := airDensity / standardAirDensity
-	torqueMultiplier *= (0.7 + 0.3*densityFactor) // Partial density dependence
+// Air filter restriction, altitude and air temperature act through the air mass
+func (e *Engine) applyEnvironmentalEffects(torqueMultiplier float64) float64 {
+	// Water vapour displaces oxygen
+	torqueMultiplier *= 1.0 - (e.Humidity * 0.02)
 
 	// Fuel quality effects
 	torqueMultiplier *= (0.9 + 0.1*e.FuelQuality) // 10% swing for fuel quality
@@ -514,27 +589,29 @@ func (e *Engine) applyEnvironmentalEffects(torqueMultiplier, airDensity float64)
 
 // Calculate octane effects on knock limit and power
 func (e *Engine) calculateOctaneEffects(ignitionAdvance float64) (knockLimit, powerMultiplier float64) {
-	// Base knock threshold for different octanes
+	// Base knock threshold for different octanes. The stock ignition map sits
+	// just inside the limit on 91 octane at normal operating temperature.
 	var baseKnockThreshold float64
 	switch {
 	case e.FuelOctane >= 100:
-		baseKnockThreshold = 45.0 // Race fuel
+		baseKnockThreshold = 56.0 // Race fuel
 	case e.FuelOctane >= 93:
-		baseKnockThreshold = 35.0 // Premium
+		baseKnockThreshold = 49.0 // Premium
 	case e.FuelOctane >= 91:
-		baseKnockThreshold = 30.0 // Mid-grade
+		baseKnockThreshold = 46.0 // Mid-grade
 	case e.FuelOctane >= 87:
-		baseKnockThreshold = 25.0 // Regular
+		baseKnockThreshold = 41.0 // Regular
 	default:
-		baseKnockThreshold = 20.0 // Low quality fuel
+		baseKnockThreshold = 36.0 // Low quality fuel
 	}
 
-	// Adjust for engine temperature and load
+	// Adjust for engine speed, temperature and load
+	rpmAdjustment := 1.5 * e.RPM / 1000.0          // Less time for detonation at high RPM
 	tempAdjustment := (e.EngineTemp - 90.0) * 0.15 // Hotter = more knock prone
-	loadAdjustment := e.ThrottlePosition * 0.1     // Higher load = more knock prone
+	loadAdjustment := e.ThrottlePosition * 0.15    // Higher load = more knock prone
 	carbonAdjustment := e.CarbonBuildup * 5.0      // Carbon increases knock tendency
 
-	knockLimit = baseKnockThreshold - tempAdjustment - loadAdjustment - carbonAdjustment
+	knockLimit = baseKnockThreshold + rpmAdjustment - tempAdjustment - loadAdjustment - carbonAdjustment
 
 	// Power multiplier based on knock proximity
 	if ignitionAdvance > knockLimit {
@@ -555,6 +632,26 @@ func (e *Engine) calculateOctaneEffects(ignitionAdvance float64) (knockLimit, po
 	return knockLimit, powerMultiplier
 }
 
+// knockSensorSignal simulates the knock sensor output for one combustion
+// event: background mechanical noise, plus a burst that grows with how far
+// the timing is past the knock limit. Detonation is erratic, so the burst
+// varies from event to event.
+func (e *Engine) knockSensorSignal(ignitionAdvance, knockLimit float64) float64 {
+	if e.RPM <= 0 {
+		return 0
+	}
+
+	// Valve train and combustion noise
+	signal := 0.3 * e.rng.Float64()
+
+	// Detonation
+	if overLimit := ignitionAdvance - knockLimit; overLimit > 0 {
+		signal += overLimit * 0.4 * (0.5 + e.rng.Float64())
+	}
+
+	return signal
+}
+
 // SetThrottle sets the throttle position
 func (e *Engine) SetThrottle(position float64) {
 	e.ThrottlePosition = math.Max(0, math.Min(100, position))
@@ -570,44 +667,49 @@ func (e *Engine) GetThrottlePosition() float64 {
 	return e.ThrottlePosition
 }
 
-// calculateStockInjectionTime calculates the expected stock fuel injection time
-// the formula for injection time is based on volumetric efficiency, RPM, throttle position, and environmental factors (this is a simplified model)
+// calculateStockInjectionTime returns the injection time that would burn the
+// current air charge at a stoichiometric mixture
 func (e *Engine) calculateStockInjectionTime() float64 {
-	// Base injection time
-	baseTime := 2.0
-
-	// Calculate volumetric efficiency with environmental effects
-	baseVE := 0.85
-
-	// Air filter restriction
-	airflowFactor := 1.0 - (e.AirFilterRestriction * 0.3)
-
-	// Altitude effects (less dense air)
-	altitudeFactor := math.Exp(-e.Altitude / 8400.0)
-
-	// Temperature effects (colder air is denser)
-	tempFactor := (273.15 + 15.0) / (273.15 + e.AirTemp)
-
-	// Humidity reduces air density
-	humidityFactor := 1.0 - (e.Humidity * 0.02)
+	return e.FuelSystem.PulseWidth(e.AirMass/StoichiometricAFR, e.BatteryVoltage)
+}
 
-	// Engine wear affects breathing efficiency
-	wearFactor := 1.0 - (e.EngineWear * 0.1)
+// combustionLambda works out the mixture the firing cylinders burn from the
+// injected fuel and trapped air. fuelCut is the share of injections skipped.
+func (e *Engine) combustionLambda(injectionTime, fuelCut, deltaTime float64) float64 {
+	// The port walls see the average fuel over the skipped and fired injections
+	fuelMass := e.deliverFuel(e.injectedFuel(injectionTime)*(1.0-fuelCut), deltaTime) // mg
 
-	// Carbon buildup affects valve operation
-	carbonFactor := 1.0 - (e.CarbonBuildup * 0.05)
+	// On a cold engine part of it condenses instead of burning
+	fuelMass *= e.fuelVaporization()
+	if fuelMass <= 0 || fuelCut >= 1 {
+		return maxLambda
+	}
+	return math.Min(maxLambda, e.AirMass*(1.0-fuelCut)/(fuelMass*StoichiometricAFR))
+}
 
-	// Combined volumetric efficiency
-	totalVE := baseVE * airflowFactor * altitudeFactor * tempFactor * humidityFactor * wearFactor * carbonFactor
-	totalVE = math.Max(0.3, math.Min(1.1, totalVE)) // Reasonable bounds
+// chargeRatio compares the air trapped this cycle with a full charge of
+// reference air (101.325 kPa, 25°C) at the same RPM
+func (e *Engine) chargeRatio() float64 {
+	constants := DefaultPhysicsConstants()
+	referenceDensity := constants.StandardPressure * 1000.0 / (constants.GasConstant * (25.0 + 273.15))
+	cylinderVolume := e.Displacement / float64(e.Cylinders) / 1e6
 
-	// RPM factor
-	rpmFactor := 1.0 + 0.5*(e.RPM-e.IdleRPM)/(e.MaxRPM-e.IdleRPM)
+	referenceMass := BaseVolumetricEfficiency(e.RPM) * referenceDensity * cylinderVolume * 1e6
+	if referenceMass <= 0 {
+		return 0
+	}
+	return e.AirMass / referenceMass
+}
 
-	// Throttle factor
-	throttleFactor := 0.5 + 0.5*(e.ThrottlePosition/100.0)
+// pumpingLoss returns the torque (Nm) spent drawing air in against manifold vacuum
+func (e *Engine) pumpingLoss() float64 {
+	if e.RPM <= 0 {
+		return 0
+	}
 
-	return baseTime * totalVE * rpmFactor * throttleFactor
+	// Work per cycle is the pressure difference times the swept volume, over two revolutions
+	vacuum := math.Max(0, e.AtmosphericPressure-e.MAP) * 1000.0
+	return vacuum * e.Displacement / 1e6 / (4.0 * math.Pi)
 }
 
 // calculateOptimalTiming calculates the optimal ignition timing
@@ -636,33 +738,31 @@ func (e *Engine) getGearRatio(gear int) float64 {
 
 // updateSensorReadings updates simulated sensor readings
 func (e *Engine) updateSensorReadings(ecuOutputs ECUOutputs, deltaTime float64) {
-	// Update O2 sensor based on ECU fuel injection
-	stockInjection := e.calculateStockInjectionTime()
-	fuelRatio := ecuOutputs.FuelInjectionTime / stockInjection
-
-	// Lambda is inverse of fuel ratio (simplified)
+	// The O2 sensor reads the mixture that was burned
 	// Lambda = 1.0 is stoichiometric (ideal mixture)
 	// Lambda < 1.0 is rich, Lambda > 1.0 is lean
-	e.O2Reading = 1.0 / fuelRatio
+	e.O2Reading = e.Lambda
 
-	// Add some noise to the sensor readings for realism
-	e.O2Reading += (rand.Float64() - 0.5) * 0.05
-
-	// Update MAP based on throttle position and RPM
-	baseMAP := 101.3 // kPa (atmospheric)
-
-	// Lower MAP at higher RPM due to intake vacuum
-	rpmVacuum := 30.0 * (e.RPM / e.MaxRPM)
+	// Cylinders skipped by a fuel cut pump plain air past the sensor
+	if ecuOutputs.FuelCut >= 1 {
+		e.O2Reading = maxLambda
+	} else if ecuOutputs.FuelCut > 0 {
+		e.O2Reading = math.Min(maxLambda, e.Lambda/(1.0-ecuOutputs.FuelCut))
+	}
 
-	// Higher MAP with more throttle opening
-	throttleEffect := rpmVacuum * (e.ThrottlePosition / 100.0)
+	// Add some noise to the sensor readings for realism
+	e.O2Reading += (e.rng.Float64() - 0.5) * 0.05
 
-	e.MAP = baseMAP - rpmVacuum + throttleEffect
+	// MAP was updated by the intake model
 
 	// Update engine temperature
-	// Temperature rises with RPM and load, falls with ambient cooling
-	heatGeneration := 0.01 * (e.RPM / 1000.0) * (0.5 + 0.5*e.ThrottlePosition/100.0)
-	cooling := 0.005 * (e.EngineTemp - e.AmbientTemp)
+	// Heat follows the fuel burned, so the air flow; the engine loses a little
+	// to the air until the thermostat opens the radiator
+	heatGeneration := 0.08 * e.AirFlow
+	cooling := 0.003 * (e.EngineTemp - e.AmbientTemp)
+	if e.EngineTemp > ThermostatTemp {
+		cooling += 0.25 * (e.EngineTemp - ThermostatTemp)
+	}
 
 	e.EngineTemp += (heatGeneration - cooling) * deltaTime
 	e.EngineTemp = math.Max(e.AmbientTemp, math.Min(120.0, e.EngineTemp)) // Clamp between ambient and 120C
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"math"
+)
+
+// WallFilmParameters describes how injected fuel behaves in the intake port:
+// the fraction that lands on the port wall instead of going straight into the
+// cylinder, and the time constant (s) with which the wall film evaporates. High
+// manifold pressure and a cold engine both wet the wall more and dry it slower.
+func WallFilmParameters(manifoldPressure, engineTemp float64) (fraction, evaporationTime float64) {
+	pressure := math.Max(0, manifoldPressure) / 100.0
+	cold := math.Max(0, 90.0-engineTemp) / 70.0
+
+	fraction = math.Min(0.8, (0.15+0.25*pressure)*(1.0+0.5*cold))
+	evaporationTime = (0.05 + 0.25*pressure) * (1.0 + cold)
+	return fraction, evaporationTime
+}
+
+// UpdateWallFilm advances a port wall film (mg per cylinder) by dt seconds with
+// fuel injected every cycle, and returns the new film and the fuel that reaches
+// the cylinder per cycle
+func UpdateWallFilm(film, injected, fraction, evaporationTime, rpm, dt float64) (newFilm, delivered float64) {
+	if rpm <= 0 || dt <= 0 {
+		return film, injected
+	}
+
+	// Each cylinder fires once every two revolutions
+	cycles := rpm / 120.0
+
+	steady := SettledWallFilm(injected, fraction, evaporationTime, rpm)
+	newFilm = steady + (film-steady)*math.Exp(-dt/evaporationTime)
+
+	delivered = (1.0-fraction)*injected + newFilm/evaporationTime/cycles
+	return newFilm, delivered
+}
+
+// ThermostatTemp is the engine temperature (°C) at which the radiator starts cooling
+const ThermostatTemp = 82.0
+
+// fuelVaporization returns the share of delivered fuel that vaporizes and
+// burns. Cold ports and cylinder walls condense some of it, most of all in the
+// first seconds after starting.
+func (e *Engine) fuelVaporization() float64 {
+	cold := math.Max(0, 90.0-e.EngineTemp) / 110.0
+	return 1.0 - 0.25*cold - 0.15*cold*math.Exp(-e.RunTime/20.0)
+}
+
+// SettledWallFilm returns the film (mg) where deposits from a steady injection
+// match evaporation
+func SettledWallFilm(injected, fraction, evaporationTime, rpm float64) float64 {
+	return fraction * injected * rpm / 120.0 * evaporationTime
+}
+
+// deliverFuel passes injected fuel (mg per cylinder per cycle) through the port
+// wall film and returns what reaches the cylinder. Opening the throttle wets
+// the wall and leans the mixture until the film builds up; closing it lets the
+// film evaporate into the cylinder and richens it.
+func (e *Engine) deliverFuel(injected, deltaTime float64) float64 {
+	fraction, evaporationTime := WallFilmParameters(e.MAP, e.EngineTemp)
+
+	// The engine starts out idling, so the film has already settled
+	if e.RunTime == 0 && e.FuelFilm == 0 {
+		e.FuelFilm = SettledWallFilm(injected, fraction, evaporationTime, e.RPM)
+	}
+
+	var delivered float64
+	e.FuelFilm, delivered = UpdateWallFilm(e.FuelFilm, injected, fraction, evaporationTime, e.RPM, deltaTime)
+	return delivered
+}
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"errors"
+	"math"
+)
+
+// Battery and charging system
+const (
+	DefaultRestVoltage     = 12.4 // V, battery without charging
+	DefaultChargingVoltage = 14.2 // V, regulator output
+	chargingFullRPM        = 3000 // RPM at which the alternator reaches full output
+)
+
+// Injector describes a fuel injector as its data sheet does
+type Injector struct {
+	FlowRate      float64 `json:"flow_rate"`      // cc/min of fuel at RatedPressure
+	RatedPressure float64 `json:"rated_pressure"` // kPa the flow rate was measured at
+	DeadTime      float64 `json:"dead_time"`      // ms to open at 14 V
+}
+
+// ErrInvalidInjector is returned for injector data that cannot flow fuel
+var ErrInvalidInjector = errors.New("invalid injector data")
+
+// FuelSystem is the pump, pressure regulator and injectors feeding the engine
+type FuelSystem struct {
+	Injector Injector
+	Pressure float64 // kPa the regulator holds across the injectors
+	Density  float64 // g/cc of fuel
+}
+
+// DefaultNinja650Injector returns the stock injector
+func DefaultNinja650Injector() Injector {
+	return Injector{
+		FlowRate:      242.0,
+		RatedPressure: 300.0,
+		DeadTime:      0.35,
+	}
+}
+
+// DefaultNinja650FuelSystem returns the stock fuel system running on pump gasoline
+func DefaultNinja650FuelSystem() FuelSystem {
+	return FuelSystem{
+		Injector: DefaultNinja650Injector(),
+		Pressure: 300.0,
+		Density:  0.745,
+	}
+}
+
+// Validate checks that the injector has a flow rate, a rated pressure and no
+// negative dead time
+func (i Injector) Validate() error {
+	if i.FlowRate <= 0 || i.RatedPressure <= 0 || i.DeadTime < 0 {
+		return ErrInvalidInjector
+	}
+	return nil
+}
+
+// DeadTimeAt returns how long (ms) the injector takes to open at the given
+// supply voltage. Fuel only flows for the pulse width beyond it, and the
+// injector opens slower the lower the voltage.
+func (i Injector) DeadTimeAt(voltage float64) float64 {
+	drop := 14.0 - math.Min(voltage, 15.0)
+	return i.DeadTime * (1.0 + 0.23*drop + 0.07*math.Pow(math.Max(0, drop), 2))
+}
+
+// MassFlowRate returns the fuel (mg) delivered per ms the injector is open.
+// Flow through the nozzle rises with the square root of the pressure across it.
+func (f FuelSystem) MassFlowRate() float64 {
+	if f.Injector.RatedPressure <= 0 {
+		return 0
+	}
+	flow := f.Injector.FlowRate * math.Sqrt(math.Max(0, f.Pressure)/f.Injector.RatedPressure) // cc/min
+	return flow * f.Density * 1000.0 / 60000.0
+}
+
+// PulseWidth returns the injector opening time (ms) that delivers fuelMass
+// (mg) at the given voltage, dead time included
+func (f FuelSystem) PulseWidth(fuelMass, voltage float64) float64 {
+	if fuelMass <= 0 {
+		return 0
+	}
+	return fuelMass/f.MassFlowRate() + f.Injector.DeadTimeAt(voltage)
+}
+
+// DeliveredFuel returns the fuel (mg) an injector delivers for a pulse width
+// in ms at the given voltage
+func (f FuelSystem) DeliveredFuel(pulseWidth, voltage float64) float64 {
+	return math.Max(0, pulseWidth-f.Injector.DeadTimeAt(voltage)) * f.MassFlowRate()
+}
+
+// InjectorDuty returns the share (%) of each engine cycle the injector is open.
+// Each injector fires once every two revolutions.
+func InjectorDuty(pulseWidth, rpm float64) float64 {
+	if rpm <= 0 {
+		return 0
+	}
+	cycle := 120000.0 / rpm // ms per two revolutions
+	return math.Min(100, pulseWidth/cycle*100.0)
+}
+
+// updateBatteryVoltage raises the battery voltage towards the regulator output
+// as the alternator comes up to speed
+func (e *Engine) updateBatteryVoltage() {
+	charging := math.Max(0, e.ChargingVoltage-e.RestVoltage) * math.Min(1, e.RPM/chargingFullRPM)
+	e.BatteryVoltage = e.RestVoltage + charging
+}
+
+// injectedFuel returns the fuel (mg) the injector delivers for a pulse width in ms
+func (e *Engine) injectedFuel(pulseWidth float64) float64 {
+	return e.FuelSystem.DeliveredFuel(pulseWidth, e.BatteryVoltage)
+}
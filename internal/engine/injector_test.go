@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDeadTimeAt(t *testing.T) {
+	inj := DefaultNinja650Injector()
+
+	for _, tt := range []struct {
+		voltage, want float64
+	}{
+		{14, 0.35}, // Rated
+		{12, 0.35 * (1 + 0.23*2 + 0.07*4)},
+		{10, 0.35 * (1 + 0.23*4 + 0.07*16)},
+		{15, 0.35 * (1 - 0.23)},
+		{17, 0.35 * (1 - 0.23)}, // Held above 15 V
+	} {
+		if got := inj.DeadTimeAt(tt.voltage); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("dead time at %g V = %g ms, want %g", tt.voltage, got, tt.want)
+		}
+	}
+}
+
+func TestMassFlowRate(t *testing.T) {
+	f := DefaultNinja650FuelSystem()
+
+	// 242 cc/min of 0.745 g/cc fuel
+	want := 242 * 0.745 / 60
+	if got := f.MassFlowRate(); math.Abs(got-want) > 1e-9 {
+		t.Errorf("mass flow at the rated pressure = %g mg/ms, want %g", got, want)
+	}
+
+	// Four times the pressure doubles the flow
+	f.Pressure *= 4
+	if got := f.MassFlowRate(); math.Abs(got-2*want) > 1e-9 {
+		t.Errorf("mass flow at four times the pressure = %g mg/ms, want %g", got, 2*want)
+	}
+
+	f.Injector.RatedPressure = 0
+	if got := f.MassFlowRate(); got != 0 {
+		t.Errorf("mass flow without a rated pressure = %g, want 0", got)
+	}
+}
+
+func TestPulseWidthAcrossVoltages(t *testing.T) {
+	f := DefaultNinja650FuelSystem()
+	const fuel = 20.0 // mg
+
+	previous := math.Inf(1)
+	for _, v := range []float64{9, 11, 12.4, 14.2} {
+		pw := f.PulseWidth(fuel, v)
+		if want := fuel/f.MassFlowRate() + f.Injector.DeadTimeAt(v); math.Abs(pw-want) > 1e-9 {
+			t.Errorf("pulse width at %g V = %g ms, want %g", v, pw, want)
+		}
+		if pw >= previous {
+			t.Errorf("pulse width at %g V = %g ms, not shorter than %g ms at a lower voltage", v, pw, previous)
+		}
+		previous = pw
+
+		// The same pulse width delivers the fuel back at its own voltage only
+		if got := f.DeliveredFuel(pw, v); math.Abs(got-fuel) > 1e-9 {
+			t.Errorf("pulse width at %g V delivered %g mg, want %g", v, got, fuel)
+		}
+		if got := f.DeliveredFuel(pw, v-1); got >= fuel {
+			t.Errorf("pulse width for %g V delivered %g mg at %g V, want less than %g", v, got, v-1, fuel)
+		}
+	}
+
+	if pw := f.PulseWidth(0, 12); pw != 0 {
+		t.Errorf("pulse width for no fuel = %g ms, want 0", pw)
+	}
+	if got := f.DeliveredFuel(f.Injector.DeadTimeAt(12)/2, 12); got != 0 {
+		t.Errorf("pulse shorter than the dead time delivered %g mg", got)
+	}
+}
+
+func TestInjectorDuty(t *testing.T) {
+	for _, tt := range []struct {
+		pw, rpm, want float64
+	}{
+		{6, 6000, 30}, // 20 ms cycle
+		{12, 12000, 100},
+		{20, 12000, 100}, // Capped
+		{5, 0, 0},
+	} {
+		if got := InjectorDuty(tt.pw, tt.rpm); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%g ms at %g RPM: duty %g%%, want %g%%", tt.pw, tt.rpm, got, tt.want)
+		}
+	}
+}
+
+func TestInjectorValidate(t *testing.T) {
+	if err := DefaultNinja650Injector().Validate(); err != nil {
+		t.Errorf("stock injector: %v", err)
+	}
+
+	for name, inj := range map[string]Injector{
+		"no flow":            {FlowRate: 0, RatedPressure: 300, DeadTime: 0.35},
+		"no rated pressure":  {FlowRate: 242, RatedPressure: 0, DeadTime: 0.35},
+		"negative dead time": {FlowRate: 242, RatedPressure: 300, DeadTime: -0.1},
+	} {
+		if err := inj.Validate(); !errors.Is(err, ErrInvalidInjector) {
+			t.Errorf("%s: got %v, want %v", name, err, ErrInvalidInjector)
+		}
+	}
+}
+
+func TestBatteryVoltageCharges(t *testing.T) {
+	e := NewEngine()
+
+	for _, tt := range []struct {
+		rpm, want float64
+	}{
+		{0, DefaultRestVoltage},
+		{1500, (DefaultRestVoltage + DefaultChargingVoltage) / 2},
+		{3000, DefaultChargingVoltage},
+		{9000, DefaultChargingVoltage},
+	} {
+		e.RPM = tt.rpm
+		e.updateBatteryVoltage()
+		if math.Abs(e.BatteryVoltage-tt.want) > 1e-9 {
+			t.Errorf("battery at %g RPM = %g V, want %g", tt.rpm, e.BatteryVoltage, tt.want)
+		}
+	}
+}
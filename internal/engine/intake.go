@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"math"
+)
+
+const (
+	airGamma          = 1.4  // Ratio of specific heats for air
+	StoichiometricAFR = 14.7 // For gasoline
+	maxLambda         = 3.0  // Reported when there is next to no fuel
+)
+
+// Intake describes the air path into the engine: the air filter, the throttle
+// bodies and the manifold volume between the throttle plates and the intake
+// valves. Air is pushed through the throttle by the pressure difference
+// between the atmosphere and the manifold, and pumped out by the cylinders.
+type Intake struct {
+	ThrottleBoreArea     float64 // m², all throttle bodies together
+	ClosedAngle          float64 // Throttle plate angle at 0% TPS, degrees from fully closed
+	IdleAirFraction      float64 // Bypass area open at closed throttle, fraction of the bore area
+	DischargeCoefficient float64 // Real flow compared to an ideal orifice
+	FilterArea           float64 // m², effective flow area of a clean air filter
+	ManifoldVolume       float64 // m³
+}
+
+// DefaultNinja650Intake returns the intake of a Ninja 650: twin 36 mm throttle bodies
+func DefaultNinja650Intake() Intake {
+	boreArea := 2 * math.Pi * 0.018 * 0.018
+
+	return Intake{
+		ThrottleBoreArea:     boreArea,
+		ClosedAngle:          6.0,
+		IdleAirFraction:      0.005,
+		DischargeCoefficient: 0.8,
+		FilterArea:           3.0 * boreArea,
+		ManifoldVolume:       0.0004,
+	}
+}
+
+// FlowArea returns the effective flow area at a throttle position, with the air
+// filter in series. filterRestriction runs from 0 (clean) to 1 (blocked).
+func (in Intake) FlowArea(throttle, filterRestriction float64) float64 {
+	// The plate opens from ClosedAngle to 90°; the uncovered area grows with 1 - cos(angle)
+	closed := in.ClosedAngle * math.Pi / 180.0
+	angle := closed + (math.Pi/2-closed)*math.Max(0, math.Min(100, throttle))/100.0
+	plateFraction := 1.0 - math.Cos(angle)/math.Cos(closed)
+
+	throttleArea := in.ThrottleBoreArea * math.Min(1.0, in.IdleAirFraction+plateFraction)
+
+	// Two restrictions in series combine like 1/A² = 1/A1² + 1/A2²
+	filterArea := in.FilterArea * math.Max(0.02, 1.0-math.Max(0, math.Min(1, filterRestriction)))
+	return 1.0 / math.Sqrt(1.0/(throttleArea*throttleArea)+1.0/(filterArea*filterArea))
+}
+
+// MassFlow returns the air flow in kg/s through an opening of the given area
+// from the atmosphere into the manifold. Pressures are in kPa, airTemp in °C.
+func (in Intake) MassFlow(area, manifold, baro, airTemp float64) float64 {
+	if manifold >= baro {
+		return 0
+	}
+
+	// Compressible flow through an orifice, choked below the critical pressure ratio
+	ratio := manifold / baro
+	critical := math.Pow(2.0/(airGamma+1.0), airGamma/(airGamma-1.0))
+
+	var flowFunction float64
+	if ratio <= critical {
+		flowFunction = math.Sqrt(airGamma) * math.Pow(2.0/(airGamma+1.0), (airGamma+1.0)/(2.0*(airGamma-1.0)))
+	} else {
+		flowFunction = math.Sqrt(2.0 * airGamma / (airGamma - 1.0) *
+			(math.Pow(ratio, 2.0/airGamma) - math.Pow(ratio, (airGamma+1.0)/airGamma)))
+	}
+
+	constants := DefaultPhysicsConstants()
+	tempK := airTemp + 273.15
+	return in.DischargeCoefficient * area * baro * 1000.0 / math.Sqrt(constants.GasConstant*tempK) * flowFunction
+}
+
+// PumpedFlow returns the air flow in kg/s the cylinders draw from a manifold
+// at the given pressure (kPa). displacement is the total swept volume in cc.
+func PumpedFlow(manifold, airTemp, rpm, volumetricEfficiency, displacement float64) float64 {
+	constants := DefaultPhysicsConstants()
+	density := manifold * 1000.0 / (constants.GasConstant * (airTemp + 273.15))
+
+	// A four-stroke fills every cylinder once every two revolutions
+	return volumetricEfficiency * density * displacement / 1e6 * rpm / 120.0
+}
+
+// SteadyStateMAP returns the manifold pressure (kPa) at which the throttle lets
+// in exactly what the engine pumps out
+func (in Intake) SteadyStateMAP(area, baro, airTemp, rpm, volumetricEfficiency, displacement float64) float64 {
+	if rpm <= 0 {
+		return baro
+	}
+
+	// Inflow falls and outflow rises with manifold pressure, so bisect for the crossing
+	low, high := 0.0, baro
+	for i := 0; i < 40; i++ {
+		mid := (low + high) / 2
+		if in.MassFlow(area, mid, baro, airTemp) > PumpedFlow(mid, airTemp, rpm, volumetricEfficiency, displacement) {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	return (low + high) / 2
+}
+
+// FillTime returns the time constant (s) with which manifold pressure follows changes
+func (in Intake) FillTime(rpm, volumetricEfficiency, displacement float64) float64 {
+	// Manifold volume over the volume the cylinders draw per second
+	pumped := volumetricEfficiency * displacement / 1e6 * rpm / 120.0
+	if pumped <= 0 {
+		return 0.5
+	}
+	return math.Max(0.005, math.Min(0.5, in.ManifoldVolume/pumped))
+}
+
+// updateIntake moves manifold pressure towards the balance of throttle and
+// cylinder flow, and works out how much air each cylinder takes in
+func (e *Engine) updateIntake(deltaTime float64) {
+	ve := e.volumetricEfficiency()
+	area := e.Intake.FlowArea(e.ThrottlePosition, e.AirFilterRestriction)
+	target := e.Intake.SteadyStateMAP(area, e.AtmosphericPressure, e.AirTemp, e.RPM, ve, e.Displacement)
+
+	// The manifold volume has to fill or empty first
+	if deltaTime > 0 {
+		tau := e.Intake.FillTime(e.RPM, ve, e.Displacement)
+		e.MAP += (target - e.MAP) * (1.0 - math.Exp(-deltaTime/tau))
+	}
+
+	// Air trapped per cylinder per cycle, and total flow
+	constants := DefaultPhysicsConstants()
+	density := e.MAP * 1000.0 / (constants.GasConstant * (e.AirTemp + 273.15))
+	cylinderVolume := e.Displacement / float64(e.Cylinders) / 1e6
+
+	e.AirMass = ve * density * cylinderVolume * 1e6 // mg
+	e.AirFlow = PumpedFlow(e.MAP, e.AirTemp, e.RPM, ve, e.Displacement) * 1000.0
+}
+
+// volumetricEfficiency returns how well the cylinders fill compared to their
+// swept volume at manifold conditions. The intake is tuned for a resonance
+// around 7500 RPM, and a free-flowing exhaust scavenges a little better.
+func (e *Engine) volumetricEfficiency() float64 {
+	ve := BaseVolumetricEfficiency(e.RPM)
+
+	// Intake resonance
+	ve *= 1.0 + 0.04*math.Exp(-math.Pow((e.RPM-7500.0)/1500.0, 2))
+
+	if e.ExhaustType != "Stock" {
+		ve *= 1.03
+	}
+
+	return ve
+}
+
+// BaseVolumetricEfficiency is the smooth breathing curve of the engine with a
+// stock exhaust, peaking at the torque peak
+func BaseVolumetricEfficiency(rpm float64) float64 {
+	return 0.88 * (1.0 - 0.25*math.Pow((rpm-DefaultMaxTorqueRPM)/DefaultMaxRPM, 2))
+}
+
+// combustionEfficiency returns the torque a mixture makes compared to the best
+// power mixture (lambda 0.88). Rich mixtures lose power slowly, lean ones quickly
+// until they misfire.
+func combustionEfficiency(lambda float64) float64 {
+	const bestPower = 0.88
+
+	var efficiency float64
+	if lambda < bestPower {
+		efficiency = 1.0 - 1.2*(bestPower-lambda)
+	} else {
+		efficiency = 1.0 - 1.5*math.Pow(lambda-bestPower, 2)
+	}
+
+	return math.Max(0, math.Min(1, efficiency))
+}
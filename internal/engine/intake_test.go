@@ -0,0 +1,229 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+// cruisingEngine returns a warm engine at a steady part throttle, with the
+// manifold settled and the air charge worked out
+func cruisingEngine() *Engine {
+	e := NewEngine()
+	e.RPM = 5000
+	e.ThrottlePosition = 20
+	e.settleIntake()
+	return e
+}
+
+// settleIntake moves the manifold straight to its steady pressure and takes in the charge
+func (e *Engine) settleIntake() {
+	area := e.Intake.FlowArea(e.ThrottlePosition, e.AirFilterRestriction)
+	e.MAP = e.Intake.SteadyStateMAP(area, e.AtmosphericPressure, e.AirTemp, e.RPM, e.volumetricEfficiency(), e.Displacement)
+	e.updateIntake(0)
+}
+
+func TestFlowArea(t *testing.T) {
+	in := DefaultNinja650Intake()
+
+	previous := 0.0
+	for throttle := 0.0; throttle <= 100; throttle += 10 {
+		area := in.FlowArea(throttle, 0)
+		if area <= previous {
+			t.Errorf("flow area at %g%% = %g m², not above %g m²", throttle, area, previous)
+		}
+		previous = area
+	}
+	if closed := in.FlowArea(0, 0); closed <= 0 {
+		t.Error("no idle air at a closed throttle")
+	}
+	if wide := in.FlowArea(100, 0); wide >= in.ThrottleBoreArea {
+		t.Errorf("wide open flow area %g m² not reduced by the filter below the bore %g m²", wide, in.ThrottleBoreArea)
+	}
+	if dirty := in.FlowArea(100, 0.8); dirty >= in.FlowArea(100, 0) {
+		t.Error("a dirty filter did not restrict the flow")
+	}
+}
+
+func TestMassFlowChokes(t *testing.T) {
+	in := DefaultNinja650Intake()
+	area := in.FlowArea(20, 0)
+
+	// Below the critical pressure ratio (about 0.53) the flow no longer rises
+	if deep, deeper := in.MassFlow(area, 40, 101.325, 25), in.MassFlow(area, 20, 101.325, 25); math.Abs(deep-deeper) > 1e-12 {
+		t.Errorf("choked flow changed with manifold pressure: %g, %g kg/s", deep, deeper)
+	}
+	if choked, unchoked := in.MassFlow(area, 40, 101.325, 25), in.MassFlow(area, 80, 101.325, 25); unchoked >= choked {
+		t.Errorf("flow at 80 kPa %g kg/s, not below the choked %g kg/s", unchoked, choked)
+	}
+	if flow := in.MassFlow(area, 101.325, 101.325, 25); flow != 0 {
+		t.Errorf("flow with no pressure difference = %g", flow)
+	}
+}
+
+func TestSteadyStateMAP(t *testing.T) {
+	in := DefaultNinja650Intake()
+	const baro, airTemp, displacement = 101.325, 25.0, 649.0
+	ve := BaseVolumetricEfficiency(5000)
+
+	// Opening the throttle raises the manifold pressure towards the air outside
+	previous := 0.0
+	for throttle := 0.0; throttle <= 100; throttle += 10 {
+		area := in.FlowArea(throttle, 0)
+		pressure := in.SteadyStateMAP(area, baro, airTemp, 5000, ve, displacement)
+		if pressure <= previous || pressure >= baro {
+			t.Errorf("MAP at %g%% = %g kPa after %g kPa", throttle, pressure, previous)
+		}
+		previous = pressure
+
+		// The throttle lets in what the cylinders pump out
+		inflow, outflow := in.MassFlow(area, pressure, baro, airTemp), PumpedFlow(pressure, airTemp, 5000, ve, displacement)
+		if math.Abs(inflow-outflow) > 1e-3*outflow {
+			t.Errorf("at %g%%: %g kg/s in, %g kg/s out", throttle, inflow, outflow)
+		}
+	}
+	if previous < 0.9*baro {
+		t.Errorf("wide open MAP = %g kPa, want near the %g kPa outside", previous, baro)
+	}
+
+	// A stopped engine pumps nothing
+	if pressure := in.SteadyStateMAP(in.FlowArea(0, 0), baro, airTemp, 0, ve, displacement); pressure != baro {
+		t.Errorf("MAP with the engine stopped = %g kPa, want %g", pressure, baro)
+	}
+}
+
+func TestMAPFollowsAltitude(t *testing.T) {
+	e := cruisingEngine()
+	seaLevelMAP, seaLevelAir := e.MAP, e.AirMass
+
+	e.SetEnvironmentalConditions(e.FuelOctane, e.AirFilterRestriction, 2000, e.Humidity)
+	e.settleIntake()
+
+	// Thinner air up high: less in the manifold and less in the cylinders
+	if e.AtmosphericPressure >= 90 {
+		t.Fatalf("pressure at 2000 m = %g kPa", e.AtmosphericPressure)
+	}
+	if e.MAP >= seaLevelMAP || e.AirMass >= seaLevelAir {
+		t.Errorf("at 2000 m: MAP %g kPa air %g mg, sea level %g kPa %g mg", e.MAP, e.AirMass, seaLevelMAP, seaLevelAir)
+	}
+
+	// At part throttle both scale close to the pressure outside
+	if ratio := e.MAP / seaLevelMAP; math.Abs(ratio-e.AtmosphericPressure/101.325) > 0.02 {
+		t.Errorf("MAP ratio %g, want about %g", ratio, e.AtmosphericPressure/101.325)
+	}
+}
+
+func TestChargeRatio(t *testing.T) {
+	e := NewEngine()
+	e.ExhaustType = "Stock"
+	e.RPM = 3000
+	e.AirTemp = 25
+
+	// A full manifold of reference air fills the cylinders as well as the curve says
+	for _, pressure := range []float64{101.325, 50} {
+		e.MAP = pressure
+		e.updateIntake(0)
+
+		want := e.volumetricEfficiency() / BaseVolumetricEfficiency(e.RPM) * pressure / 101.325
+		if got := e.chargeRatio(); math.Abs(got-want) > 1e-9 {
+			t.Errorf("charge ratio at %g kPa = %g, want %g", pressure, got, want)
+		}
+	}
+
+	// Hot air is thinner
+	cool := e.chargeRatio()
+	e.AirTemp = 55
+	e.updateIntake(0)
+	if want := cool * (25 + 273.15) / (55 + 273.15); math.Abs(e.chargeRatio()-want) > 1e-9 {
+		t.Errorf("charge ratio in 55°C air = %g, want %g", e.chargeRatio(), want)
+	}
+}
+
+func TestPumpingLoss(t *testing.T) {
+	e := NewEngine()
+	e.RPM = 3000
+	e.AtmosphericPressure = 101.325
+
+	for _, tt := range []struct {
+		manifold, want float64
+	}{
+		{101.325, 0},
+		{110, 0}, // No loss above the air outside
+		{41.325, 60000 * 649e-6 / (4 * math.Pi)},
+	} {
+		e.MAP = tt.manifold
+		if got := e.pumpingLoss(); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("pumping loss at %g kPa = %g Nm, want %g", tt.manifold, got, tt.want)
+		}
+	}
+
+	e.RPM = 0
+	if got := e.pumpingLoss(); got != 0 {
+		t.Errorf("pumping loss with the engine stopped = %g Nm", got)
+	}
+}
+
+func TestCombustionLambda(t *testing.T) {
+	tests := []struct {
+		name      string
+		fuelError float64 // Share more fuel than the air needs
+		fuelCut   float64
+		want      float64
+	}{
+		{"stoichiometric", 0, 0, 1},
+		{"rich", 0.1, 0, 1 / 1.1},
+		{"lean", -0.2, 0, 1 / 0.8},
+		{"half cut", 0, 0.5, 1}, // The firing cylinder still burns its own mixture
+		{"full cut", 0, 1, maxLambda},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := cruisingEngine()
+			pw := e.FuelSystem.PulseWidth(e.AirMass/StoichiometricAFR*(1+tt.fuelError), e.BatteryVoltage)
+
+			if got := e.combustionLambda(pw, tt.fuelCut, 0); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("lambda = %g, want %g", got, tt.want)
+			}
+		})
+	}
+
+	// A cold engine condenses some of the fuel and runs lean
+	e := cruisingEngine()
+	e.EngineTemp = 20
+	if got := e.combustionLambda(e.calculateStockInjectionTime(), 0, 0); got <= 1 {
+		t.Errorf("cold engine lambda = %g, want lean of 1", got)
+	}
+
+	// No fuel reads as plain air
+	e = cruisingEngine()
+	if got := e.combustionLambda(0, 0, 0); got != maxLambda {
+		t.Errorf("lambda with no fuel = %g, want %g", got, maxLambda)
+	}
+}
+
+func TestTorqueFollowsAirAndFuel(t *testing.T) {
+	torque := func(throttle, fuelError float64) float64 {
+		e := cruisingEngine()
+		e.ThrottlePosition = throttle
+		e.settleIntake()
+		e.Gear = 0
+		pw := e.FuelSystem.PulseWidth(e.AirMass/StoichiometricAFR/0.88*(1+fuelError), e.BatteryVoltage)
+		e.Update(ECUOutputs{FuelInjectionTime: pw, IgnitionAdvance: e.calculateOptimalTiming(), LambdaTarget: 0.88}, 0)
+		return e.Torque
+	}
+
+	// More air makes more torque
+	previous := math.Inf(-1)
+	for _, throttle := range []float64{5, 20, 50, 100} {
+		got := torque(throttle, 0)
+		if got <= previous {
+			t.Errorf("torque at %g%% = %g Nm, not above %g Nm", throttle, got, previous)
+		}
+		previous = got
+	}
+
+	// A fueling error costs torque through the mixture it burns
+	if best, lean := torque(50, 0), torque(50, -0.25); lean >= best {
+		t.Errorf("lean torque %g Nm not below %g Nm at best power", lean, best)
+	}
+}
@@ -66,15 +66,23 @@ func CalculateAirDensity(altitude, temperature float64) float64 {
 	tempK := temperature + 273.15
 
 	// Barometric pressure approximation based on altitude
-	// Using the barometric formula: P = P0 * exp(-g*h/(R*T))
-	pressure := constants.StandardPressure * math.Exp(-constants.GravityAcceleration*altitude/(constants.GasConstant*tempK))
+	pressure := CalculateBarometricPressure(altitude, temperature)
 
-	// Density calculation: ρ = P/(R*T)
-	density := pressure / (constants.GasConstant * tempK)
+	// Density calculation: ρ = P/(R*T), with P in Pa
+	density := pressure * 1000.0 / (constants.GasConstant * tempK)
 
 	return density
 }
 
+// CalculateBarometricPressure returns atmospheric pressure in kPa at an altitude (m) and temperature (°C)
+func CalculateBarometricPressure(altitude, temperature float64) float64 {
+	constants := DefaultPhysicsConstants()
+	tempK := temperature + 273.15
+
+	// Using the barometric formula: P = P0 * exp(-g*h/(R*T))
+	return constants.StandardPressure * math.Exp(-constants.GravityAcceleration*altitude/(constants.GasConstant*tempK))
+}
+
 // CalculateAerodynamicDrag calculates aerodynamic drag force
 func CalculateAerodynamicDrag(speed, dragCoefficient, frontalArea, airDensity float64) float64 {
 	// Drag Force = 0.5 * ρ * v² * Cd * A
@@ -221,63 +229,6 @@ func CalculateThrottleResponse(throttlePosition float64) float64 {
 	return math.Pow(normalizedPosition, 3)
 }
 
-// CalculateExhaustEffect calculates the effect of an aftermarket exhaust
-func CalculateExhaustEffect(rpm float64, exhaustType string) (torqueMultiplier, powerMultiplier float64) {
-	// Default - no change
-	torqueMultiplier = 1.0
-	powerMultiplier = 1.0
-
-	// RPM normalized to 0-1 range (assuming max RPM of 11000)
-	normalizedRPM := math.Min(1.0, rpm/11000.0)
-
-	switch exhaustType {
-	case "Stock":
-		// No change
-		return
-
-	case "Yoshimura Alpha 2":
-		// Yoshimura typically adds power in mid-high RPM range
-		// with less improvement at very low and very high RPM
-
-		if normalizedRPM < 0.2 {
-			// Low RPM range - slight improvement
-			torqueMultiplier = 1.02
-		} else if normalizedRPM < 0.4 {
-			// Low-mid RPM range - moderate improvement
-			torqueMultiplier = 1.03 + 0.02*(normalizedRPM-0.2)/0.2
-		} else if normalizedRPM < 0.7 {
-			// Mid-high RPM range - best improvement
-			torqueMultiplier = 1.05 + 0.05*(normalizedRPM-0.4)/0.3
-		} else {
-			// High RPM range - good improvement but tapering off
-			torqueMultiplier = 1.10 - 0.03*(normalizedRPM-0.7)/0.3
-		}
-
-		// Power is affected similarly
-		powerMultiplier = torqueMultiplier
-
-	case "Akrapovic Full System":
-		// Akrapovic typically offers more significant gains
-		if normalizedRPM < 0.2 {
-			torqueMultiplier = 1.03
-		} else if normalizedRPM < 0.4 {
-			torqueMultiplier = 1.04 + 0.04*(normalizedRPM-0.2)/0.2
-		} else if normalizedRPM < 0.7 {
-			torqueMultiplier = 1.08 + 0.06*(normalizedRPM-0.4)/0.3
-		} else {
-			torqueMultiplier = 1.14 - 0.02*(normalizedRPM-0.7)/0.3
-		}
-
-		powerMultiplier = torqueMultiplier * 1.01 // Slightly more power gain
-
-	default:
-		// Unknown exhaust type
-		return
-	}
-
-	return
-}
-
 // SimulateAcceleration simulates acceleration time from 0 to a target speed
 func SimulateAcceleration(
 	engine *Engine,
@@ -286,6 +237,7 @@ func SimulateAcceleration(
 ) float64 {
 	// Create a copy of the engine to avoid modifying the original
 	simulationEngine := *engine
+	simulationEngine.fork()
 
 	// Reset engine to idle in neutral
 	simulationEngine.RPM = simulationEngine.IdleRPM
@@ -310,8 +262,9 @@ func SimulateAcceleration(
 
 	// Acceleration simulation
 	for simulationEngine.Speed < targetSpeedKmh {
-		// Full throttle
+		// Full throttle, fueled for a stoichiometric mixture
 		simulationEngine.ThrottlePosition = 100.0
+		ecuOutputs.FuelInjectionTime = simulationEngine.calculateStockInjectionTime()
 
 		// Determine optimal gear
 		if simulationEngine.Speed > 5.0 && simulationEngine.Gear == 0 {
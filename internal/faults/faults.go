@@ -0,0 +1,258 @@
+package faults
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+)
+
+// Sensors that can be faulted
+const (
+	SensorRPM = "rpm" // Crank position sensor
+	SensorTPS = "tps" // Throttle position sensor
+	SensorIAT = "iat" // Intake air temperature sensor
+	SensorECT = "ect" // Engine coolant temperature sensor
+	SensorMAP = "map" // Manifold pressure sensor
+	SensorO2  = "o2"  // Oxygen sensor
+)
+
+// Fault types
+const (
+	Stuck   = "stuck"   // Holds the reading from when the fault started
+	Offset  = "offset"  // Adds Value to the reading
+	Gain    = "gain"    // Multiplies the reading by Value
+	Noise   = "noise"   // Adds random noise of up to ±Value
+	Dropout = "dropout" // Reads as an open circuit on a share Value (0-1) of cycles
+	Open    = "open"    // Open circuit: the signal floats to the reference voltage
+	Short   = "short"   // Short to ground: the signal reads 0 V
+)
+
+// Errors returned for faults the injector cannot apply
+var (
+	ErrUnknownSensor = errors.New("unknown sensor")
+	ErrUnknownFault  = errors.New("unknown fault type")
+	ErrInvalidFault  = errors.New("invalid fault")
+)
+
+// circuit holds what a sensor reads with its signal wire open or shorted to
+// ground. The ECU pulls every signal up, so an open circuit reads the highest
+// voltage and a short the lowest, both outside the sensor's range.
+type circuit struct {
+	open, short float64
+}
+
+// circuits holds the open and short circuit readings of each sensor
+var circuits = map[string]circuit{
+	SensorRPM: {0, 0},     // No pulses from the pickup either way
+	SensorTPS: {110, -10}, // Voltage rises with throttle opening
+	SensorIAT: {-50, 160}, // Thermistor voltage falls as it warms
+	SensorECT: {-50, 160}, // Thermistor voltage falls as it warms
+	SensorMAP: {130, -10}, // Voltage rises with pressure
+	SensorO2:  {1.0, 3.5}, // Open floats at the 0.45 V bias; 0 V reads very lean
+}
+
+// Fault is one sensor fault. It starts Delay seconds after it is injected and
+// lasts Duration seconds, or until cleared when Duration is 0.
+type Fault struct {
+	Sensor   string  `json:"sensor"`             // One of the Sensor constants
+	Type     string  `json:"type"`               // One of the fault type constants
+	Value    float64 `json:"value,omitempty"`    // Offset, gain, noise amplitude or dropout share
+	Delay    float64 `json:"delay,omitempty"`    // seconds
+	Duration float64 `json:"duration,omitempty"` // seconds, 0 = until cleared
+}
+
+// Validate checks that the fault names a known sensor and type with a usable value
+func (f Fault) Validate() error {
+	if _, ok := circuits[f.Sensor]; !ok {
+		return fmt.Errorf("%w %q", ErrUnknownSensor, f.Sensor)
+	}
+
+	switch f.Type {
+	case Stuck, Offset, Gain, Open, Short:
+	case Noise:
+		if f.Value <= 0 {
+			return fmt.Errorf("%w: noise amplitude must be positive", ErrInvalidFault)
+		}
+	case Dropout:
+		if f.Value <= 0 || f.Value > 1 {
+			return fmt.Errorf("%w: dropout share must be between 0 and 1", ErrInvalidFault)
+		}
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownFault, f.Type)
+	}
+
+	if f.Delay < 0 || f.Duration < 0 {
+		return fmt.Errorf("%w: delay and duration cannot be negative", ErrInvalidFault)
+	}
+	return nil
+}
+
+// String names the fault as sensor:type, as it appears in telemetry
+func (f Fault) String() string {
+	return f.Sensor + ":" + f.Type
+}
+
+// active is an injected fault with its schedule on the injector clock
+type active struct {
+	Fault
+	start   float64 // Injector time the fault starts
+	held    float64 // Reading a stuck sensor holds
+	holding bool
+}
+
+// due reports whether the fault is in effect at time t
+func (a *active) due(t float64) bool {
+	return t >= a.start && !a.expired(t)
+}
+
+// expired reports whether the fault has run its course at time t
+func (a *active) expired(t float64) bool {
+	return a.Duration > 0 && t >= a.start+a.Duration
+}
+
+// Injector sits between the engine's sensors and the ECU and corrupts the
+// readings of the sensors with faults injected
+type Injector struct {
+	faults  []*active
+	applied []Fault // Faults in effect on the last readings
+	time    float64 // seconds of readings passed through
+
+	rng *rand.Rand
+}
+
+// NewInjector creates an injector with no faults and a random seed; use SetSeed
+// for reproducible runs
+func NewInjector() *Injector {
+	in := &Injector{}
+	in.SetSeed(rand.Uint64())
+	return in
+}
+
+// faultStream separates the injector's random stream from the engine's, which
+// is seeded with the same simulator seed
+const faultStream = 0x9e3779b97f4a7c15
+
+// SetSeed reseeds the random source used for noise and dropouts
+func (in *Injector) SetSeed(seed uint64) {
+	in.rng = rand.New(rand.NewPCG(seed, seed^faultStream))
+}
+
+// Inject schedules a fault, starting its delay from now
+func (in *Injector) Inject(f Fault) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	in.faults = append(in.faults, &active{Fault: f, start: in.time + f.Delay})
+	return nil
+}
+
+// Clear removes every fault on a sensor, or every fault when sensor is empty
+func (in *Injector) Clear(sensor string) error {
+	if sensor == "" {
+		in.faults = nil
+		return nil
+	}
+	if _, ok := circuits[sensor]; !ok {
+		return fmt.Errorf("%w %q", ErrUnknownSensor, sensor)
+	}
+
+	kept := in.faults[:0]
+	for _, a := range in.faults {
+		if a.Sensor != sensor {
+			kept = append(kept, a)
+		}
+	}
+	in.faults = kept
+	return nil
+}
+
+// Active returns the faults in effect on the last readings, in the order they
+// were injected
+func (in *Injector) Active() []Fault {
+	return in.applied
+}
+
+// Summary lists the faults in effect as comma separated sensor:type pairs
+func (in *Injector) Summary() string {
+	var names []string
+	for _, f := range in.Active() {
+		names = append(names, f.String())
+	}
+	return strings.Join(names, ",")
+}
+
+// Apply corrupts the sensor readings with the faults in effect, then moves the
+// injector clock on by dt. Faults on the same sensor stack in injection order.
+func (in *Injector) Apply(data engine.SensorData, dt float64) engine.SensorData {
+	in.applied = nil
+
+	kept := in.faults[:0]
+	for _, a := range in.faults {
+		if a.expired(in.time) {
+			continue
+		}
+		kept = append(kept, a)
+
+		if a.due(in.time) {
+			reading := sensorReading(&data, a.Sensor)
+			*reading = in.corrupt(a, *reading)
+			in.applied = append(in.applied, a.Fault)
+		}
+	}
+	in.faults = kept
+
+	in.time += dt
+	return data
+}
+
+// corrupt returns what the sensor reads with the fault applied
+func (in *Injector) corrupt(a *active, value float64) float64 {
+	c := circuits[a.Sensor]
+
+	switch a.Type {
+	case Stuck:
+		if !a.holding {
+			a.held = value
+			a.holding = true
+		}
+		return a.held
+	case Offset:
+		return value + a.Value
+	case Gain:
+		return value * a.Value
+	case Noise:
+		return value + (in.rng.Float64()*2-1)*a.Value
+	case Dropout:
+		if in.rng.Float64() < a.Value {
+			return c.open
+		}
+		return value
+	case Open:
+		return c.open
+	case Short:
+		return c.short
+	default:
+		return value
+	}
+}
+
+// sensorReading returns the field of data the sensor fills in
+func sensorReading(data *engine.SensorData, sensor string) *float64 {
+	switch sensor {
+	case SensorRPM:
+		return &data.RPM
+	case SensorTPS:
+		return &data.ThrottlePosition
+	case SensorIAT:
+		return &data.AirTemperature
+	case SensorECT:
+		return &data.EngineTemperature
+	case SensorMAP:
+		return &data.MAP
+	default:
+		return &data.O2
+	}
+}
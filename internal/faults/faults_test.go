@@ -0,0 +1,255 @@
+package faults
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+)
+
+// reading is a healthy set of sensor readings
+func reading() engine.SensorData {
+	return engine.SensorData{
+		RPM:               5000,
+		ThrottlePosition:  30,
+		AirTemperature:    25,
+		EngineTemperature: 90,
+		MAP:               60,
+		O2:                1,
+	}
+}
+
+// injector returns a seeded injector with the faults injected
+func injector(t *testing.T, faults ...Fault) *Injector {
+	t.Helper()
+
+	in := NewInjector()
+	in.SetSeed(1)
+	for _, f := range faults {
+		if err := in.Inject(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return in
+}
+
+func TestFaultTypes(t *testing.T) {
+	tests := []struct {
+		fault Fault
+		want  float64
+	}{
+		{Fault{Sensor: SensorTPS, Type: Offset, Value: 5}, 35},
+		{Fault{Sensor: SensorMAP, Type: Gain, Value: 1.5}, 90},
+		{Fault{Sensor: SensorTPS, Type: Open}, 110},
+		{Fault{Sensor: SensorTPS, Type: Short}, -10},
+		{Fault{Sensor: SensorECT, Type: Open}, -50},
+		{Fault{Sensor: SensorECT, Type: Short}, 160},
+		{Fault{Sensor: SensorIAT, Type: Open}, -50},
+		{Fault{Sensor: SensorO2, Type: Short}, 3.5},
+		{Fault{Sensor: SensorRPM, Type: Open}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fault.String(), func(t *testing.T) {
+			in := injector(t, tt.fault)
+			got := in.Apply(reading(), 0.1)
+			if v := *sensorReading(&got, tt.fault.Sensor); math.Abs(v-tt.want) > 1e-9 {
+				t.Errorf("reads %g, want %g", v, tt.want)
+			}
+			if in.Summary() != tt.fault.String() {
+				t.Errorf("summary = %q, want %q", in.Summary(), tt.fault.String())
+			}
+		})
+	}
+}
+
+func TestFaultLeavesOtherSensors(t *testing.T) {
+	in := injector(t, Fault{Sensor: SensorMAP, Type: Open})
+	got := in.Apply(reading(), 0.1)
+
+	want := reading()
+	want.MAP = 130
+	if got != want {
+		t.Errorf("readings = %+v, want %+v", got, want)
+	}
+}
+
+func TestStuckHoldsFirstReading(t *testing.T) {
+	in := injector(t, Fault{Sensor: SensorTPS, Type: Stuck, Delay: 0.2})
+	data := reading()
+
+	// Moves freely until the delay, then holds what it read then
+	for i, want := range []float64{30, 40, 50, 50, 50} {
+		data.ThrottlePosition = 30 + 10*float64(i)
+		if got := in.Apply(data, 0.1).ThrottlePosition; got != want {
+			t.Errorf("cycle %d: reads %g, want %g", i, got, want)
+		}
+	}
+}
+
+func TestNoiseAndDropout(t *testing.T) {
+	const cycles = 2000
+
+	in := injector(t, Fault{Sensor: SensorMAP, Type: Noise, Value: 2})
+	var moved int
+	for range cycles {
+		v := in.Apply(reading(), 0.01).MAP
+		if math.Abs(v-60) > 2 {
+			t.Fatalf("noisy reading %g beyond ±2 of 60", v)
+		}
+		if v != 60 {
+			moved++
+		}
+	}
+	if moved < cycles*9/10 {
+		t.Errorf("noise moved only %d of %d readings", moved, cycles)
+	}
+
+	in = injector(t, Fault{Sensor: SensorTPS, Type: Dropout, Value: 0.25})
+	var dropped int
+	for range cycles {
+		switch v := in.Apply(reading(), 0.01).ThrottlePosition; v {
+		case 110:
+			dropped++
+		case 30:
+		default:
+			t.Fatalf("dropout read %g, want 30 or the open circuit 110", v)
+		}
+	}
+	if share := float64(dropped) / cycles; math.Abs(share-0.25) > 0.05 {
+		t.Errorf("dropped %g of readings, want about 0.25", share)
+	}
+}
+
+func TestFaultSchedule(t *testing.T) {
+	in := injector(t, Fault{Sensor: SensorTPS, Type: Offset, Value: 10, Delay: 0.5, Duration: 1})
+
+	// Cycles are 0.25 s: in effect from 0.5 s until 1.5 s, then gone for good
+	for i, want := range []float64{30, 30, 40, 40, 40, 40, 30, 30} {
+		got := in.Apply(reading(), 0.25)
+		if got.ThrottlePosition != want {
+			t.Errorf("at %g s: reads %g, want %g", float64(i)*0.25, got.ThrottlePosition, want)
+		}
+		if active := len(in.Active()) == 1; active != (want == 40) {
+			t.Errorf("at %g s: active faults %v", float64(i)*0.25, in.Active())
+		}
+	}
+	if len(in.faults) != 0 {
+		t.Errorf("expired fault kept: %+v", in.faults)
+	}
+
+	// The delay runs from when the fault is injected
+	if err := in.Inject(Fault{Sensor: SensorTPS, Type: Short, Delay: 0.25}); err != nil {
+		t.Fatal(err)
+	}
+	if got := in.Apply(reading(), 0.25).ThrottlePosition; got != 30 {
+		t.Errorf("late fault in effect before its delay: reads %g", got)
+	}
+	if got := in.Apply(reading(), 0.25).ThrottlePosition; got != -10 {
+		t.Errorf("late fault after its delay: reads %g, want -10", got)
+	}
+
+	// No duration lasts until cleared
+	for range 100 {
+		in.Apply(reading(), 0.25)
+	}
+	if in.Summary() != "tps:short" {
+		t.Errorf("fault without a duration expired: %q", in.Summary())
+	}
+}
+
+func TestFaultsStack(t *testing.T) {
+	in := injector(t,
+		Fault{Sensor: SensorTPS, Type: Offset, Value: 10},
+		Fault{Sensor: SensorTPS, Type: Gain, Value: 2},
+	)
+
+	if got := in.Apply(reading(), 0.1).ThrottlePosition; got != 80 {
+		t.Errorf("offset then gain reads %g, want (30+10)*2 = 80", got)
+	}
+	if in.Summary() != "tps:offset,tps:gain" {
+		t.Errorf("summary = %q", in.Summary())
+	}
+}
+
+func TestClearFaults(t *testing.T) {
+	in := injector(t,
+		Fault{Sensor: SensorTPS, Type: Open},
+		Fault{Sensor: SensorMAP, Type: Open},
+		Fault{Sensor: SensorTPS, Type: Offset, Value: 5},
+	)
+
+	if err := in.Clear(SensorTPS); err != nil {
+		t.Fatal(err)
+	}
+	got := in.Apply(reading(), 0.1)
+	if got.ThrottlePosition != 30 || got.MAP != 130 || in.Summary() != "map:open" {
+		t.Errorf("after clearing tps: tps %g map %g summary %q", got.ThrottlePosition, got.MAP, in.Summary())
+	}
+
+	if err := in.Clear("egt"); !errors.Is(err, ErrUnknownSensor) {
+		t.Errorf("clearing an unknown sensor: got %v, want %v", err, ErrUnknownSensor)
+	}
+
+	if err := in.Clear(""); err != nil {
+		t.Fatal(err)
+	}
+	if got := in.Apply(reading(), 0.1); got != reading() || in.Summary() != "" {
+		t.Errorf("after clearing all: %+v summary %q", got, in.Summary())
+	}
+}
+
+func TestFaultValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault Fault
+		want  error
+	}{
+		{"unknown sensor", Fault{Sensor: "egt", Type: Open}, ErrUnknownSensor},
+		{"unknown type", Fault{Sensor: SensorTPS, Type: "flicker"}, ErrUnknownFault},
+		{"no noise", Fault{Sensor: SensorTPS, Type: Noise}, ErrInvalidFault},
+		{"no dropout", Fault{Sensor: SensorTPS, Type: Dropout}, ErrInvalidFault},
+		{"dropout above 1", Fault{Sensor: SensorTPS, Type: Dropout, Value: 1.5}, ErrInvalidFault},
+		{"negative delay", Fault{Sensor: SensorTPS, Type: Open, Delay: -1}, ErrInvalidFault},
+		{"negative duration", Fault{Sensor: SensorTPS, Type: Open, Duration: -1}, ErrInvalidFault},
+		{"valid", Fault{Sensor: SensorO2, Type: Dropout, Value: 1, Delay: 2, Duration: 3}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := NewInjector()
+			if err := in.Inject(tt.fault); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+			if tt.want != nil && len(in.faults) != 0 {
+				t.Error("invalid fault was scheduled")
+			}
+		})
+	}
+}
+
+func TestSeededFaultsRepeat(t *testing.T) {
+	run := func(seed uint64) []float64 {
+		in := NewInjector()
+		in.SetSeed(seed)
+		in.Inject(Fault{Sensor: SensorMAP, Type: Noise, Value: 5})
+		in.Inject(Fault{Sensor: SensorTPS, Type: Dropout, Value: 0.5})
+
+		var out []float64
+		for range 50 {
+			d := in.Apply(reading(), 0.01)
+			out = append(out, d.MAP, d.ThrottlePosition)
+		}
+		return out
+	}
+
+	a, b, c := run(7), run(7), run(8)
+	if !slices.Equal(a, b) {
+		t.Error("the same seed gave different readings")
+	}
+	if slices.Equal(a, c) {
+		t.Error("different seeds gave the same readings")
+	}
+}
@@ -0,0 +1,229 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/ecu"
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+	"github.com/StevenD2002/ninja650sim/internal/telemetry"
+)
+
+// Journal is everything needed to re-run a session exactly: how the simulator
+// started, and every change made to it stamped with the tick it was applied at
+type Journal struct {
+	Session    string  `json:"session,omitempty"`
+	Seed       uint64  `json:"seed"`
+	Start      int64   `json:"start"`       // Simulated clock at tick 0, Unix nanoseconds
+	TimestepMs float64 `json:"timestep_ms"` // Timestep at tick 0
+	Ticks      int64   `json:"ticks"`       // Ticks run when the journal was saved
+
+	// ECU learned tables at tick 0
+	Learned *ecu.Learned `json:"learned,omitempty"`
+
+	Entries []Entry `json:"entries"`
+
+	// Last rider input recorded, so repeats can be skipped
+	lastInput *sim.InputCommand
+}
+
+// Entry is one change to the simulation. Exactly one field besides Tick is set.
+type Entry struct {
+	Tick int64 `json:"tick"` // Number of steps run before the change was applied
+
+	Input       *sim.InputCommand       `json:"input,omitempty"`
+	MapEdit     *sim.MapEditCommand     `json:"map_edit,omitempty"`
+	Settings    *ecu.Settings           `json:"settings,omitempty"`
+	Brake       *bool                   `json:"brake,omitempty"`
+	Environment *sim.EnvironmentCommand `json:"environment,omitempty"`
+	Injector    *sim.InjectorCommand    `json:"injector,omitempty"`
+	ClearCodes  bool                    `json:"clear_codes,omitempty"`
+	Fault       *sim.FaultCommand       `json:"fault,omitempty"`
+	ClearFaults *sim.ClearFaultsCommand `json:"clear_faults,omitempty"`
+	MapImport   *sim.MapImportCommand   `json:"map_import,omitempty"`
+	LoadTune    *sim.LoadTuneCommand    `json:"load_tune,omitempty"`
+	TimestepMs  float64                 `json:"timestep_ms,omitempty"` // Session timestep change
+}
+
+// New starts an empty journal for a simulator created with sim.NewSeededAt(seed, start)
+// and loaded with the given learned tables
+func New(session string, seed uint64, start time.Time, timestep time.Duration, learned ecu.Learned) *Journal {
+	return &Journal{
+		Session:    session,
+		Seed:       seed,
+		Start:      start.UnixNano(),
+		TimestepMs: durationToMillis(timestep),
+		Learned:    &learned,
+	}
+}
+
+// Record appends a command that changes the simulation. Anything else, such as
+// reads, is ignored, as are rider inputs identical to the previous one.
+func (j *Journal) Record(tick int64, cmd sim.Command) {
+	e := Entry{Tick: tick}
+
+	switch c := cmd.(type) {
+	case sim.InputCommand:
+		if j.lastInput != nil && *j.lastInput == c {
+			return
+		}
+		j.lastInput = &c
+		e.Input = &c
+	case sim.MapEditCommand:
+		e.MapEdit = &c
+	case sim.SettingsCommand:
+		e.Settings = &c.Settings
+	case sim.BrakeCommand:
+		e.Brake = &c.Applied
+	case sim.EnvironmentCommand:
+		e.Environment = &c
+	case sim.InjectorCommand:
+		e.Injector = &c
+	case sim.ClearCodesCommand:
+		e.ClearCodes = true
+	case sim.FaultCommand:
+		e.Fault = &c
+	case sim.ClearFaultsCommand:
+		e.ClearFaults = &c
+	case sim.MapImportCommand:
+		e.MapImport = &c
+	case sim.LoadTuneCommand:
+		e.LoadTune = &c
+	default:
+		return
+	}
+
+	j.Entries = append(j.Entries, e)
+}
+
+// RecordTimestep notes a change to the session timestep
+func (j *Journal) RecordTimestep(tick int64, timestep time.Duration) {
+	j.Entries = append(j.Entries, Entry{Tick: tick, TimestepMs: durationToMillis(timestep)})
+}
+
+// Snapshot copies the journal so it can be saved while the session keeps running
+func (j *Journal) Snapshot(ticks int64) *Journal {
+	c := *j
+	c.Ticks = ticks
+	c.Entries = append([]Entry(nil), j.Entries...)
+	return &c
+}
+
+// command returns the simulator command stored in an entry, or nil for a timestep change
+func (e Entry) command() sim.Command {
+	switch {
+	case e.Input != nil:
+		return *e.Input
+	case e.MapEdit != nil:
+		return *e.MapEdit
+	case e.Settings != nil:
+		return sim.SettingsCommand{Settings: *e.Settings}
+	case e.Brake != nil:
+		return sim.BrakeCommand{Applied: *e.Brake}
+	case e.Environment != nil:
+		return *e.Environment
+	case e.Injector != nil:
+		return *e.Injector
+	case e.ClearCodes:
+		return sim.ClearCodesCommand{}
+	case e.Fault != nil:
+		return *e.Fault
+	case e.ClearFaults != nil:
+		return *e.ClearFaults
+	case e.MapImport != nil:
+		return *e.MapImport
+	case e.LoadTune != nil:
+		return *e.LoadTune
+	default:
+		return nil
+	}
+}
+
+// Load reads a journal file
+func Load(path string) (*Journal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var j Journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("parse journal %s: %w", path, err)
+	}
+	if j.TimestepMs <= 0 {
+		return nil, fmt.Errorf("journal %s: timestep_ms must be positive", path)
+	}
+
+	return &j, nil
+}
+
+// Save writes the journal to path, creating missing directories
+func (j *Journal) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// NewSimulator creates a simulator in the state the journaled session started in
+func (j *Journal) NewSimulator() (*sim.Simulator, error) {
+	s := sim.NewSeededAt(j.Seed, time.Unix(0, j.Start))
+
+	if j.Learned != nil {
+		if err := s.ECU.LoadLearned(*j.Learned); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Resimulate replays the journal on s, which should come from NewSimulator,
+// possibly with a different tune loaded. Every frame goes to the writers.
+func (j *Journal) Resimulate(s *sim.Simulator, writers ...telemetry.Writer) error {
+	timestep := millisToDuration(j.TimestepMs)
+
+	next := 0
+	for tick := int64(0); tick < j.Ticks; tick++ {
+		// Apply every change made before this step
+		for next < len(j.Entries) && j.Entries[next].Tick <= tick {
+			e := j.Entries[next]
+			next++
+
+			if cmd := e.command(); cmd != nil {
+				if err := cmd.Apply(s); err != nil {
+					return fmt.Errorf("tick %d: %w", e.Tick, err)
+				}
+			} else if e.TimestepMs > 0 {
+				timestep = millisToDuration(e.TimestepMs)
+			}
+		}
+
+		data := s.Step(timestep.Seconds())
+		for _, w := range writers {
+			if err := w.Write(data); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// durationToMillis converts a duration to milliseconds for the journal file
+func durationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// millisToDuration converts a journal millisecond value into a duration
+func millisToDuration(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
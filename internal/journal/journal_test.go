@@ -0,0 +1,87 @@
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/faults"
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+	"github.com/StevenD2002/ninja650sim/internal/telemetry"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// frames collects re-simulated telemetry
+type frames []*pb.EngineData
+
+func (f *frames) Write(data *pb.EngineData) error {
+	*f = append(*f, data)
+	return nil
+}
+
+func (f *frames) Close() error { return nil }
+
+func TestResimulateMatchesLiveRun(t *testing.T) {
+	const seed = 7
+	start := time.Date(2024, 6, 1, 9, 30, 0, 0, time.UTC)
+	timestep := 50 * time.Millisecond
+
+	live := sim.NewSeededAt(seed, start)
+	j := New("test", seed, start, timestep, live.ECU.Learned())
+
+	// Changes made part-way through the ride, by tick
+	changes := map[int64][]sim.Command{
+		0:   {sim.InputCommand{ThrottlePosition: 0, ClutchPosition: 1}},
+		50:  {sim.FaultCommand{Fault: faults.Fault{Sensor: faults.SensorMAP, Type: faults.Noise, Value: 3}}},
+		80:  {sim.InputCommand{ThrottlePosition: 35, Gear: 1}},
+		150: {sim.MapEditCommand{MapType: "fuel", RPM: 4000, Load: 40, Value: 6.5}},
+		200: {sim.InputCommand{ThrottlePosition: 80, Gear: 2}, sim.ClearFaultsCommand{}},
+	}
+
+	var want []*pb.EngineData
+	for tick := int64(0); tick < 300; tick++ {
+		for _, cmd := range changes[tick] {
+			if err := cmd.Apply(live); err != nil {
+				t.Fatalf("tick %d: %v", tick, err)
+			}
+			j.Record(live.Tick, cmd)
+		}
+		if tick == 120 {
+			timestep = 20 * time.Millisecond
+			j.RecordTimestep(live.Tick, timestep)
+		}
+		want = append(want, live.Step(timestep.Seconds()))
+	}
+
+	// Go through a file, as saved journals do
+	path := filepath.Join(t.TempDir(), "ride.journal.json")
+	if err := j.Snapshot(live.Tick).Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := loaded.NewSimulator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got frames
+	if err := loaded.Resimulate(s, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("re-simulated %d ticks, want %d", len(got), len(want))
+	}
+	if diffs, compared := telemetry.Diff(want, got); len(diffs) > 0 || compared != len(want) {
+		t.Errorf("re-simulation differs from the live run over %d ticks: %+v", compared, diffs)
+	}
+	for i := range want {
+		if !proto.Equal(want[i], got[i]) {
+			t.Fatalf("tick %d differs:\nlive %v\nre-simulated %v", want[i].Tick, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+	"github.com/StevenD2002/ninja650sim/internal/telemetry"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// Assertion types
+const (
+	AssertMax    = "max"     // Field never goes above Limit
+	AssertMin    = "min"     // Field never goes below Limit
+	AssertWithin = "within"  // Field stays within Tolerance of Target
+	AssertTimeTo = "time_to" // Field climbs from From to To in at most MaxTime seconds
+)
+
+// Assertion is a check run against every tick of a scenario.
+// Fields are EngineData names such as "rpm", "speed" or "afr_current".
+type Assertion struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Field string `json:"field"`
+
+	// max / min: a fixed limit, or the name of an ECU setting ("rev_limit", "idle_rpm")
+	Limit    *float64 `json:"limit,omitempty"`
+	LimitRef string   `json:"limit_ref,omitempty"`
+
+	// within: the field to compare against and the allowed difference
+	Target    string  `json:"target,omitempty"`
+	Tolerance float64 `json:"tolerance,omitempty"`
+
+	// time_to: the start and end values and the time allowed between them
+	From    float64 `json:"from,omitempty"`
+	To      float64 `json:"to,omitempty"`
+	MaxTime float64 `json:"max_time,omitempty"` // seconds
+
+	// Only check ticks where this condition holds (max, min and within)
+	When *Condition `json:"when,omitempty"`
+}
+
+// Condition restricts an assertion to part of the run
+type Condition struct {
+	Field string   `json:"field"`
+	Above *float64 `json:"above,omitempty"`
+	Below *float64 `json:"below,omitempty"`
+}
+
+// validate checks the assertion definition before a run
+func (a *Assertion) validate() error {
+	if a.Name == "" {
+		a.Name = fmt.Sprintf("%s %s", a.Field, a.Type)
+	}
+	if err := telemetry.ValidateField(a.Field); err != nil {
+		return err
+	}
+
+	switch a.Type {
+	case AssertMax, AssertMin:
+		if a.Limit == nil && a.LimitRef == "" {
+			return errors.New("limit or limit_ref is required")
+		}
+		if a.LimitRef != "" {
+			if _, ok := limitRefs[a.LimitRef]; !ok {
+				return fmt.Errorf("unknown limit_ref %q", a.LimitRef)
+			}
+		}
+	case AssertWithin:
+		if err := telemetry.ValidateField(a.Target); err != nil {
+			return fmt.Errorf("target: %w", err)
+		}
+		if a.Tolerance < 0 {
+			return errors.New("tolerance must not be negative")
+		}
+	case AssertTimeTo:
+		if a.To <= a.From {
+			return errors.New("to must be greater than from")
+		}
+		if a.MaxTime <= 0 {
+			return errors.New("max_time must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown assertion type %q", a.Type)
+	}
+
+	if a.When != nil {
+		if err := telemetry.ValidateField(a.When.Field); err != nil {
+			return fmt.Errorf("when: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// limitRefs resolves named ECU settings used as assertion limits
+var limitRefs = map[string]func(s *sim.Simulator) float64{
+	"rev_limit": func(s *sim.Simulator) float64 { return s.ECU.RevLimit },
+	"idle_rpm":  func(s *sim.Simulator) float64 { return s.ECU.IdleRPM },
+}
+
+// AssertionResult is the outcome of one assertion
+type AssertionResult struct {
+	Name   string
+	Passed bool
+
+	// Where the first failure happened
+	Tick    int64
+	Time    float64 // seconds
+	Message string
+
+	// How many ticks failed, and the worst value seen
+	Violations int
+	Worst      float64
+}
+
+// Checker evaluates assertions as telemetry arrives. It implements telemetry.Writer
+// so it can sit alongside file output in a run.
+type Checker struct {
+	assertions []Assertion
+	limits     []float64
+	results    []AssertionResult
+	timestep   time.Duration
+
+	// time_to bookkeeping: tick of the last sample at or below From, and whether To was reached
+	startTick []int64
+	reached   []bool
+}
+
+// NewChecker prepares the scenario assertions against the simulator they will run on
+func NewChecker(sc *Scenario, s *sim.Simulator) *Checker {
+	c := &Checker{
+		assertions: sc.Assertions,
+		limits:     make([]float64, len(sc.Assertions)),
+		results:    make([]AssertionResult, len(sc.Assertions)),
+		timestep:   sc.Timestep(),
+		startTick:  make([]int64, len(sc.Assertions)),
+		reached:    make([]bool, len(sc.Assertions)),
+	}
+
+	for i, a := range sc.Assertions {
+		c.results[i] = AssertionResult{Name: a.Name, Passed: true}
+		c.startTick[i] = -1
+
+		switch {
+		case a.Limit != nil:
+			c.limits[i] = *a.Limit
+		case a.LimitRef != "":
+			c.limits[i] = limitRefs[a.LimitRef](s)
+		}
+	}
+
+	return c
+}
+
+// Write checks one tick against every assertion
+func (c *Checker) Write(data *pb.EngineData) error {
+	for i := range c.assertions {
+		if err := c.check(i, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close finishes assertions that can only be judged at the end of the run
+func (c *Checker) Close() error {
+	for i, a := range c.assertions {
+		if a.Type == AssertTimeTo && !c.reached[i] {
+			r := &c.results[i]
+			r.Passed = false
+			r.Tick = -1
+			r.Message = fmt.Sprintf("%s never climbed from %g to %g", a.Field, a.From, a.To)
+		}
+	}
+	return nil
+}
+
+// Results returns the outcome of every assertion
+func (c *Checker) Results() []AssertionResult {
+	return c.results
+}
+
+// Passed reports whether every assertion held
+func (c *Checker) Passed() bool {
+	for _, r := range c.results {
+		if !r.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// check evaluates assertion i on one tick
+func (c *Checker) check(i int, data *pb.EngineData) error {
+	a := c.assertions[i]
+	r := &c.results[i]
+
+	value, err := telemetry.Value(data, a.Field)
+	if err != nil {
+		return err
+	}
+
+	if a.When != nil && a.Type != AssertTimeTo {
+		active, err := a.When.holds(data)
+		if err != nil {
+			return err
+		}
+		if !active {
+			return nil
+		}
+	}
+
+	switch a.Type {
+	case AssertMax:
+		if value > c.limits[i] {
+			c.fail(r, data, value, value > r.Worst || r.Violations == 0,
+				fmt.Sprintf("%s=%.3f above limit %.3f", a.Field, value, c.limits[i]))
+		}
+
+	case AssertMin:
+		if value < c.limits[i] {
+			c.fail(r, data, value, value < r.Worst || r.Violations == 0,
+				fmt.Sprintf("%s=%.3f below limit %.3f", a.Field, value, c.limits[i]))
+		}
+
+	case AssertWithin:
+		target, err := telemetry.Value(data, a.Target)
+		if err != nil {
+			return err
+		}
+		deviation := math.Abs(value - target)
+		if deviation > a.Tolerance {
+			c.fail(r, data, deviation, deviation > r.Worst,
+				fmt.Sprintf("%s=%.3f is %.3f from %s=%.3f (tolerance %.3f)",
+					a.Field, value, deviation, a.Target, target, a.Tolerance))
+		}
+
+	case AssertTimeTo:
+		if c.reached[i] {
+			return nil
+		}
+		if value <= a.From {
+			c.startTick[i] = data.Tick
+			return nil
+		}
+		if value >= a.To && c.startTick[i] >= 0 {
+			c.reached[i] = true
+			elapsed := float64(data.Tick-c.startTick[i]) * c.timestep.Seconds()
+			r.Worst = elapsed
+			if elapsed > a.MaxTime {
+				r.Passed = false
+				r.Tick = data.Tick
+				r.Time = c.tickTime(data.Tick)
+				r.Message = fmt.Sprintf("%s took %.2fs to climb from %g to %g (limit %.2fs)",
+					a.Field, elapsed, a.From, a.To, a.MaxTime)
+			} else {
+				r.Message = fmt.Sprintf("%s climbed from %g to %g in %.2fs", a.Field, a.From, a.To, elapsed)
+			}
+		}
+	}
+
+	return nil
+}
+
+// fail records a violation, keeping the details of the first one
+func (c *Checker) fail(r *AssertionResult, data *pb.EngineData, value float64, worse bool, message string) {
+	if r.Passed {
+		r.Passed = false
+		r.Tick = data.Tick
+		r.Time = c.tickTime(data.Tick)
+		r.Message = message
+	}
+	if worse {
+		r.Worst = value
+	}
+	r.Violations++
+}
+
+// tickTime converts a tick number into seconds from the start of the run
+func (c *Checker) tickTime(tick int64) float64 {
+	return float64(tick) * c.timestep.Seconds()
+}
+
+// holds reports whether the condition is true for this tick
+func (cond *Condition) holds(data *pb.EngineData) (bool, error) {
+	value, err := telemetry.Value(data, cond.Field)
+	if err != nil {
+		return false, err
+	}
+
+	if cond.Above != nil && value <= *cond.Above {
+		return false, nil
+	}
+	if cond.Below != nil && value >= *cond.Below {
+		return false, nil
+	}
+	return true, nil
+}
+
+// WriteReport prints a human readable summary of the assertion results
+func WriteReport(w io.Writer, results []AssertionResult) {
+	failed := 0
+	for _, r := range results {
+		if r.Passed {
+			fmt.Fprintf(w, "PASS  %s", r.Name)
+			if r.Message != "" {
+				fmt.Fprintf(w, ": %s", r.Message)
+			}
+			fmt.Fprintln(w)
+			continue
+		}
+
+		failed++
+		fmt.Fprintf(w, "FAIL  %s: %s", r.Name, r.Message)
+		if r.Tick >= 0 {
+			fmt.Fprintf(w, " at tick %d (%.2fs)", r.Tick, r.Time)
+		}
+		if r.Violations > 1 {
+			fmt.Fprintf(w, "; %d ticks failed, worst %.3f", r.Violations, r.Worst)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "%d/%d assertions passed\n", len(results)-failed, len(results))
+}
@@ -0,0 +1,186 @@
+package scenario
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/ecu"
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+	"github.com/StevenD2002/ninja650sim/internal/telemetry"
+)
+
+// Result summarizes a finished run
+type Result struct {
+	Ticks   int64
+	SimTime time.Duration
+	Elapsed time.Duration // Wall-clock time the run took
+}
+
+// NewSimulator creates the simulator the scenario runs on, with the scenario's ECU setup loaded
+func (sc *Scenario) NewSimulator() (*sim.Simulator, error) {
+	s := sim.NewSeeded(sc.Seed)
+
+	if sc.ECU != nil {
+		if err := sc.ECU.Apply(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Apply loads the ECU setup into the simulator
+func (setup *ECUSetup) Apply(s *sim.Simulator) error {
+	if setup.Tune != "" {
+		path := setup.path(setup.Tune)
+		t, err := ecu.LoadTuneFile(path)
+		if err != nil {
+			return err
+		}
+		if err := (sim.LoadTuneCommand{Tune: *t}).Apply(s); err != nil {
+			return fmt.Errorf("tune %s: %w", path, err)
+		}
+	}
+
+	if setup.Preset != "" {
+		if err := s.ECU.ApplyPreset(setup.Preset); err != nil {
+			return err
+		}
+	}
+
+	settings := s.ECU.Settings()
+	if setup.FuelTrim != nil {
+		settings.FuelTrim = *setup.FuelTrim
+	}
+	if setup.IgnitionTrim != nil {
+		settings.IgnitionTrim = *setup.IgnitionTrim
+	}
+	if setup.IdleRPM != nil {
+		settings.IdleRPM = *setup.IdleRPM
+	}
+	if setup.RevLimit != nil {
+		settings.RevLimit = *setup.RevLimit
+	}
+	if setup.TempCompensation != nil {
+		settings.TempCompensation = *setup.TempCompensation
+	}
+	if setup.LoadModel != nil {
+		settings.LoadModel = *setup.LoadModel
+	}
+	if setup.RevLimiter != nil {
+		settings.RevLimiter = *setup.RevLimiter
+	}
+	if setup.GearRevLimits != nil {
+		settings.GearRevLimits = setup.GearRevLimits
+	}
+	if setup.LaunchControl != nil {
+		settings.LaunchControl = *setup.LaunchControl
+	}
+	if setup.LaunchRPM != nil {
+		settings.LaunchRPM = *setup.LaunchRPM
+	}
+	if setup.FuelMapAxis != nil {
+		settings.FuelMapAxis = *setup.FuelMapAxis
+	}
+	if setup.IgnitionMapAxis != nil {
+		settings.IgnitionMapAxis = *setup.IgnitionMapAxis
+	}
+	if setup.AFRMapAxis != nil {
+		settings.AFRMapAxis = *setup.AFRMapAxis
+	}
+	if err := s.ECU.ApplySettings(settings); err != nil {
+		return err
+	}
+
+	if setup.ClosedLoop != nil {
+		s.ECU.ClosedLoop.Enabled = *setup.ClosedLoop
+	}
+	if setup.KnockControl != nil {
+		s.ECU.KnockControl.Enabled = *setup.KnockControl
+	}
+	if setup.AccelEnrichment != nil {
+		s.ECU.Transient.Enrichment = *setup.AccelEnrichment
+	}
+	if setup.WallWetting != nil {
+		s.ECU.Transient.WallWetting = *setup.WallWetting
+	}
+	if setup.DecelFuelCut != nil {
+		s.ECU.Transient.FuelCut = *setup.DecelFuelCut
+	}
+	if setup.Diagnostics != nil {
+		s.ECU.Diagnostics.Enabled = *setup.Diagnostics
+	}
+	if setup.Failsafe != nil {
+		s.ECU.Failsafe.Enabled = *setup.Failsafe
+	}
+
+	for _, edit := range setup.MapEdits {
+		if err := edit.Apply(s); err != nil {
+			return fmt.Errorf("map edit %s @ %.0f RPM / %.0f load: %w", edit.MapType, edit.RPM, edit.Load, err)
+		}
+	}
+
+	return nil
+}
+
+// path resolves a file reference against the directory the setup was read from
+func (setup *ECUSetup) path(name string) string {
+	if filepath.IsAbs(name) || setup.dir == "" {
+		return name
+	}
+	return filepath.Join(setup.dir, name)
+}
+
+// Run plays the scenario as fast as possible on a fresh simulator and passes
+// every frame to the given writers
+func Run(sc *Scenario, writers ...telemetry.Writer) (*Result, error) {
+	s, err := sc.NewSimulator()
+	if err != nil {
+		return nil, err
+	}
+	return RunOn(sc, s, writers...)
+}
+
+// RunOn plays the scenario on an existing simulator
+func RunOn(sc *Scenario, s *sim.Simulator, writers ...telemetry.Writer) (*Result, error) {
+	start := time.Now()
+
+	timestep := sc.Timestep()
+	ticks := sc.Ticks()
+
+	// Rider controls as they stand at the start of the run
+	controls := sim.InputCommand{
+		ThrottlePosition: s.Engine.ThrottlePosition,
+		ClutchPosition:   s.Engine.ClutchPosition,
+		Gear:             s.Engine.Gear,
+	}
+
+	next := 0
+	for tick := int64(0); tick < ticks; tick++ {
+		now := float64(tick) * timestep.Seconds()
+
+		// Apply every event that is due before this step
+		for next < len(sc.Events) && sc.Events[next].Time <= now+1e-9 {
+			for _, cmd := range sc.Events[next].commands(&controls) {
+				if err := cmd.Apply(s); err != nil {
+					return nil, err
+				}
+			}
+			next++
+		}
+
+		data := s.Step(timestep.Seconds())
+		for _, w := range writers {
+			if err := w.Write(data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &Result{
+		Ticks:   ticks,
+		SimTime: time.Duration(ticks) * timestep,
+		Elapsed: time.Since(start),
+	}, nil
+}
@@ -0,0 +1,206 @@
+package scenario
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+)
+
+// DefaultTimestepMs is used when a scenario does not set its own timestep
+const DefaultTimestepMs = 50.0
+
+// Scenario is a scripted ride: a timeline of rider inputs and environment changes
+type Scenario struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Seed        uint64  `json:"seed"`
+	TimestepMs  float64 `json:"timestep_ms,omitempty"`
+	Duration    float64 `json:"duration"` // seconds
+
+	// ECU tune to load before the run starts
+	ECU *ECUSetup `json:"ecu,omitempty"`
+
+	Events     []Event     `json:"events"`
+	Assertions []Assertion `json:"assertions,omitempty"`
+}
+
+// ECUSetup describes the ECU configuration for a scenario
+type ECUSetup struct {
+	Tune   string `json:"tune,omitempty"`   // Tune file to load first, relative to the setup's file
+	Preset string `json:"preset,omitempty"` // Name of a TuningPreset
+
+	// Individual settings, applied after the preset. Nil fields are left as they are.
+	FuelTrim         *float64  `json:"fuel_trim,omitempty"`
+	IgnitionTrim     *float64  `json:"ignition_trim,omitempty"`
+	IdleRPM          *float64  `json:"idle_rpm,omitempty"`
+	RevLimit         *float64  `json:"rev_limit,omitempty"`
+	TempCompensation *bool     `json:"temp_compensation,omitempty"`
+	LoadModel        *string   `json:"load_model,omitempty"`       // "alpha_n", "speed_density" or "blended"
+	ClosedLoop       *bool     `json:"closed_loop,omitempty"`      // Closed-loop lambda control
+	KnockControl     *bool     `json:"knock_control,omitempty"`    // Knock detection and retard
+	AccelEnrichment  *bool     `json:"accel_enrichment,omitempty"` // Extra fuel on throttle openings
+	WallWetting      *bool     `json:"wall_wetting,omitempty"`     // Port wall film compensation
+	DecelFuelCut     *bool     `json:"decel_fuel_cut,omitempty"`   // Fuel cut on closed throttle
+	Diagnostics      *bool     `json:"diagnostics,omitempty"`      // Trouble codes and the FI lamp
+	Failsafe         *bool     `json:"failsafe,omitempty"`         // Overheat protection, sensor defaults and limp mode
+	RevLimiter       *string   `json:"rev_limiter,omitempty"`      // "soft", "spark_cut", "fuel_cut" or "staged"
+	GearRevLimits    []float64 `json:"gear_rev_limits,omitempty"`  // Per gear from first, 0 uses rev_limit
+	LaunchControl    *bool     `json:"launch_control,omitempty"`
+	LaunchRPM        *float64  `json:"launch_rpm,omitempty"`
+	FuelMapAxis      *string   `json:"fuel_map_axis,omitempty"`     // "none", "gear" or "engine_temp"
+	IgnitionMapAxis  *string   `json:"ignition_map_axis,omitempty"` // "none", "gear" or "engine_temp"
+	AFRMapAxis       *string   `json:"afr_map_axis,omitempty"`      // "none", "gear" or "engine_temp"
+
+	// Map cells to change, applied last
+	MapEdits []sim.MapEditCommand `json:"map_edits,omitempty"`
+
+	// Directory of the file the setup was read from; relative file references
+	// such as Tune are resolved against it
+	dir string
+}
+
+// Event changes rider inputs or conditions at a point in time. Nil fields are left as they are.
+type Event struct {
+	Time float64 `json:"time"` // seconds from the start
+
+	ThrottlePosition *float64 `json:"throttle,omitempty"` // 0-100%
+	ClutchPosition   *float64 `json:"clutch,omitempty"`   // 0-1 (0=engaged, 1=disengaged)
+	Gear             *int     `json:"gear,omitempty"`     // 0=Neutral, 1-6=Gears
+	Brake            *bool    `json:"brake,omitempty"`
+
+	Environment *sim.EnvironmentCommand `json:"environment,omitempty"`
+	Injector    *sim.InjectorCommand    `json:"injector,omitempty"`     // Swap injectors, optionally rescaling the ECU
+	Faults      []sim.FaultCommand      `json:"faults,omitempty"`       // Sensor faults to inject
+	ClearFaults *string                 `json:"clear_faults,omitempty"` // Sensor to clear faults on, "" for all
+}
+
+// Load reads a scenario from a JSON file
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var sc Scenario
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return nil, fmt.Errorf("parse scenario %s: %w", path, err)
+	}
+
+	if err := sc.Validate(); err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", path, err)
+	}
+	if sc.ECU != nil {
+		sc.ECU.dir = filepath.Dir(path)
+	}
+
+	return &sc, nil
+}
+
+// LoadECUSetup reads a standalone ECU setup from a JSON file, in the same form
+// as a scenario's "ecu" block
+func LoadECUSetup(path string) (*ECUSetup, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var setup ECUSetup
+	if err := json.Unmarshal(data, &setup); err != nil {
+		return nil, fmt.Errorf("parse ECU setup %s: %w", path, err)
+	}
+	setup.dir = filepath.Dir(path)
+	return &setup, nil
+}
+
+// Validate checks the scenario for obvious mistakes and fills in defaults
+func (sc *Scenario) Validate() error {
+	if sc.TimestepMs == 0 {
+		sc.TimestepMs = DefaultTimestepMs
+	}
+	if sc.TimestepMs < 0 {
+		return errors.New("timestep_ms must be positive")
+	}
+	if sc.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+
+	for i, ev := range sc.Events {
+		if ev.Time < 0 || ev.Time > sc.Duration {
+			return fmt.Errorf("event %d at %.3fs is outside the scenario duration", i, ev.Time)
+		}
+		for _, f := range ev.Faults {
+			if err := f.Validate(); err != nil {
+				return fmt.Errorf("event %d: %w", i, err)
+			}
+		}
+	}
+
+	for i := range sc.Assertions {
+		if err := sc.Assertions[i].validate(); err != nil {
+			return fmt.Errorf("assertion %d: %w", i, err)
+		}
+	}
+
+	// Events are applied in time order; keep the file order for events at the same time
+	sort.SliceStable(sc.Events, func(i, j int) bool {
+		return sc.Events[i].Time < sc.Events[j].Time
+	})
+
+	return nil
+}
+
+// Timestep returns the simulated time per tick
+func (sc *Scenario) Timestep() time.Duration {
+	return time.Duration(sc.TimestepMs * float64(time.Millisecond))
+}
+
+// Ticks returns the number of steps needed to cover the scenario duration
+func (sc *Scenario) Ticks() int64 {
+	return int64(sc.Duration*1000/sc.TimestepMs + 0.5)
+}
+
+// commands turns an event into simulator commands, given the current rider controls
+func (ev Event) commands(controls *sim.InputCommand) []sim.Command {
+	var cmds []sim.Command
+
+	if ev.ThrottlePosition != nil || ev.ClutchPosition != nil || ev.Gear != nil {
+		if ev.ThrottlePosition != nil {
+			controls.ThrottlePosition = *ev.ThrottlePosition
+		}
+		if ev.ClutchPosition != nil {
+			controls.ClutchPosition = *ev.ClutchPosition
+		}
+		if ev.Gear != nil {
+			controls.Gear = *ev.Gear
+		}
+		cmds = append(cmds, *controls)
+	}
+
+	if ev.Brake != nil {
+		cmds = append(cmds, sim.BrakeCommand{Applied: *ev.Brake})
+	}
+
+	if ev.Environment != nil {
+		cmds = append(cmds, *ev.Environment)
+	}
+
+	if ev.Injector != nil {
+		cmds = append(cmds, *ev.Injector)
+	}
+
+	// Clear before injecting, so an event can swap one fault for another
+	if ev.ClearFaults != nil {
+		cmds = append(cmds, sim.ClearFaultsCommand{Sensor: *ev.ClearFaults})
+	}
+	for _, f := range ev.Faults {
+		cmds = append(cmds, f)
+	}
+
+	return cmds
+}
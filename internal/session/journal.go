@@ -0,0 +1,42 @@
+package session
+
+import (
+	"errors"
+	"log"
+
+	"github.com/StevenD2002/ninja650sim/internal/journal"
+)
+
+// ErrNoJournal is returned when saving the journal of a session that does not keep one
+var ErrNoJournal = errors.New("session has no input journal")
+
+// JournalStatus describes a saved journal
+type JournalStatus struct {
+	Path    string
+	Entries int
+	Ticks   int64
+}
+
+// SaveJournal writes every input and ECU change made since the session started
+// to path, so the ride can be re-simulated later
+func (s *Session) SaveJournal(path string) (JournalStatus, error) {
+	// Copy the journal on the loop goroutine, then write it without holding up the simulation
+	var j *journal.Journal
+	err := s.control(func() error {
+		if s.journal == nil {
+			return ErrNoJournal
+		}
+		j = s.journal.Snapshot(s.sim.Tick)
+		return nil
+	})
+	if err != nil {
+		return JournalStatus{}, err
+	}
+
+	if err := j.Save(path); err != nil {
+		return JournalStatus{}, err
+	}
+
+	log.Printf("Session %s saved %d journal entries over %d ticks to %s", s.ID, len(j.Entries), j.Ticks, path)
+	return JournalStatus{Path: path, Entries: len(j.Entries), Ticks: j.Ticks}, nil
+}
@@ -0,0 +1,44 @@
+package session
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+
+	"github.com/StevenD2002/ninja650sim/internal/ecu"
+)
+
+// loadLearned restores the ECU's learned tables from the persisted file, if there is one
+func (s *Session) loadLearned() {
+	if s.learnedPath == "" {
+		return
+	}
+
+	learned, err := ecu.LoadLearnedFile(s.learnedPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err == nil {
+		err = s.sim.ECU.LoadLearned(*learned)
+	}
+	if err != nil {
+		log.Printf("Session %s starting with fresh learned tables: %v", s.ID, err)
+		return
+	}
+
+	log.Printf("Session %s loaded learned tables from %s", s.ID, s.learnedPath)
+}
+
+// saveLearned persists the ECU's learned tables for the next session
+func (s *Session) saveLearned() {
+	if s.learnedPath == "" || s.replay != nil {
+		return
+	}
+
+	if err := ecu.SaveLearnedFile(s.learnedPath, s.sim.ECU.Learned()); err != nil {
+		log.Printf("Session %s failed to save learned tables: %v", s.ID, err)
+		return
+	}
+
+	log.Printf("Session %s saved learned tables to %s", s.ID, s.learnedPath)
+}
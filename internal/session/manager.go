@@ -0,0 +1,118 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// Manager keeps track of the active simulation sessions
+type Manager struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+
+	// Settings handed to every new session
+	config sessionConfig
+}
+
+// sessionConfig is what the manager hands every new session
+type sessionConfig struct {
+	// Recorded log to play back instead of simulating, nil for live simulation
+	replay []*pb.EngineData
+
+	// File the ECU's learned tables are loaded from at start and saved to at
+	// the end of each session, empty to start fresh every time
+	learnedPath string
+}
+
+// NewManager creates an empty session manager
+func NewManager() *Manager {
+	return &Manager{
+		sessions: make(map[string]*Session),
+	}
+}
+
+// NewReplayManager creates a session manager whose sessions play back the
+// given log instead of simulating. Each session has its own playback position.
+func NewReplayManager(frames []*pb.EngineData) *Manager {
+	m := NewManager()
+	m.config.replay = frames
+	return m
+}
+
+// PersistLearned keeps the ECU's learned tables (such as long-term fuel trims)
+// in path, so each new session picks up where the last one finished
+func (m *Manager) PersistLearned(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.config.learnedPath = path
+}
+
+// Acquire attaches a client to the session with the given ID.
+// An empty ID always starts a new session, and an unknown ID creates
+// a session under that ID so clients can agree on a name up front.
+func (m *Manager) Acquire(id string) *Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id != "" {
+		if s, ok := m.sessions[id]; ok {
+			s.clients++
+			return s
+		}
+	} else {
+		id = m.newID()
+	}
+
+	s := newSession(id, m.config)
+	s.clients = 1
+	m.sessions[id] = s
+
+	return s
+}
+
+// Release detaches a client from the session and stops the session
+// once the last client has gone
+func (m *Manager) Release(s *Session) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s.clients--
+	if s.clients <= 0 {
+		delete(m.sessions, s.ID)
+		close(s.stop)
+	}
+}
+
+// Get looks up an active session without attaching to it
+func (m *Manager) Get(id string) (*Session, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[id]
+	return s, ok
+}
+
+// Count returns the number of active sessions
+func (m *Manager) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.sessions)
+}
+
+// newID generates a random session ID that is not already in use
+func (m *Manager) newID() string {
+	for {
+		buf := make([]byte, 8)
+		rand.Read(buf)
+
+		id := hex.EncodeToString(buf)
+		if _, exists := m.sessions[id]; !exists {
+			return id
+		}
+	}
+}
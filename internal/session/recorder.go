@@ -0,0 +1,123 @@
+package session
+
+import (
+	"errors"
+	"log"
+
+	"github.com/StevenD2002/ninja650sim/internal/telemetry"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// Errors returned by the recorder
+var (
+	ErrAlreadyRecording = errors.New("session is already recording")
+	ErrNotRecording     = errors.New("session is not recording")
+)
+
+// RecordingStatus describes a session's recorder
+type RecordingStatus struct {
+	Recording bool
+	Path      string
+	Format    string
+	Frames    int64 // Ticks written so far
+}
+
+// recorder writes every tick of a session to a telemetry file
+type recorder struct {
+	out    *telemetry.FileWriter
+	path   string
+	format string
+	frames int64
+}
+
+// status reports the recorder state
+func (r *recorder) status() RecordingStatus {
+	if r == nil {
+		return RecordingStatus{}
+	}
+	return RecordingStatus{
+		Recording: true,
+		Path:      r.path,
+		Format:    r.format,
+		Frames:    r.frames,
+	}
+}
+
+// StartRecording creates a telemetry file at path and writes every following tick to it
+func (s *Session) StartRecording(path, format string) (RecordingStatus, error) {
+	var status RecordingStatus
+	err := s.control(func() error {
+		if s.recorder != nil {
+			return ErrAlreadyRecording
+		}
+
+		out, err := telemetry.CreateFile(path, format)
+		if err != nil {
+			return err
+		}
+
+		s.recorder = &recorder{out: out, path: path, format: format}
+		status = s.recorder.status()
+		log.Printf("Session %s recording to %s", s.ID, path)
+		return nil
+	})
+	return status, err
+}
+
+// StopRecording finishes the current recording and closes its file
+func (s *Session) StopRecording() (RecordingStatus, error) {
+	var status RecordingStatus
+	err := s.control(func() error {
+		if s.recorder == nil {
+			return ErrNotRecording
+		}
+
+		// Report the final frame count with Recording cleared
+		status = s.recorder.status()
+		status.Recording = false
+		return s.closeRecorder()
+	})
+	return status, err
+}
+
+// RecordingStatus returns the state of the session's recorder
+func (s *Session) RecordingStatus() (RecordingStatus, error) {
+	var status RecordingStatus
+	err := s.control(func() error {
+		status = s.recorder.status()
+		return nil
+	})
+	return status, err
+}
+
+// record writes one tick to the active recording, if any
+func (s *Session) record(data *pb.EngineData) {
+	if s.recorder == nil {
+		return
+	}
+
+	if err := s.recorder.out.Write(data); err != nil {
+		// A broken recording must not stop the simulation
+		log.Printf("Session %s recording failed: %v", s.ID, err)
+		s.closeRecorder()
+		return
+	}
+	s.recorder.frames++
+}
+
+// closeRecorder flushes and closes the active recording
+func (s *Session) closeRecorder() error {
+	if s.recorder == nil {
+		return nil
+	}
+
+	r := s.recorder
+	s.recorder = nil
+
+	if err := r.out.Close(); err != nil {
+		log.Printf("Session %s failed to close recording %s: %v", s.ID, r.path, err)
+		return err
+	}
+	log.Printf("Session %s recorded %d ticks to %s", s.ID, r.frames, r.path)
+	return nil
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// Errors returned by replay sessions
+var (
+	ErrReplaying    = errors.New("not available while replaying a log")
+	ErrNotReplaying = errors.New("session is not replaying a log")
+	ErrInvalidSeek  = errors.New("seek position is outside the log")
+)
+
+// replay plays recorded frames back in place of the simulator. The frames are
+// shared between sessions and never modified.
+type replay struct {
+	frames []*pb.EngineData
+	pos    int // Index of the next frame to publish
+}
+
+// next returns the next frame, or nil at the end of the log
+func (r *replay) next() *pb.EngineData {
+	if r.pos >= len(r.frames) {
+		return nil
+	}
+	data := r.frames[r.pos]
+	r.pos++
+	return data
+}
+
+// tick returns the tick of the last published frame
+func (r *replay) tick() int64 {
+	if r.pos == 0 {
+		return 0
+	}
+	return r.frames[r.pos-1].Tick
+}
+
+// endTick returns the tick of the last frame in the log
+func (r *replay) endTick() int64 {
+	return r.frames[len(r.frames)-1].Tick
+}
+
+// replayTimestep works out the recording's tick length from the first two
+// frames, falling back to the default when the log cannot tell us
+func replayTimestep(frames []*pb.EngineData) time.Duration {
+	if len(frames) < 2 {
+		return DefaultTimestep
+	}
+
+	ticks := frames[1].Tick - frames[0].Tick
+	elapsed := time.Duration(frames[1].Timestamp - frames[0].Timestamp)
+	if ticks <= 0 || elapsed <= 0 {
+		return DefaultTimestep
+	}
+
+	timestep := elapsed / time.Duration(ticks)
+	if timestep < MinTimestep || timestep > MaxTimestep {
+		return DefaultTimestep
+	}
+	return timestep
+}
+
+// SeekTo jumps a replay session to the first frame at or after tick and publishes it
+func (s *Session) SeekTo(tick int64) (TimeStatus, error) {
+	var status TimeStatus
+	err := s.control(func() error {
+		if s.replay == nil {
+			return ErrNotReplaying
+		}
+
+		frames := s.replay.frames
+		if tick < 0 || tick > s.replay.endTick() {
+			return ErrInvalidSeek
+		}
+
+		i := sort.Search(len(frames), func(i int) bool {
+			return frames[i].Tick >= tick
+		})
+		s.replay.pos = i
+
+		// Show the new position straight away, even while paused
+		data := s.replay.next()
+		s.clock.simTime = time.Duration(s.replay.pos) * s.clock.timestep
+		s.clock.pending = 0
+		s.latest.Store(data)
+		s.broadcast(data)
+
+		status = s.timeStatus()
+		return nil
+	})
+	return status, err
+}
@@ -0,0 +1,272 @@
+package session
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/journal"
+	"github.com/StevenD2002/ninja650sim/internal/sim"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+const (
+	// TickInterval is how often the session loop wakes up and publishes telemetry.
+	// The amount of simulated time per wake-up is set by the time controller.
+	TickInterval = 50 * time.Millisecond // 20Hz
+
+	// Buffer sizes for the command queue and each subscriber
+	commandQueueSize   = 64
+	subscriberBuffSize = 10
+)
+
+// ErrClosed is returned when a command is sent to a session that has stopped
+var ErrClosed = errors.New("session closed")
+
+// request is a command waiting on the session queue
+type request struct {
+	cmd  sim.Command
+	done chan error // nil for fire-and-forget commands
+}
+
+// readFunc lets callers inspect the simulator on the loop goroutine
+type readFunc func(s *sim.Simulator)
+
+// Apply runs the read
+func (f readFunc) Apply(s *sim.Simulator) error {
+	f(s)
+	return nil
+}
+
+// controlFunc changes session-level state on the loop goroutine
+type controlFunc func() error
+
+// Apply runs the control change
+func (f controlFunc) Apply(*sim.Simulator) error {
+	return f()
+}
+
+// Session runs one authoritative simulation shared by any number of clients.
+// The simulator is owned by the session goroutine; everything else talks to it
+// through the command queue, so no engine or ECU state is shared between goroutines.
+type Session struct {
+	ID string
+
+	sim *sim.Simulator
+
+	// Session clock: pause, single-step, timestep and time scale
+	clock timeControl
+
+	// Active telemetry recording, nil when not recording
+	recorder *recorder
+
+	// Recorded log played in place of the simulator, nil for live sessions
+	replay *replay
+
+	// Every change made to a live simulation, for exact re-simulation
+	journal *journal.Journal
+
+	// Where the ECU's learned tables persist between sessions, if anywhere
+	learnedPath string
+
+	// All inputs funnel through this queue and are applied on the loop goroutine
+	commands chan request
+
+	// Most recent telemetry, readable from any goroutine
+	latest atomic.Pointer[pb.EngineData]
+
+	// Telemetry subscribers
+	subMu       sync.Mutex
+	subscribers map[chan *pb.EngineData]struct{}
+
+	// Number of streams currently attached to this session
+	clients int
+
+	stop chan struct{}
+}
+
+// newSession creates a session and starts its simulation loop. When the config
+// holds a log the session replays it instead of running the simulator.
+func newSession(id string, config sessionConfig) *Session {
+	// Timestamps follow simulated time so they stay consistent when paused or scaled,
+	// and a known seed lets the journal reproduce the session exactly
+	seed, start := rand.Uint64(), time.Now()
+	sm := sim.NewSeededAt(seed, start)
+
+	s := &Session{
+		ID:          id,
+		sim:         sm,
+		clock:       newTimeControl(),
+		commands:    make(chan request, commandQueueSize),
+		subscribers: make(map[chan *pb.EngineData]struct{}),
+		learnedPath: config.learnedPath,
+		stop:        make(chan struct{}),
+	}
+
+	if len(config.replay) > 0 {
+		s.replay = &replay{frames: config.replay}
+		s.clock.timestep = replayTimestep(config.replay)
+	} else {
+		s.loadLearned()
+		s.journal = journal.New(id, seed, start, s.clock.timestep, sm.ECU.Learned())
+	}
+
+	go s.run()
+
+	return s
+}
+
+// run is the single simulation clock for the session
+func (s *Session) run() {
+	ticker := time.NewTicker(TickInterval)
+	defer ticker.Stop()
+
+	lastTick := time.Now()
+
+	// Finish any recording and keep what the ECU learned when the last client leaves
+	defer s.saveLearned()
+	defer s.closeRecorder()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case req := <-s.commands:
+			err := s.apply(req.cmd)
+			if req.done != nil {
+				req.done <- err
+			}
+		case now := <-ticker.C:
+			elapsed := now.Sub(lastTick)
+			lastTick = now
+
+			s.advance(s.clock.due(elapsed))
+		}
+	}
+}
+
+// apply runs a queued command and journals it. A replay has no simulator to
+// change, so only session-level controls are accepted.
+func (s *Session) apply(cmd sim.Command) error {
+	if s.replay != nil {
+		if _, ok := cmd.(controlFunc); !ok {
+			return ErrReplaying
+		}
+	}
+
+	if err := cmd.Apply(s.sim); err != nil {
+		return err
+	}
+	if s.journal != nil {
+		s.journal.Record(s.sim.Tick, cmd)
+	}
+	return nil
+}
+
+// advance runs n fixed steps, records each one, and publishes the final state
+func (s *Session) advance(n int) {
+	var data *pb.EngineData
+	for i := 0; i < n; i++ {
+		next := s.step()
+		if next == nil {
+			// End of the log: hold the last frame until the viewer seeks
+			s.clock.paused = true
+			break
+		}
+
+		data = next
+		s.clock.simTime += s.clock.timestep
+		s.record(data)
+	}
+
+	if data == nil {
+		return
+	}
+
+	s.latest.Store(data)
+	s.broadcast(data)
+}
+
+// step produces the next frame, from the simulator or from the log being replayed
+func (s *Session) step() *pb.EngineData {
+	if s.replay != nil {
+		return s.replay.next()
+	}
+	return s.sim.Step(s.clock.timestep.Seconds())
+}
+
+// Submit queues a command to be applied before the next tick without waiting for it
+func (s *Session) Submit(cmd sim.Command) {
+	select {
+	case s.commands <- request{cmd: cmd}:
+	case <-s.stop:
+	}
+}
+
+// Do queues a command and waits until the simulation has applied it
+func (s *Session) Do(cmd sim.Command) error {
+	done := make(chan error, 1)
+
+	select {
+	case s.commands <- request{cmd: cmd, done: done}:
+	case <-s.stop:
+		return ErrClosed
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-s.stop:
+		return ErrClosed
+	}
+}
+
+// Read runs fn on the simulation goroutine between ticks. fn must copy out
+// anything it needs, since the simulator keeps changing once it returns.
+func (s *Session) Read(fn func(sim *sim.Simulator)) error {
+	return s.Do(readFunc(fn))
+}
+
+// control runs a session-level change on the loop goroutine and waits for it
+func (s *Session) control(fn func() error) error {
+	return s.Do(controlFunc(fn))
+}
+
+// Latest returns the most recent telemetry, or nil before the first tick
+func (s *Session) Latest() *pb.EngineData {
+	return s.latest.Load()
+}
+
+// Subscribe registers for telemetry. The returned function must be
+// called to unsubscribe once the caller stops reading.
+func (s *Session) Subscribe() (<-chan *pb.EngineData, func()) {
+	ch := make(chan *pb.EngineData, subscriberBuffSize)
+
+	s.subMu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subMu.Unlock()
+
+	unsubscribe := func() {
+		s.subMu.Lock()
+		delete(s.subscribers, ch)
+		s.subMu.Unlock()
+	}
+
+	return ch, unsubscribe
+}
+
+// broadcast fans telemetry out to every subscriber
+func (s *Session) broadcast(data *pb.EngineData) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- data:
+		default:
+			// Subscriber is behind, skip this update
+		}
+	}
+}
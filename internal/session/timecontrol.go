@@ -0,0 +1,175 @@
+package session
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultTimestep is the simulated time covered by one tick
+	DefaultTimestep = 50 * time.Millisecond
+
+	// Limits for the adjustable clock
+	MinTimestep  = 1 * time.Millisecond
+	MaxTimestep  = 200 * time.Millisecond
+	MinTimeScale = 0.01
+	MaxTimeScale = 100.0
+
+	// maxStepsPerTick bounds the work done in one wall-clock tick so a large
+	// time scale cannot starve the command queue
+	maxStepsPerTick = 5000
+
+	// MaxStepCount is the most ticks a single step request may run. The session
+	// cannot serve its clients until they are done, so it gets the same bound.
+	MaxStepCount = maxStepsPerTick
+)
+
+// Errors returned by the time controller
+var (
+	ErrNotPaused        = errors.New("session must be paused to single-step")
+	ErrInvalidTimestep  = errors.New("timestep out of range")
+	ErrInvalidTimeScale = errors.New("time scale out of range")
+	ErrInvalidStepCount = errors.New("step count out of range")
+)
+
+// TimeStatus describes the state of a session clock
+type TimeStatus struct {
+	Paused    bool
+	Timestep  time.Duration
+	TimeScale float64
+	Tick      int64
+	SimTime   time.Duration
+
+	// Replay sessions report the last tick in the log
+	Replay  bool
+	EndTick int64
+}
+
+// timeControl decides how many fixed steps to run for each wall-clock tick
+type timeControl struct {
+	paused    bool
+	timestep  time.Duration
+	timeScale float64
+
+	// Simulated time owed but not yet stepped
+	pending time.Duration
+
+	// Total simulated time
+	simTime time.Duration
+}
+
+// newTimeControl creates a real-time clock with the default timestep
+func newTimeControl() timeControl {
+	return timeControl{
+		timestep:  DefaultTimestep,
+		timeScale: 1.0,
+	}
+}
+
+// due returns how many steps should run after wallElapsed of real time
+func (t *timeControl) due(wallElapsed time.Duration) int {
+	if t.paused {
+		return 0
+	}
+
+	t.pending += time.Duration(float64(wallElapsed) * t.timeScale)
+	steps := int(t.pending / t.timestep)
+	if steps > maxStepsPerTick {
+		// Drop the backlog rather than spiral
+		steps = maxStepsPerTick
+		t.pending = 0
+	} else {
+		t.pending -= time.Duration(steps) * t.timestep
+	}
+
+	return steps
+}
+
+// timeStatus reports the session clock state
+func (s *Session) timeStatus() TimeStatus {
+	ts := TimeStatus{
+		Paused:    s.clock.paused,
+		Timestep:  s.clock.timestep,
+		TimeScale: s.clock.timeScale,
+		Tick:      s.sim.Tick,
+		SimTime:   s.clock.simTime,
+	}
+
+	if s.replay != nil {
+		ts.Tick = s.replay.tick()
+		ts.Replay = true
+		ts.EndTick = s.replay.endTick()
+	}
+
+	return ts
+}
+
+// SetPaused freezes or resumes the session clock
+func (s *Session) SetPaused(paused bool) (TimeStatus, error) {
+	var status TimeStatus
+	err := s.control(func() error {
+		s.clock.paused = paused
+		s.clock.pending = 0
+		status = s.timeStatus()
+		return nil
+	})
+	return status, err
+}
+
+// StepTicks advances a paused session by n ticks
+func (s *Session) StepTicks(n int) (TimeStatus, error) {
+	var status TimeStatus
+	err := s.control(func() error {
+		if !s.clock.paused {
+			return ErrNotPaused
+		}
+		if n <= 0 || n > MaxStepCount {
+			return ErrInvalidStepCount
+		}
+
+		s.advance(n)
+		status = s.timeStatus()
+		return nil
+	})
+	return status, err
+}
+
+// SetTimeControl changes the timestep and time scale. Zero leaves a value unchanged.
+func (s *Session) SetTimeControl(timestep time.Duration, timeScale float64) (TimeStatus, error) {
+	var status TimeStatus
+	err := s.control(func() error {
+		if timestep != 0 && s.replay != nil {
+			// The recording fixes the timestep; only the playback speed can change
+			return ErrReplaying
+		}
+		if timestep != 0 && (timestep < MinTimestep || timestep > MaxTimestep) {
+			return ErrInvalidTimestep
+		}
+		if timeScale != 0 && (timeScale < MinTimeScale || timeScale > MaxTimeScale) {
+			return ErrInvalidTimeScale
+		}
+
+		if timestep != 0 && timestep != s.clock.timestep {
+			s.clock.timestep = timestep
+			s.journal.RecordTimestep(s.sim.Tick, timestep)
+		}
+		if timeScale != 0 {
+			s.clock.timeScale = timeScale
+		}
+		s.clock.pending = 0
+
+		status = s.timeStatus()
+		return nil
+	})
+	return status, err
+}
+
+// TimeStatus returns the current state of the session clock
+func (s *Session) TimeStatus() (TimeStatus, error) {
+	var status TimeStatus
+	err := s.control(func() error {
+		status = s.timeStatus()
+		return nil
+	})
+	return status, err
+}
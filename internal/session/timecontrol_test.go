@@ -0,0 +1,46 @@
+package session
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStepTicks(t *testing.T) {
+	m := NewManager()
+	s := m.Acquire("")
+	defer m.Release(s)
+
+	if _, err := s.StepTicks(1); !errors.Is(err, ErrNotPaused) {
+		t.Fatalf("stepping a running session: got %v, want %v", err, ErrNotPaused)
+	}
+
+	paused, err := s.SetPaused(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []int{-1, 0, MaxStepCount + 1} {
+		if _, err := s.StepTicks(n); !errors.Is(err, ErrInvalidStepCount) {
+			t.Errorf("StepTicks(%d): got %v, want %v", n, err, ErrInvalidStepCount)
+		}
+	}
+
+	status, err := s.StepTicks(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Tick != paused.Tick+3 {
+		t.Errorf("tick after stepping 3 = %d, want %d", status.Tick, paused.Tick+3)
+	}
+	if !status.Paused {
+		t.Error("session resumed after single-stepping")
+	}
+
+	status, err = s.StepTicks(MaxStepCount)
+	if err != nil {
+		t.Fatalf("StepTicks(MaxStepCount): %v", err)
+	}
+	if status.Tick != paused.Tick+3+MaxStepCount {
+		t.Errorf("tick after stepping %d = %d, want %d", MaxStepCount, status.Tick, paused.Tick+3+MaxStepCount)
+	}
+}
@@ -0,0 +1,204 @@
+package sim
+
+import (
+	"github.com/StevenD2002/ninja650sim/internal/ecu"
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+	"github.com/StevenD2002/ninja650sim/internal/faults"
+)
+
+// Command is a change to the simulation that is applied between steps
+type Command interface {
+	Apply(s *Simulator) error
+}
+
+// InputCommand carries rider controls
+type InputCommand struct {
+	ThrottlePosition float64 `json:"throttle"` // 0-100%
+	ClutchPosition   float64 `json:"clutch"`   // 0-1 (0=engaged, 1=disengaged)
+	Gear             int     `json:"gear"`     // 0=Neutral, 1-6=Gears
+}
+
+// Apply sets the rider controls on the engine
+func (c InputCommand) Apply(s *Simulator) error {
+	s.ApplyInput(c.ThrottlePosition, c.ClutchPosition, c.Gear)
+	return nil
+}
+
+// MapEditCommand changes a single ECU map cell
+type MapEditCommand struct {
+	MapType string  `json:"map"` // "fuel", "ignition", "afr", "ltft", "knock", or a 1D table or 3D map type
+	RPM     float64 `json:"rpm"`
+	Load    float64 `json:"load"`
+	Axis    float64 `json:"axis,omitempty"` // Breakpoint of a 1D table, or the third axis of a 3D map
+	Value   float64 `json:"value"`
+}
+
+// Apply writes the cell nearest to the requested RPM and load, in 3D maps in
+// the layer nearest to the axis value, or for 1D tables the entry nearest to it
+func (c MapEditCommand) Apply(s *Simulator) error {
+	if _, err := s.ECU.Table(c.MapType); err == nil {
+		return s.ECU.SetTableValue(c.MapType, c.Axis, c.Value)
+	}
+	if _, err := s.ECU.Map3D(c.MapType); err == nil {
+		return s.ECU.SetMap3DValue(c.MapType, c.Axis, c.RPM, c.Load, c.Value)
+	}
+	return s.ECU.SetMapValue(c.MapType, c.RPM, c.Load, c.Value)
+}
+
+// SettingsCommand replaces the ECU settings
+type SettingsCommand struct {
+	Settings ecu.Settings
+}
+
+// Apply hands the new settings to the ECU
+func (c SettingsCommand) Apply(s *Simulator) error {
+	return s.ECU.ApplySettings(c.Settings)
+}
+
+// BrakeCommand applies or releases the brakes
+type BrakeCommand struct {
+	Applied bool
+}
+
+// Apply sets the brake state on the engine model
+func (c BrakeCommand) Apply(s *Simulator) error {
+	s.Engine.BrakeApplied = c.Applied
+	return nil
+}
+
+// EnvironmentCommand changes riding conditions. Nil fields are left as they are.
+type EnvironmentCommand struct {
+	FuelOctane           *float64 `json:"fuel_octane,omitempty"`
+	AirFilterRestriction *float64 `json:"air_filter_restriction,omitempty"` // 0-1
+	Altitude             *float64 `json:"altitude,omitempty"`               // meters
+	Humidity             *float64 `json:"humidity,omitempty"`               // 0-1
+	AmbientTemp          *float64 `json:"ambient_temp,omitempty"`           // Celsius
+	EngineTemp           *float64 `json:"engine_temp,omitempty"`            // Celsius, e.g. ambient for a cold start
+	BatteryVoltage       *float64 `json:"battery_voltage,omitempty"`        // V at rest, e.g. 11.5 for a weak battery
+	ChargingVoltage      *float64 `json:"charging_voltage,omitempty"`       // V the regulator holds
+	FuelPressure         *float64 `json:"fuel_pressure,omitempty"`          // kPa, e.g. lower for a weak pump
+}
+
+// Apply merges the given conditions into the engine model
+func (c EnvironmentCommand) Apply(s *Simulator) error {
+	e := s.Engine
+
+	octane, filter, altitude, humidity := e.FuelOctane, e.AirFilterRestriction, e.Altitude, e.Humidity
+	if c.FuelOctane != nil {
+		octane = *c.FuelOctane
+	}
+	if c.AirFilterRestriction != nil {
+		filter = *c.AirFilterRestriction
+	}
+	if c.Altitude != nil {
+		altitude = *c.Altitude
+	}
+	if c.Humidity != nil {
+		humidity = *c.Humidity
+	}
+	e.SetEnvironmentalConditions(octane, filter, altitude, humidity)
+
+	// Intake air follows the ambient temperature
+	if c.AmbientTemp != nil {
+		e.AmbientTemp = *c.AmbientTemp
+		e.AirTemp = *c.AmbientTemp
+	}
+	if c.EngineTemp != nil {
+		e.EngineTemp = *c.EngineTemp
+	}
+	if c.BatteryVoltage != nil {
+		e.RestVoltage = *c.BatteryVoltage
+	}
+	if c.ChargingVoltage != nil {
+		e.ChargingVoltage = *c.ChargingVoltage
+	}
+	if c.FuelPressure != nil {
+		e.FuelSystem.Pressure = *c.FuelPressure
+	}
+
+	return nil
+}
+
+// ClearCodesCommand erases the ECU's stored trouble codes
+type ClearCodesCommand struct{}
+
+// Apply clears the codes and turns the FI lamp off
+func (c ClearCodesCommand) Apply(s *Simulator) error {
+	s.ECU.Diagnostics.Clear()
+	return nil
+}
+
+// InjectorCommand fits different injectors to the engine. Zero fields keep the
+// current injector's data. With Rescale the ECU's injector data is switched
+// too, otherwise the tune still assumes the old injectors.
+type InjectorCommand struct {
+	engine.Injector
+	Rescale bool `json:"rescale,omitempty"`
+}
+
+// Apply swaps the injectors, and with Rescale rescales the ECU for them
+func (c InjectorCommand) Apply(s *Simulator) error {
+	inj := s.Engine.FuelSystem.Injector
+	if c.FlowRate != 0 {
+		inj.FlowRate = c.FlowRate
+	}
+	if c.RatedPressure != 0 {
+		inj.RatedPressure = c.RatedPressure
+	}
+	if c.DeadTime != 0 {
+		inj.DeadTime = c.DeadTime
+	}
+	if err := inj.Validate(); err != nil {
+		return err
+	}
+
+	if c.Rescale {
+		if err := s.ECU.RescaleForInjector(inj); err != nil {
+			return err
+		}
+	}
+	s.Engine.FuelSystem.Injector = inj
+	return nil
+}
+
+// FaultCommand injects a sensor fault
+type FaultCommand struct {
+	faults.Fault
+}
+
+// Apply schedules the fault on the simulator's fault injector
+func (c FaultCommand) Apply(s *Simulator) error {
+	return s.Faults.Inject(c.Fault)
+}
+
+// ClearFaultsCommand removes the faults on a sensor, or all faults when Sensor is empty
+type ClearFaultsCommand struct {
+	Sensor string `json:"sensor,omitempty"`
+}
+
+// Apply clears the faults, so the ECU sees true readings again
+func (c ClearFaultsCommand) Apply(s *Simulator) error {
+	return s.Faults.Clear(c.Sensor)
+}
+
+// MapImportCommand replaces an ECU map or table with one read from a CSV or JSON file
+type MapImportCommand struct {
+	MapType string `json:"map"`    // Any map, table or 3D map type
+	Format  string `json:"format"` // "csv" or "json"
+	Data    string `json:"data"`   // File contents
+}
+
+// Apply decodes and validates the map, then swaps it into the ECU
+func (c MapImportCommand) Apply(s *Simulator) error {
+	return s.ECU.ImportMap(c.MapType, c.Format, []byte(c.Data))
+}
+
+// LoadTuneCommand replaces the ECU's calibration with a tune
+type LoadTuneCommand struct {
+	Tune ecu.Tune `json:"tune"`
+}
+
+// Apply loads the tune, refusing it unless it was built for the bike's exhaust and injectors
+func (c LoadTuneCommand) Apply(s *Simulator) error {
+	return s.ECU.LoadTune(c.Tune, s.Hardware())
+}
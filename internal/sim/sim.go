@@ -0,0 +1,136 @@
+package sim
+
+import (
+	"time"
+
+	"github.com/StevenD2002/ninja650sim/internal/ecu"
+	"github.com/StevenD2002/ninja650sim/internal/engine"
+	"github.com/StevenD2002/ninja650sim/internal/faults"
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// Simulator advances an engine and its ECU together, one fixed step at a time
+type Simulator struct {
+	Engine *engine.Engine
+	ECU    *ecu.ECU
+
+	// Sensor faults applied between the engine and the ECU
+	Faults *faults.Injector
+
+	// Number of steps taken so far
+	Tick int64
+}
+
+// New creates a simulator with a stock Ninja 650 engine and ECU
+func New() *Simulator {
+	return &Simulator{
+		Engine: engine.NewEngine(),
+		ECU:    ecu.NewECU(),
+		Faults: faults.NewInjector(),
+	}
+}
+
+// NewSeeded creates a reproducible simulator: it runs on a simulated clock
+// starting at the Unix epoch and draws all noise from the given seed, so the
+// same seed and inputs always produce identical telemetry
+func NewSeeded(seed uint64) *Simulator {
+	return NewSeededAt(seed, time.Unix(0, 0).UTC())
+}
+
+// NewSeededAt creates a reproducible simulator whose simulated clock starts at start
+func NewSeededAt(seed uint64, start time.Time) *Simulator {
+	s := New()
+	s.Engine.SetClock(engine.NewSimClock(start))
+	s.Engine.SetSeed(seed)
+	s.Faults.SetSeed(seed)
+	return s
+}
+
+// ApplyInput applies rider controls to the engine
+func (s *Simulator) ApplyInput(throttle, clutch float64, gear int) {
+	s.Engine.SetThrottle(throttle)
+	s.Engine.ClutchPosition = clutch
+	s.Engine.Gear = gear
+}
+
+// Hardware returns the exhaust and injectors fitted to the bike, which a tune
+// has to be built for
+func (s *Simulator) Hardware() ecu.Hardware {
+	return ecu.Hardware{
+		ExhaustType: s.Engine.ExhaustType,
+		Injector:    s.Engine.FuelSystem.Injector,
+	}
+}
+
+// Step runs one sensor -> ECU -> engine cycle and returns the resulting telemetry
+func (s *Simulator) Step(deltaTime float64) *pb.EngineData {
+	// Get sensor data from engine
+	sensorData := s.Engine.GetSensorData()
+
+	// Corrupt the readings of faulty sensors before the ECU sees them
+	ecuOutputs := s.ECU.ProcessSensorData(s.Faults.Apply(sensorData, deltaTime))
+
+	// Update engine based on ECU outputs
+	s.Engine.Update(ecuOutputs, deltaTime)
+	s.Tick++
+
+	// Calculate performance metrics
+	power, torque := s.Engine.CalculatePerformance()
+
+	return &pb.EngineData{
+		Rpm:                   s.Engine.GetRPM(),
+		ThrottlePosition:      s.Engine.GetThrottlePosition(),
+		Timestamp:             sensorData.Timestamp,
+		Power:                 power,
+		Torque:                torque,
+		Speed:                 sensorData.Speed,
+		EngineTemp:            sensorData.EngineTemperature,
+		AfrCurrent:            sensorData.O2 * ecu.StoichiometricAFR, // Convert lambda to AFR
+		AfrTarget:             ecuOutputs.LambdaTarget * ecu.StoichiometricAFR,
+		FuelInjectionMs:       ecuOutputs.FuelInjectionTime,
+		IgnitionAdvance:       ecuOutputs.IgnitionAdvance,
+		Gear:                  int32(s.Engine.Gear),
+		ClutchPosition:        s.Engine.ClutchPosition,
+		Tick:                  s.Tick,
+		KnockCount:            int32(s.ECU.KnockCount),
+		AfrDeviation:          s.ECU.AFRDeviation,
+		ClosedLoop:            s.ECU.ClosedLoop.Active,
+		ShortTermFuelTrim:     s.ECU.ClosedLoop.STFT,
+		LongTermFuelTrim:      s.ECU.ClosedLoop.LTFTCell,
+		KnockIntensity:        s.Engine.KnockIntensity,
+		Knocking:              s.ECU.KnockControl.Knocking,
+		KnockRetard:           s.ECU.KnockControl.Retard,
+		LearnedKnockRetard:    s.ECU.KnockControl.LearnedCell,
+		EngineLoad:            s.ECU.Load,
+		ManifoldPressure:      s.Engine.MAP,
+		AirMass:               s.Engine.AirMass,
+		AirFlow:               s.Engine.AirFlow,
+		FuelCut:               s.ECU.Transient.Cut,
+		AccelEnrichment:       s.ECU.Transient.AccelEnrichment,
+		FuelFilm:              s.Engine.FuelFilm,
+		RevLimiterActive:      s.ECU.RevLimiter.Active,
+		RevLimit:              s.ECU.RevLimiter.Limit,
+		LaunchControl:         s.ECU.RevLimiter.Launch,
+		LimiterRetard:         s.ECU.RevLimiter.Retard,
+		LimiterSparkCut:       s.ECU.RevLimiter.SparkCut * 100.0,
+		LimiterFuelCut:        s.ECU.RevLimiter.FuelCut * 100.0,
+		WarmupEnrichment:      s.ECU.WarmUp.WarmupEnrichment,
+		AfterStartEnrichment:  s.ECU.WarmUp.AfterStartEnrichment,
+		ColdIgnitionRetard:    s.ECU.WarmUp.Retard,
+		IdleTarget:            ecuOutputs.TargetIdleRPM,
+		BatteryVoltage:        sensorData.BatteryVoltage,
+		Baro:                  sensorData.Baro,
+		IatFuelCorrection:     s.ECU.Compensation.IATFuelCorrection,
+		BaroFuelCorrection:    s.ECU.Compensation.BaroFuelCorrection,
+		IatIgnitionCorrection: s.ECU.Compensation.IATIgnitionCorrection,
+		InjectorDeadTime:      s.ECU.Compensation.InjectorDeadTime,
+		FuelMass:              s.ECU.FuelMass,
+		InjectorDuty:          engine.InjectorDuty(ecuOutputs.FuelInjectionTime, s.Engine.GetRPM()),
+		FiLamp:                s.ECU.Diagnostics.Lamp,
+		DtcCount:              int32(s.ECU.Diagnostics.Count()),
+		SensorFaults:          s.Faults.Summary(),
+		SensorFaultCount:      int32(len(s.Faults.Active())),
+		Failsafe:              s.ECU.Failsafe.Active(),
+		LimpMode:              s.ECU.Failsafe.Limp,
+	}
+}
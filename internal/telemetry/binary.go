@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+// BinaryWriter writes frames as length-delimited protobuf messages, the most
+// compact format and the cheapest to read back
+type BinaryWriter struct {
+	w *bufio.Writer
+}
+
+// NewBinaryWriter creates a binary telemetry writer
+func NewBinaryWriter(w io.Writer) *BinaryWriter {
+	return &BinaryWriter{w: bufio.NewWriter(w)}
+}
+
+// Write appends one delimited message
+func (b *BinaryWriter) Write(data *pb.EngineData) error {
+	_, err := protodelim.MarshalTo(b.w, data)
+	return err
+}
+
+// Close flushes buffered messages
+func (b *BinaryWriter) Close() error {
+	return b.w.Flush()
+}
+
+// readBinary parses length-delimited frames until the end of the stream
+func readBinary(r io.Reader) ([]*pb.EngineData, error) {
+	var frames []*pb.EngineData
+
+	br := bufio.NewReader(r)
+	for {
+		data := &pb.EngineData{}
+		err := protodelim.UnmarshalFrom(br, data)
+		if err == io.EOF {
+			return frames, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("frame %d: %w", len(frames), err)
+		}
+		frames = append(frames, data)
+	}
+}
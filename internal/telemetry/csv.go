@@ -0,0 +1,153 @@
+package telemetry
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CSVWriter writes one row per frame with a header taken from the EngineData field names
+type CSVWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+// NewCSVWriter creates a CSV telemetry writer
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// Write appends one row
+func (c *CSVWriter) Write(data *pb.EngineData) error {
+	fields := data.ProtoReflect().Descriptor().Fields()
+
+	// Header row comes from the message definition so new fields show up automatically
+	if !c.headerWritten {
+		header := make([]string, fields.Len())
+		for i := 0; i < fields.Len(); i++ {
+			header[i] = string(fields.Get(i).Name())
+		}
+		if err := c.w.Write(header); err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+
+	msg := data.ProtoReflect()
+	row := make([]string, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		row[i] = formatField(fields.Get(i), msg.Get(fields.Get(i)))
+	}
+
+	return c.w.Write(row)
+}
+
+// Close flushes buffered rows
+func (c *CSVWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// formatField renders a single field value as CSV text. Lists are joined with ';'.
+func formatField(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.IsList() {
+		list := v.List()
+		parts := make([]string, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			parts[i] = formatScalar(fd, list.Get(i))
+		}
+		return strings.Join(parts, ";")
+	}
+	return formatScalar(fd, v)
+}
+
+// formatScalar renders a single scalar value
+func formatScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.DoubleKind, protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	case protoreflect.MessageKind:
+		return fmt.Sprint(v.Message().Interface())
+	default:
+		return v.String()
+	}
+}
+
+// readCSV parses rows written by CSVWriter. Columns are matched to fields by
+// name, so columns this build does not know about are ignored.
+func readCSV(r io.Reader) ([]*pb.EngineData, error) {
+	cr := csv.NewReader(r)
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]protoreflect.FieldDescriptor, len(header))
+	for i, name := range header {
+		columns[i] = engineDataFields.ByName(protoreflect.Name(name))
+	}
+
+	var frames []*pb.EngineData
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return frames, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		data := &pb.EngineData{}
+		msg := data.ProtoReflect()
+		for i, fd := range columns {
+			if fd == nil || row[i] == "" {
+				continue
+			}
+			v, err := parseScalar(fd, row[i])
+			if err != nil {
+				return nil, fmt.Errorf("row %d, %s: %w", len(frames)+1, fd.Name(), err)
+			}
+			msg.Set(fd, v)
+		}
+		frames = append(frames, data)
+	}
+}
+
+// parseScalar reads a single CSV value back into a field value
+func parseScalar(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.Int32Kind:
+		i, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind:
+		i, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("cannot read %s fields from CSV", fd.Kind())
+	}
+}
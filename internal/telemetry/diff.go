@@ -0,0 +1,129 @@
+package telemetry
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// FieldDiff summarizes how one field differs between two traces
+type FieldDiff struct {
+	Field   string
+	MaxAbs  float64 // Largest absolute difference
+	MaxTick int64   // Tick where the largest difference happened
+	MeanAbs float64
+	Ticks   int // Ticks where the values differ
+}
+
+// diffFields returns the numeric fields worth comparing. Tick and timestamp
+// only say where a frame sits, so they are left out.
+func diffFields() []string {
+	var names []string
+	for i := 0; i < engineDataFields.Len(); i++ {
+		name := string(engineDataFields.Get(i).Name())
+		if name == "tick" || name == "timestamp" {
+			continue
+		}
+		if _, err := numericField(name); err == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// pairs matches frames from two traces on their tick number
+func pairs(a, b []*pb.EngineData) [][2]*pb.EngineData {
+	byTick := make(map[int64]*pb.EngineData, len(b))
+	for _, data := range b {
+		byTick[data.Tick] = data
+	}
+
+	var matched [][2]*pb.EngineData
+	for _, data := range a {
+		if other, ok := byTick[data.Tick]; ok {
+			matched = append(matched, [2]*pb.EngineData{data, other})
+		}
+	}
+	return matched
+}
+
+// Diff compares two traces tick by tick and summarizes every field that
+// differs. Ticks present in only one trace are skipped; the number of ticks
+// compared is returned alongside.
+func Diff(a, b []*pb.EngineData) ([]FieldDiff, int) {
+	matched := pairs(a, b)
+
+	var diffs []FieldDiff
+	for _, name := range diffFields() {
+		d := FieldDiff{Field: name}
+		total := 0.0
+
+		for _, p := range matched {
+			va, _ := Value(p[0], name)
+			vb, _ := Value(p[1], name)
+			delta := math.Abs(vb - va)
+			if delta == 0 {
+				continue
+			}
+
+			d.Ticks++
+			total += delta
+			if delta > d.MaxAbs {
+				d.MaxAbs = delta
+				d.MaxTick = p[0].Tick
+			}
+		}
+
+		if d.Ticks > 0 {
+			d.MeanAbs = total / float64(len(matched))
+			diffs = append(diffs, d)
+		}
+	}
+
+	return diffs, len(matched)
+}
+
+// WriteDiffCSV writes one row per matched tick with the difference (b - a) of every numeric field
+func WriteDiffCSV(w io.Writer, a, b []*pb.EngineData) error {
+	fields := diffFields()
+	cw := csv.NewWriter(w)
+
+	header := append([]string{"tick"}, fields...)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, p := range pairs(a, b) {
+		row := make([]string, 0, len(header))
+		row = append(row, strconv.FormatInt(p[0].Tick, 10))
+		for _, name := range fields {
+			va, _ := Value(p[0], name)
+			vb, _ := Value(p[1], name)
+			row = append(row, strconv.FormatFloat(vb-va, 'g', -1, 64))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteDiffReport prints a human readable summary of a diff
+func WriteDiffReport(w io.Writer, diffs []FieldDiff, compared int) {
+	if len(diffs) == 0 {
+		fmt.Fprintf(w, "Traces identical over %d ticks\n", compared)
+		return
+	}
+
+	fmt.Fprintf(w, "%d fields differ over %d ticks:\n", len(diffs), compared)
+	fmt.Fprintf(w, "  %-20s %12s %8s %12s %8s\n", "field", "max |diff|", "at tick", "mean |diff|", "ticks")
+	for _, d := range diffs {
+		fmt.Fprintf(w, "  %-20s %12.4g %8d %12.4g %8d\n", d.Field, d.MaxAbs, d.MaxTick, d.MeanAbs, d.Ticks)
+	}
+}
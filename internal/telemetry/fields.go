@@ -0,0 +1,64 @@
+package telemetry
+
+import (
+	"fmt"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// engineDataFields describes the EngineData message, used to look fields up by name
+var engineDataFields = (&pb.EngineData{}).ProtoReflect().Descriptor().Fields()
+
+// numericField finds a scalar numeric EngineData field by its proto name
+func numericField(name string) (protoreflect.FieldDescriptor, error) {
+	fd := engineDataFields.ByName(protoreflect.Name(name))
+	if fd == nil {
+		return nil, fmt.Errorf("unknown telemetry field %q", name)
+	}
+	if fd.IsList() || fd.IsMap() {
+		return nil, fmt.Errorf("telemetry field %q is not a single value", name)
+	}
+
+	switch fd.Kind() {
+	case protoreflect.DoubleKind, protoreflect.FloatKind,
+		protoreflect.Int32Kind, protoreflect.Int64Kind,
+		protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+		protoreflect.BoolKind, protoreflect.EnumKind:
+		return fd, nil
+	default:
+		return nil, fmt.Errorf("telemetry field %q is not numeric", name)
+	}
+}
+
+// ValidateField checks that name is a numeric EngineData field
+func ValidateField(name string) error {
+	_, err := numericField(name)
+	return err
+}
+
+// Value reads a numeric EngineData field by its proto name (e.g. "rpm", "afr_current").
+// Booleans read as 0 or 1.
+func Value(data *pb.EngineData, name string) (float64, error) {
+	fd, err := numericField(name)
+	if err != nil {
+		return 0, err
+	}
+
+	v := data.ProtoReflect().Get(fd)
+	switch fd.Kind() {
+	case protoreflect.DoubleKind, protoreflect.FloatKind:
+		return v.Float(), nil
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return float64(v.Int()), nil
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind:
+		return float64(v.Uint()), nil
+	case protoreflect.EnumKind:
+		return float64(v.Enum()), nil
+	default:
+		if v.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	}
+}
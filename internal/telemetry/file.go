@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FileWriter is a Writer that owns the file it writes to
+type FileWriter struct {
+	Writer
+	file *os.File
+}
+
+// CreateFile creates the file at path, along with any missing directories,
+// and returns a writer for the given format
+func CreateFile(path, format string) (*FileWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := NewWriter(format, f)
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, err
+	}
+
+	return &FileWriter{Writer: w, file: f}, nil
+}
+
+// Close finishes the telemetry and closes the file
+func (f *FileWriter) Close() error {
+	err := f.Writer.Close()
+	if cerr := f.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
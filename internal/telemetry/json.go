@@ -0,0 +1,81 @@
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// jsonOptions renders frames with the proto field names and every field present
+var jsonOptions = protojson.MarshalOptions{
+	UseProtoNames:   true,
+	EmitUnpopulated: true,
+}
+
+// JSONWriter writes all frames as a single JSON array
+type JSONWriter struct {
+	w     *bufio.Writer
+	count int
+}
+
+// NewJSONWriter creates a JSON array telemetry writer
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{w: bufio.NewWriter(w)}
+}
+
+// Write appends one element to the array
+func (j *JSONWriter) Write(data *pb.EngineData) error {
+	b, err := jsonOptions.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n"
+	if j.count == 0 {
+		sep = "[\n"
+	}
+	j.count++
+
+	if _, err := j.w.WriteString(sep); err != nil {
+		return err
+	}
+	_, err = j.w.Write(b)
+	return err
+}
+
+// Close terminates the array and flushes
+func (j *JSONWriter) Close() error {
+	end := "\n]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	if _, err := j.w.WriteString(end); err != nil {
+		return err
+	}
+	return j.w.Flush()
+}
+
+// unmarshalOptions accepts logs written by newer versions with fields this build does not know
+var unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// readJSON parses a JSON array of frames
+func readJSON(r io.Reader) ([]*pb.EngineData, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	frames := make([]*pb.EngineData, len(raw))
+	for i, b := range raw {
+		frames[i] = &pb.EngineData{}
+		if err := unmarshalOptions.Unmarshal(b, frames[i]); err != nil {
+			return nil, fmt.Errorf("frame %d: %w", i, err)
+		}
+	}
+
+	return frames, nil
+}
@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// JSONLWriter writes one JSON object per line. Unlike JSONWriter the output
+// stays valid if the recording is cut short.
+type JSONLWriter struct {
+	w *bufio.Writer
+}
+
+// NewJSONLWriter creates a JSON Lines telemetry writer
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	return &JSONLWriter{w: bufio.NewWriter(w)}
+}
+
+// Write appends one line
+func (j *JSONLWriter) Write(data *pb.EngineData) error {
+	b, err := jsonOptions.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := j.w.Write(b); err != nil {
+		return err
+	}
+	return j.w.WriteByte('\n')
+}
+
+// Close flushes buffered lines
+func (j *JSONLWriter) Close() error {
+	return j.w.Flush()
+}
+
+// readJSONL parses one frame per line, skipping blank lines
+func readJSONL(r io.Reader) ([]*pb.EngineData, error) {
+	var frames []*pb.EngineData
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		data := &pb.EngineData{}
+		if err := unmarshalOptions.Unmarshal(scanner.Bytes(), data); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		frames = append(frames, data)
+	}
+
+	return frames, scanner.Err()
+}
@@ -0,0 +1,40 @@
+package telemetry
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// ReadAll reads every frame from a telemetry stream in the given format
+func ReadAll(format string, r io.Reader) ([]*pb.EngineData, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatJSON:
+		return readJSON(r)
+	case FormatJSONL:
+		return readJSONL(r)
+	case FormatBinary:
+		return readBinary(r)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownFormat, format)
+	}
+}
+
+// ReadFile loads a recorded telemetry file, taking the format from its extension
+func ReadFile(path string) ([]*pb.EngineData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	frames, err := ReadAll(FormatFromPath(path), f)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return frames, nil
+}
@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/StevenD2002/ninja650sim/proto"
+)
+
+// Supported telemetry file formats
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatJSONL  = "jsonl"
+	FormatBinary = "bin" // Length-delimited protobuf
+)
+
+// ErrUnknownFormat is returned for a format name that has no writer
+var ErrUnknownFormat = errors.New("unknown telemetry format")
+
+// Writer writes a stream of telemetry frames
+type Writer interface {
+	// Write appends one frame
+	Write(data *pb.EngineData) error
+
+	// Close flushes anything buffered and finishes the file. It does not close the underlying io.Writer.
+	Close() error
+}
+
+// NewWriter creates a writer for the named format
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatJSON:
+		return NewJSONWriter(w), nil
+	case FormatJSONL:
+		return NewJSONLWriter(w), nil
+	case FormatBinary:
+		return NewBinaryWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownFormat, format)
+	}
+}
+
+// FormatFromPath guesses the telemetry format from a file extension
+func FormatFromPath(path string) string {
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+}
+
+// Collector is a Writer that keeps every frame in memory
+type Collector struct {
+	Frames []*pb.EngineData
+}
+
+// Write keeps the frame
+func (c *Collector) Write(data *pb.EngineData) error {
+	c.Frames = append(c.Frames, data)
+	return nil
+}
+
+// Close does nothing
+func (c *Collector) Close() error {
+	return nil
+}
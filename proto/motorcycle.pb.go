@@ -26,19 +26,60 @@ type EngineData struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Rpm              float64 `protobuf:"fixed64,1,opt,name=rpm,proto3" json:"rpm,omitempty"`
-	ThrottlePosition float64 `protobuf:"fixed64,2,opt,name=throttle_position,json=throttlePosition,proto3" json:"throttle_position,omitempty"` // 0-100%
-	Timestamp        int64   `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Power            float64 `protobuf:"fixed64,4,opt,name=power,proto3" json:"power,omitempty"`                                              // Horsepower
-	Torque           float64 `protobuf:"fixed64,5,opt,name=torque,proto3" json:"torque,omitempty"`                                            // Nm
-	EngineTemp       float64 `protobuf:"fixed64,6,opt,name=engine_temp,json=engineTemp,proto3" json:"engine_temp,omitempty"`                  // Celsius
-	AfrCurrent       float64 `protobuf:"fixed64,7,opt,name=afr_current,json=afrCurrent,proto3" json:"afr_current,omitempty"`                  // Current Air/Fuel Ratio
-	AfrTarget        float64 `protobuf:"fixed64,8,opt,name=afr_target,json=afrTarget,proto3" json:"afr_target,omitempty"`                     // Target Air/Fuel Ratio
-	FuelInjectionMs  float64 `protobuf:"fixed64,9,opt,name=fuel_injection_ms,json=fuelInjectionMs,proto3" json:"fuel_injection_ms,omitempty"` // Fuel injection duration in ms
-	IgnitionAdvance  float64 `protobuf:"fixed64,10,opt,name=ignition_advance,json=ignitionAdvance,proto3" json:"ignition_advance,omitempty"`  // Ignition timing in degrees BTDC
-	Gear             int32   `protobuf:"varint,11,opt,name=gear,proto3" json:"gear,omitempty"`
-	Speed            float64 `protobuf:"fixed64,12,opt,name=speed,proto3" json:"speed,omitempty"`                                         // km/h
-	ClutchPosition   float64 `protobuf:"fixed64,13,opt,name=clutch_position,json=clutchPosition,proto3" json:"clutch_position,omitempty"` // 0-1
+	Rpm                   float64 `protobuf:"fixed64,1,opt,name=rpm,proto3" json:"rpm,omitempty"`
+	ThrottlePosition      float64 `protobuf:"fixed64,2,opt,name=throttle_position,json=throttlePosition,proto3" json:"throttle_position,omitempty"` // 0-100%
+	Timestamp             int64   `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Power                 float64 `protobuf:"fixed64,4,opt,name=power,proto3" json:"power,omitempty"`                                              // Horsepower
+	Torque                float64 `protobuf:"fixed64,5,opt,name=torque,proto3" json:"torque,omitempty"`                                            // Nm
+	EngineTemp            float64 `protobuf:"fixed64,6,opt,name=engine_temp,json=engineTemp,proto3" json:"engine_temp,omitempty"`                  // Celsius
+	AfrCurrent            float64 `protobuf:"fixed64,7,opt,name=afr_current,json=afrCurrent,proto3" json:"afr_current,omitempty"`                  // Current Air/Fuel Ratio
+	AfrTarget             float64 `protobuf:"fixed64,8,opt,name=afr_target,json=afrTarget,proto3" json:"afr_target,omitempty"`                     // Target Air/Fuel Ratio
+	FuelInjectionMs       float64 `protobuf:"fixed64,9,opt,name=fuel_injection_ms,json=fuelInjectionMs,proto3" json:"fuel_injection_ms,omitempty"` // Fuel injection duration in ms
+	IgnitionAdvance       float64 `protobuf:"fixed64,10,opt,name=ignition_advance,json=ignitionAdvance,proto3" json:"ignition_advance,omitempty"`  // Ignition timing in degrees BTDC
+	Gear                  int32   `protobuf:"varint,11,opt,name=gear,proto3" json:"gear,omitempty"`
+	Speed                 float64 `protobuf:"fixed64,12,opt,name=speed,proto3" json:"speed,omitempty"`                                                                // km/h
+	ClutchPosition        float64 `protobuf:"fixed64,13,opt,name=clutch_position,json=clutchPosition,proto3" json:"clutch_position,omitempty"`                        // 0-1
+	Tick                  int64   `protobuf:"varint,14,opt,name=tick,proto3" json:"tick,omitempty"`                                                                   // Simulation step number
+	KnockCount            int32   `protobuf:"varint,15,opt,name=knock_count,json=knockCount,proto3" json:"knock_count,omitempty"`                                     // Knock events seen by the ECU
+	AfrDeviation          float64 `protobuf:"fixed64,16,opt,name=afr_deviation,json=afrDeviation,proto3" json:"afr_deviation,omitempty"`                              // Distance from target AFR
+	ClosedLoop            bool    `protobuf:"varint,17,opt,name=closed_loop,json=closedLoop,proto3" json:"closed_loop,omitempty"`                                     // Fueling corrected from the O2 sensor
+	ShortTermFuelTrim     float64 `protobuf:"fixed64,18,opt,name=short_term_fuel_trim,json=shortTermFuelTrim,proto3" json:"short_term_fuel_trim,omitempty"`           // Percent
+	LongTermFuelTrim      float64 `protobuf:"fixed64,19,opt,name=long_term_fuel_trim,json=longTermFuelTrim,proto3" json:"long_term_fuel_trim,omitempty"`              // Percent, learned for the current cell
+	KnockIntensity        float64 `protobuf:"fixed64,20,opt,name=knock_intensity,json=knockIntensity,proto3" json:"knock_intensity,omitempty"`                        // Knock sensor signal, 0 = quiet
+	Knocking              bool    `protobuf:"varint,21,opt,name=knocking,proto3" json:"knocking,omitempty"`                                                           // Knock heard by the ECU this step
+	KnockRetard           float64 `protobuf:"fixed64,22,opt,name=knock_retard,json=knockRetard,proto3" json:"knock_retard,omitempty"`                                 // Degrees pulled in response to knock
+	LearnedKnockRetard    float64 `protobuf:"fixed64,23,opt,name=learned_knock_retard,json=learnedKnockRetard,proto3" json:"learned_knock_retard,omitempty"`          // Degrees, learned for the current cell
+	EngineLoad            float64 `protobuf:"fixed64,24,opt,name=engine_load,json=engineLoad,proto3" json:"engine_load,omitempty"`                                    // Load used for map lookups, percent; its meaning depends on the load model
+	ManifoldPressure      float64 `protobuf:"fixed64,25,opt,name=manifold_pressure,json=manifoldPressure,proto3" json:"manifold_pressure,omitempty"`                  // kPa
+	AirMass               float64 `protobuf:"fixed64,26,opt,name=air_mass,json=airMass,proto3" json:"air_mass,omitempty"`                                             // mg of air per cylinder per cycle
+	AirFlow               float64 `protobuf:"fixed64,27,opt,name=air_flow,json=airFlow,proto3" json:"air_flow,omitempty"`                                             // g/s of air into the engine
+	FuelCut               bool    `protobuf:"varint,28,opt,name=fuel_cut,json=fuelCut,proto3" json:"fuel_cut,omitempty"`                                              // Deceleration fuel cut active
+	AccelEnrichment       float64 `protobuf:"fixed64,29,opt,name=accel_enrichment,json=accelEnrichment,proto3" json:"accel_enrichment,omitempty"`                     // Percent extra fuel for a throttle opening
+	FuelFilm              float64 `protobuf:"fixed64,30,opt,name=fuel_film,json=fuelFilm,proto3" json:"fuel_film,omitempty"`                                          // mg of fuel on the intake port wall, per cylinder
+	RevLimiterActive      bool    `protobuf:"varint,31,opt,name=rev_limiter_active,json=revLimiterActive,proto3" json:"rev_limiter_active,omitempty"`                 // Rev limiter retarding or cutting
+	RevLimit              float64 `protobuf:"fixed64,32,opt,name=rev_limit,json=revLimit,proto3" json:"rev_limit,omitempty"`                                          // RPM limit in force: rev limit, gear limit or launch limit
+	LaunchControl         bool    `protobuf:"varint,33,opt,name=launch_control,json=launchControl,proto3" json:"launch_control,omitempty"`                            // Launch control limit in force
+	LimiterRetard         float64 `protobuf:"fixed64,34,opt,name=limiter_retard,json=limiterRetard,proto3" json:"limiter_retard,omitempty"`                           // Degrees pulled by the rev limiter
+	LimiterSparkCut       float64 `protobuf:"fixed64,35,opt,name=limiter_spark_cut,json=limiterSparkCut,proto3" json:"limiter_spark_cut,omitempty"`                   // Percent of sparks cut by the rev limiter
+	LimiterFuelCut        float64 `protobuf:"fixed64,36,opt,name=limiter_fuel_cut,json=limiterFuelCut,proto3" json:"limiter_fuel_cut,omitempty"`                      // Percent of injections cut by the rev limiter
+	WarmupEnrichment      float64 `protobuf:"fixed64,37,opt,name=warmup_enrichment,json=warmupEnrichment,proto3" json:"warmup_enrichment,omitempty"`                  // Percent extra fuel for a cold engine
+	AfterStartEnrichment  float64 `protobuf:"fixed64,38,opt,name=after_start_enrichment,json=afterStartEnrichment,proto3" json:"after_start_enrichment,omitempty"`    // Percent extra fuel just after starting
+	ColdIgnitionRetard    float64 `protobuf:"fixed64,39,opt,name=cold_ignition_retard,json=coldIgnitionRetard,proto3" json:"cold_ignition_retard,omitempty"`          // Degrees pulled for a cold engine
+	IdleTarget            float64 `protobuf:"fixed64,40,opt,name=idle_target,json=idleTarget,proto3" json:"idle_target,omitempty"`                                    // Idle RPM the ECU is aiming for
+	BatteryVoltage        float64 `protobuf:"fixed64,41,opt,name=battery_voltage,json=batteryVoltage,proto3" json:"battery_voltage,omitempty"`                        // Volts
+	Baro                  float64 `protobuf:"fixed64,42,opt,name=baro,proto3" json:"baro,omitempty"`                                                                  // Barometric pressure, kPa
+	IatFuelCorrection     float64 `protobuf:"fixed64,43,opt,name=iat_fuel_correction,json=iatFuelCorrection,proto3" json:"iat_fuel_correction,omitempty"`             // Percent fuel for intake air temperature
+	BaroFuelCorrection    float64 `protobuf:"fixed64,44,opt,name=baro_fuel_correction,json=baroFuelCorrection,proto3" json:"baro_fuel_correction,omitempty"`          // Percent fuel for barometric pressure
+	IatIgnitionCorrection float64 `protobuf:"fixed64,45,opt,name=iat_ignition_correction,json=iatIgnitionCorrection,proto3" json:"iat_ignition_correction,omitempty"` // Degrees for intake air temperature
+	InjectorDeadTime      float64 `protobuf:"fixed64,46,opt,name=injector_dead_time,json=injectorDeadTime,proto3" json:"injector_dead_time,omitempty"`                // Milliseconds added to each pulse
+	FuelMass              float64 `protobuf:"fixed64,47,opt,name=fuel_mass,json=fuelMass,proto3" json:"fuel_mass,omitempty"`                                          // Fuel asked of each injection, mg
+	InjectorDuty          float64 `protobuf:"fixed64,48,opt,name=injector_duty,json=injectorDuty,proto3" json:"injector_duty,omitempty"`                              // Percent of each engine cycle the injector is open
+	FiLamp                bool    `protobuf:"varint,49,opt,name=fi_lamp,json=fiLamp,proto3" json:"fi_lamp,omitempty"`                                                 // FI warning lamp, lit while a confirmed fault is present
+	DtcCount              int32   `protobuf:"varint,50,opt,name=dtc_count,json=dtcCount,proto3" json:"dtc_count,omitempty"`                                           // Stored trouble codes
+	SensorFaults          string  `protobuf:"bytes,51,opt,name=sensor_faults,json=sensorFaults,proto3" json:"sensor_faults,omitempty"`                                // Injected faults in effect, e.g. "tps:stuck,map:noise"
+	SensorFaultCount      int32   `protobuf:"varint,52,opt,name=sensor_fault_count,json=sensorFaultCount,proto3" json:"sensor_fault_count,omitempty"`                 // Number of injected faults in effect
+	Failsafe              string  `protobuf:"bytes,53,opt,name=failsafe,proto3" json:"failsafe,omitempty"`                                                            // Failsafe strategies in force, e.g. "tps_default,limp"
+	LimpMode              bool    `protobuf:"varint,54,opt,name=limp_mode,json=limpMode,proto3" json:"limp_mode,omitempty"`                                           // Rev limit lowered by the failsafe
 }
 
 func (x *EngineData) Reset() {
@@ -164,6 +205,293 @@ func (x *EngineData) GetClutchPosition() float64 {
 	return 0
 }
 
+func (x *EngineData) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *EngineData) GetKnockCount() int32 {
+	if x != nil {
+		return x.KnockCount
+	}
+	return 0
+}
+
+func (x *EngineData) GetAfrDeviation() float64 {
+	if x != nil {
+		return x.AfrDeviation
+	}
+	return 0
+}
+
+func (x *EngineData) GetClosedLoop() bool {
+	if x != nil {
+		return x.ClosedLoop
+	}
+	return false
+}
+
+func (x *EngineData) GetShortTermFuelTrim() float64 {
+	if x != nil {
+		return x.ShortTermFuelTrim
+	}
+	return 0
+}
+
+func (x *EngineData) GetLongTermFuelTrim() float64 {
+	if x != nil {
+		return x.LongTermFuelTrim
+	}
+	return 0
+}
+
+func (x *EngineData) GetKnockIntensity() float64 {
+	if x != nil {
+		return x.KnockIntensity
+	}
+	return 0
+}
+
+func (x *EngineData) GetKnocking() bool {
+	if x != nil {
+		return x.Knocking
+	}
+	return false
+}
+
+func (x *EngineData) GetKnockRetard() float64 {
+	if x != nil {
+		return x.KnockRetard
+	}
+	return 0
+}
+
+func (x *EngineData) GetLearnedKnockRetard() float64 {
+	if x != nil {
+		return x.LearnedKnockRetard
+	}
+	return 0
+}
+
+func (x *EngineData) GetEngineLoad() float64 {
+	if x != nil {
+		return x.EngineLoad
+	}
+	return 0
+}
+
+func (x *EngineData) GetManifoldPressure() float64 {
+	if x != nil {
+		return x.ManifoldPressure
+	}
+	return 0
+}
+
+func (x *EngineData) GetAirMass() float64 {
+	if x != nil {
+		return x.AirMass
+	}
+	return 0
+}
+
+func (x *EngineData) GetAirFlow() float64 {
+	if x != nil {
+		return x.AirFlow
+	}
+	return 0
+}
+
+func (x *EngineData) GetFuelCut() bool {
+	if x != nil {
+		return x.FuelCut
+	}
+	return false
+}
+
+func (x *EngineData) GetAccelEnrichment() float64 {
+	if x != nil {
+		return x.AccelEnrichment
+	}
+	return 0
+}
+
+func (x *EngineData) GetFuelFilm() float64 {
+	if x != nil {
+		return x.FuelFilm
+	}
+	return 0
+}
+
+func (x *EngineData) GetRevLimiterActive() bool {
+	if x != nil {
+		return x.RevLimiterActive
+	}
+	return false
+}
+
+func (x *EngineData) GetRevLimit() float64 {
+	if x != nil {
+		return x.RevLimit
+	}
+	return 0
+}
+
+func (x *EngineData) GetLaunchControl() bool {
+	if x != nil {
+		return x.LaunchControl
+	}
+	return false
+}
+
+func (x *EngineData) GetLimiterRetard() float64 {
+	if x != nil {
+		return x.LimiterRetard
+	}
+	return 0
+}
+
+func (x *EngineData) GetLimiterSparkCut() float64 {
+	if x != nil {
+		return x.LimiterSparkCut
+	}
+	return 0
+}
+
+func (x *EngineData) GetLimiterFuelCut() float64 {
+	if x != nil {
+		return x.LimiterFuelCut
+	}
+	return 0
+}
+
+func (x *EngineData) GetWarmupEnrichment() float64 {
+	if x != nil {
+		return x.WarmupEnrichment
+	}
+	return 0
+}
+
+func (x *EngineData) GetAfterStartEnrichment() float64 {
+	if x != nil {
+		return x.AfterStartEnrichment
+	}
+	return 0
+}
+
+func (x *EngineData) GetColdIgnitionRetard() float64 {
+	if x != nil {
+		return x.ColdIgnitionRetard
+	}
+	return 0
+}
+
+func (x *EngineData) GetIdleTarget() float64 {
+	if x != nil {
+		return x.IdleTarget
+	}
+	return 0
+}
+
+func (x *EngineData) GetBatteryVoltage() float64 {
+	if x != nil {
+		return x.BatteryVoltage
+	}
+	return 0
+}
+
+func (x *EngineData) GetBaro() float64 {
+	if x != nil {
+		return x.Baro
+	}
+	return 0
+}
+
+func (x *EngineData) GetIatFuelCorrection() float64 {
+	if x != nil {
+		return x.IatFuelCorrection
+	}
+	return 0
+}
+
+func (x *EngineData) GetBaroFuelCorrection() float64 {
+	if x != nil {
+		return x.BaroFuelCorrection
+	}
+	return 0
+}
+
+func (x *EngineData) GetIatIgnitionCorrection() float64 {
+	if x != nil {
+		return x.IatIgnitionCorrection
+	}
+	return 0
+}
+
+func (x *EngineData) GetInjectorDeadTime() float64 {
+	if x != nil {
+		return x.InjectorDeadTime
+	}
+	return 0
+}
+
+func (x *EngineData) GetFuelMass() float64 {
+	if x != nil {
+		return x.FuelMass
+	}
+	return 0
+}
+
+func (x *EngineData) GetInjectorDuty() float64 {
+	if x != nil {
+		return x.InjectorDuty
+	}
+	return 0
+}
+
+func (x *EngineData) GetFiLamp() bool {
+	if x != nil {
+		return x.FiLamp
+	}
+	return false
+}
+
+func (x *EngineData) GetDtcCount() int32 {
+	if x != nil {
+		return x.DtcCount
+	}
+	return 0
+}
+
+func (x *EngineData) GetSensorFaults() string {
+	if x != nil {
+		return x.SensorFaults
+	}
+	return ""
+}
+
+func (x *EngineData) GetSensorFaultCount() int32 {
+	if x != nil {
+		return x.SensorFaultCount
+	}
+	return 0
+}
+
+func (x *EngineData) GetFailsafe() string {
+	if x != nil {
+		return x.Failsafe
+	}
+	return ""
+}
+
+func (x *EngineData) GetLimpMode() bool {
+	if x != nil {
+		return x.LimpMode
+	}
+	return false
+}
+
 // User input
 type UserInput struct {
 	state         protoimpl.MessageState
@@ -282,7 +610,7 @@ type Map2D struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type            string    `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "fuel", "ignition", or "afr"
+	Type            string    `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "fuel", "ignition", "afr", "ltft" or "knock"
 	RpmBreakpoints  []float64 `protobuf:"fixed64,2,rep,packed,name=rpm_breakpoints,json=rpmBreakpoints,proto3" json:"rpm_breakpoints,omitempty"`
 	LoadBreakpoints []float64 `protobuf:"fixed64,3,rep,packed,name=load_breakpoints,json=loadBreakpoints,proto3" json:"load_breakpoints,omitempty"`
 	Values          []*MapRow `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"`
@@ -348,19 +676,20 @@ func (x *Map2D) GetValues() []*MapRow {
 	return nil
 }
 
-// All ECU maps
-type ECUMaps struct {
+// A stack of 2D maps along a third axis (e.g., ignition by gear)
+type Map3D struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FuelMap     *Map2D `protobuf:"bytes,1,opt,name=fuel_map,json=fuelMap,proto3" json:"fuel_map,omitempty"`
-	IgnitionMap *Map2D `protobuf:"bytes,2,opt,name=ignition_map,json=ignitionMap,proto3" json:"ignition_map,omitempty"`
-	AfrMap      *Map2D `protobuf:"bytes,3,opt,name=afr_map,json=afrMap,proto3" json:"afr_map,omitempty"`
+	Type        string    `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                        // "fuel_3d", "ignition_3d" or "afr_3d"
+	Axis        string    `protobuf:"bytes,2,opt,name=axis,proto3" json:"axis,omitempty"`                        // "gear" or "engine_temp"
+	Breakpoints []float64 `protobuf:"fixed64,3,rep,packed,name=breakpoints,proto3" json:"breakpoints,omitempty"` // Third axis value of each layer
+	Layers      []*Map2D  `protobuf:"bytes,4,rep,name=layers,proto3" json:"layers,omitempty"`
 }
 
-func (x *ECUMaps) Reset() {
-	*x = ECUMaps{}
+func (x *Map3D) Reset() {
+	*x = Map3D{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_motorcycle_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -368,13 +697,13 @@ func (x *ECUMaps) Reset() {
 	}
 }
 
-func (x *ECUMaps) String() string {
+func (x *Map3D) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ECUMaps) ProtoMessage() {}
+func (*Map3D) ProtoMessage() {}
 
-func (x *ECUMaps) ProtoReflect() protoreflect.Message {
+func (x *Map3D) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_motorcycle_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -386,41 +715,52 @@ func (x *ECUMaps) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ECUMaps.ProtoReflect.Descriptor instead.
-func (*ECUMaps) Descriptor() ([]byte, []int) {
+// Deprecated: Use Map3D.ProtoReflect.Descriptor instead.
+func (*Map3D) Descriptor() ([]byte, []int) {
 	return file_proto_motorcycle_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ECUMaps) GetFuelMap() *Map2D {
+func (x *Map3D) GetType() string {
 	if x != nil {
-		return x.FuelMap
+		return x.Type
 	}
-	return nil
+	return ""
 }
 
-func (x *ECUMaps) GetIgnitionMap() *Map2D {
+func (x *Map3D) GetAxis() string {
 	if x != nil {
-		return x.IgnitionMap
+		return x.Axis
+	}
+	return ""
+}
+
+func (x *Map3D) GetBreakpoints() []float64 {
+	if x != nil {
+		return x.Breakpoints
 	}
 	return nil
 }
 
-func (x *ECUMaps) GetAfrMap() *Map2D {
+func (x *Map3D) GetLayers() []*Map2D {
 	if x != nil {
-		return x.AfrMap
+		return x.Layers
 	}
 	return nil
 }
 
-// Request for ECU maps
-type MapsRequest struct {
+// A 1D table (e.g., warm-up enrichment against engine temperature)
+type Map1D struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Type        string    `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "warmup", "after_start", "cold_ignition", "cold_idle", "iat_fuel", "iat_ignition", "baro_fuel" or "dead_time"
+	Breakpoints []float64 `protobuf:"fixed64,2,rep,packed,name=breakpoints,proto3" json:"breakpoints,omitempty"`
+	Values      []float64 `protobuf:"fixed64,3,rep,packed,name=values,proto3" json:"values,omitempty"`
 }
 
-func (x *MapsRequest) Reset() {
-	*x = MapsRequest{}
+func (x *Map1D) Reset() {
+	*x = Map1D{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_motorcycle_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -428,13 +768,13 @@ func (x *MapsRequest) Reset() {
 	}
 }
 
-func (x *MapsRequest) String() string {
+func (x *Map1D) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MapsRequest) ProtoMessage() {}
+func (*Map1D) ProtoMessage() {}
 
-func (x *MapsRequest) ProtoReflect() protoreflect.Message {
+func (x *Map1D) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_motorcycle_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -446,25 +786,58 @@ func (x *MapsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MapsRequest.ProtoReflect.Descriptor instead.
-func (*MapsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Map1D.ProtoReflect.Descriptor instead.
+func (*Map1D) Descriptor() ([]byte, []int) {
 	return file_proto_motorcycle_proto_rawDescGZIP(), []int{5}
 }
 
-// Request to update a map cell
-type MapUpdateRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Map1D) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
 
-	MapType string  `protobuf:"bytes,1,opt,name=map_type,json=mapType,proto3" json:"map_type,omitempty"` // "fuel", "ignition", or "afr"
-	Rpm     float64 `protobuf:"fixed64,2,opt,name=rpm,proto3" json:"rpm,omitempty"`
-	Load    float64 `protobuf:"fixed64,3,opt,name=load,proto3" json:"load,omitempty"`
-	Value   float64 `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+func (x *Map1D) GetBreakpoints() []float64 {
+	if x != nil {
+		return x.Breakpoints
+	}
+	return nil
 }
 
-func (x *MapUpdateRequest) Reset() {
-	*x = MapUpdateRequest{}
+func (x *Map1D) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// All ECU maps
+type ECUMaps struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FuelMap               *Map2D `protobuf:"bytes,1,opt,name=fuel_map,json=fuelMap,proto3" json:"fuel_map,omitempty"`
+	IgnitionMap           *Map2D `protobuf:"bytes,2,opt,name=ignition_map,json=ignitionMap,proto3" json:"ignition_map,omitempty"`
+	AfrMap                *Map2D `protobuf:"bytes,3,opt,name=afr_map,json=afrMap,proto3" json:"afr_map,omitempty"`
+	LongTermFuelTrim      *Map2D `protobuf:"bytes,4,opt,name=long_term_fuel_trim,json=longTermFuelTrim,proto3" json:"long_term_fuel_trim,omitempty"`               // Learned by closed-loop fueling, percent
+	KnockRetard           *Map2D `protobuf:"bytes,5,opt,name=knock_retard,json=knockRetard,proto3" json:"knock_retard,omitempty"`                                  // Learned by knock control, degrees
+	WarmupEnrichment      *Map1D `protobuf:"bytes,6,opt,name=warmup_enrichment,json=warmupEnrichment,proto3" json:"warmup_enrichment,omitempty"`                   // Percent extra fuel by engine temperature
+	AfterStartEnrichment  *Map1D `protobuf:"bytes,7,opt,name=after_start_enrichment,json=afterStartEnrichment,proto3" json:"after_start_enrichment,omitempty"`     // Percent extra fuel just after starting, by engine temperature
+	ColdIgnitionRetard    *Map1D `protobuf:"bytes,8,opt,name=cold_ignition_retard,json=coldIgnitionRetard,proto3" json:"cold_ignition_retard,omitempty"`           // Degrees by engine temperature
+	ColdIdleRpm           *Map1D `protobuf:"bytes,9,opt,name=cold_idle_rpm,json=coldIdleRpm,proto3" json:"cold_idle_rpm,omitempty"`                                // Idle target by engine temperature
+	IatFuelCorrection     *Map1D `protobuf:"bytes,10,opt,name=iat_fuel_correction,json=iatFuelCorrection,proto3" json:"iat_fuel_correction,omitempty"`             // Percent fuel by intake air temperature
+	IatIgnitionCorrection *Map1D `protobuf:"bytes,11,opt,name=iat_ignition_correction,json=iatIgnitionCorrection,proto3" json:"iat_ignition_correction,omitempty"` // Degrees by intake air temperature
+	BaroFuelCorrection    *Map1D `protobuf:"bytes,12,opt,name=baro_fuel_correction,json=baroFuelCorrection,proto3" json:"baro_fuel_correction,omitempty"`          // Percent fuel by barometric pressure
+	InjectorDeadTime      *Map1D `protobuf:"bytes,13,opt,name=injector_dead_time,json=injectorDeadTime,proto3" json:"injector_dead_time,omitempty"`                // Milliseconds by battery voltage
+	FuelMap3D             *Map3D `protobuf:"bytes,14,opt,name=fuel_map3d,json=fuelMap3d,proto3" json:"fuel_map3d,omitempty"`                                       // Unset while the 3D fuel map is off
+	IgnitionMap3D         *Map3D `protobuf:"bytes,15,opt,name=ignition_map3d,json=ignitionMap3d,proto3" json:"ignition_map3d,omitempty"`                           // Unset while the 3D ignition map is off
+	AfrMap3D              *Map3D `protobuf:"bytes,16,opt,name=afr_map3d,json=afrMap3d,proto3" json:"afr_map3d,omitempty"`                                          // Unset while the 3D AFR map is off
+}
+
+func (x *ECUMaps) Reset() {
+	*x = ECUMaps{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_motorcycle_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -472,6 +845,202 @@ func (x *MapUpdateRequest) Reset() {
 	}
 }
 
+func (x *ECUMaps) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ECUMaps) ProtoMessage() {}
+
+func (x *ECUMaps) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ECUMaps.ProtoReflect.Descriptor instead.
+func (*ECUMaps) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ECUMaps) GetFuelMap() *Map2D {
+	if x != nil {
+		return x.FuelMap
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetIgnitionMap() *Map2D {
+	if x != nil {
+		return x.IgnitionMap
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetAfrMap() *Map2D {
+	if x != nil {
+		return x.AfrMap
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetLongTermFuelTrim() *Map2D {
+	if x != nil {
+		return x.LongTermFuelTrim
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetKnockRetard() *Map2D {
+	if x != nil {
+		return x.KnockRetard
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetWarmupEnrichment() *Map1D {
+	if x != nil {
+		return x.WarmupEnrichment
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetAfterStartEnrichment() *Map1D {
+	if x != nil {
+		return x.AfterStartEnrichment
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetColdIgnitionRetard() *Map1D {
+	if x != nil {
+		return x.ColdIgnitionRetard
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetColdIdleRpm() *Map1D {
+	if x != nil {
+		return x.ColdIdleRpm
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetIatFuelCorrection() *Map1D {
+	if x != nil {
+		return x.IatFuelCorrection
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetIatIgnitionCorrection() *Map1D {
+	if x != nil {
+		return x.IatIgnitionCorrection
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetBaroFuelCorrection() *Map1D {
+	if x != nil {
+		return x.BaroFuelCorrection
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetInjectorDeadTime() *Map1D {
+	if x != nil {
+		return x.InjectorDeadTime
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetFuelMap3D() *Map3D {
+	if x != nil {
+		return x.FuelMap3D
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetIgnitionMap3D() *Map3D {
+	if x != nil {
+		return x.IgnitionMap3D
+	}
+	return nil
+}
+
+func (x *ECUMaps) GetAfrMap3D() *Map3D {
+	if x != nil {
+		return x.AfrMap3D
+	}
+	return nil
+}
+
+// Request for ECU maps
+type MapsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MapsRequest) Reset() {
+	*x = MapsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapsRequest) ProtoMessage() {}
+
+func (x *MapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapsRequest.ProtoReflect.Descriptor instead.
+func (*MapsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{7}
+}
+
+// Request to update a map cell
+type MapUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MapType string  `protobuf:"bytes,1,opt,name=map_type,json=mapType,proto3" json:"map_type,omitempty"` // "fuel", "ignition", "afr", "ltft", "knock", or a 1D table or 3D map type
+	Rpm     float64 `protobuf:"fixed64,2,opt,name=rpm,proto3" json:"rpm,omitempty"`
+	Load    float64 `protobuf:"fixed64,3,opt,name=load,proto3" json:"load,omitempty"`
+	Value   float64 `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	Axis    float64 `protobuf:"fixed64,5,opt,name=axis,proto3" json:"axis,omitempty"` // Breakpoint to change in a 1D table, e.g. engine temperature, or the layer of a 3D map, e.g. gear
+}
+
+func (x *MapUpdateRequest) Reset() {
+	*x = MapUpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
 func (x *MapUpdateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
@@ -479,7 +1048,7 @@ func (x *MapUpdateRequest) String() string {
 func (*MapUpdateRequest) ProtoMessage() {}
 
 func (x *MapUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_motorcycle_proto_msgTypes[6]
+	mi := &file_proto_motorcycle_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +1061,7 @@ func (x *MapUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MapUpdateRequest.ProtoReflect.Descriptor instead.
 func (*MapUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_motorcycle_proto_rawDescGZIP(), []int{6}
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *MapUpdateRequest) GetMapType() string {
@@ -523,23 +1092,38 @@ func (x *MapUpdateRequest) GetValue() float64 {
 	return 0
 }
 
+func (x *MapUpdateRequest) GetAxis() float64 {
+	if x != nil {
+		return x.Axis
+	}
+	return 0
+}
+
 // ECU settings
 type ECUSettings struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FuelTrim         float64 `protobuf:"fixed64,1,opt,name=fuel_trim,json=fuelTrim,proto3" json:"fuel_trim,omitempty"`
-	IgnitionTrim     float64 `protobuf:"fixed64,2,opt,name=ignition_trim,json=ignitionTrim,proto3" json:"ignition_trim,omitempty"`
-	IdleRpm          float64 `protobuf:"fixed64,3,opt,name=idle_rpm,json=idleRpm,proto3" json:"idle_rpm,omitempty"`
-	RevLimit         float64 `protobuf:"fixed64,4,opt,name=rev_limit,json=revLimit,proto3" json:"rev_limit,omitempty"`
-	TempCompensation bool    `protobuf:"varint,5,opt,name=temp_compensation,json=tempCompensation,proto3" json:"temp_compensation,omitempty"`
+	FuelTrim         float64   `protobuf:"fixed64,1,opt,name=fuel_trim,json=fuelTrim,proto3" json:"fuel_trim,omitempty"`
+	IgnitionTrim     float64   `protobuf:"fixed64,2,opt,name=ignition_trim,json=ignitionTrim,proto3" json:"ignition_trim,omitempty"`
+	IdleRpm          float64   `protobuf:"fixed64,3,opt,name=idle_rpm,json=idleRpm,proto3" json:"idle_rpm,omitempty"`
+	RevLimit         float64   `protobuf:"fixed64,4,opt,name=rev_limit,json=revLimit,proto3" json:"rev_limit,omitempty"`
+	TempCompensation bool      `protobuf:"varint,5,opt,name=temp_compensation,json=tempCompensation,proto3" json:"temp_compensation,omitempty"`
+	LoadModel        string    `protobuf:"bytes,6,opt,name=load_model,json=loadModel,proto3" json:"load_model,omitempty"`                        // "alpha_n", "speed_density" or "blended"; empty keeps the current model
+	RevLimiter       string    `protobuf:"bytes,7,opt,name=rev_limiter,json=revLimiter,proto3" json:"rev_limiter,omitempty"`                     // "soft", "spark_cut", "fuel_cut" or "staged"; empty keeps the current strategy
+	GearRevLimits    []float64 `protobuf:"fixed64,8,rep,packed,name=gear_rev_limits,json=gearRevLimits,proto3" json:"gear_rev_limits,omitempty"` // Per gear from first, 0 uses rev_limit; empty keeps the current limits
+	LaunchControl    bool      `protobuf:"varint,9,opt,name=launch_control,json=launchControl,proto3" json:"launch_control,omitempty"`
+	LaunchRpm        float64   `protobuf:"fixed64,10,opt,name=launch_rpm,json=launchRpm,proto3" json:"launch_rpm,omitempty"`                   // 0 keeps the current launch limit
+	FuelMapAxis      string    `protobuf:"bytes,11,opt,name=fuel_map_axis,json=fuelMapAxis,proto3" json:"fuel_map_axis,omitempty"`             // "none", "gear" or "engine_temp"; empty keeps the current axis
+	IgnitionMapAxis  string    `protobuf:"bytes,12,opt,name=ignition_map_axis,json=ignitionMapAxis,proto3" json:"ignition_map_axis,omitempty"` // "none", "gear" or "engine_temp"; empty keeps the current axis
+	AfrMapAxis       string    `protobuf:"bytes,13,opt,name=afr_map_axis,json=afrMapAxis,proto3" json:"afr_map_axis,omitempty"`                // "none", "gear" or "engine_temp"; empty keeps the current axis
 }
 
 func (x *ECUSettings) Reset() {
 	*x = ECUSettings{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_motorcycle_proto_msgTypes[7]
+		mi := &file_proto_motorcycle_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -552,7 +1136,7 @@ func (x *ECUSettings) String() string {
 func (*ECUSettings) ProtoMessage() {}
 
 func (x *ECUSettings) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_motorcycle_proto_msgTypes[7]
+	mi := &file_proto_motorcycle_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,7 +1149,7 @@ func (x *ECUSettings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ECUSettings.ProtoReflect.Descriptor instead.
 func (*ECUSettings) Descriptor() ([]byte, []int) {
-	return file_proto_motorcycle_proto_rawDescGZIP(), []int{7}
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ECUSettings) GetFuelTrim() float64 {
@@ -603,33 +1187,91 @@ func (x *ECUSettings) GetTempCompensation() bool {
 	return false
 }
 
-// Status response for updates
-type UpdateStatus struct {
+func (x *ECUSettings) GetLoadModel() string {
+	if x != nil {
+		return x.LoadModel
+	}
+	return ""
+}
+
+func (x *ECUSettings) GetRevLimiter() string {
+	if x != nil {
+		return x.RevLimiter
+	}
+	return ""
+}
+
+func (x *ECUSettings) GetGearRevLimits() []float64 {
+	if x != nil {
+		return x.GearRevLimits
+	}
+	return nil
+}
+
+func (x *ECUSettings) GetLaunchControl() bool {
+	if x != nil {
+		return x.LaunchControl
+	}
+	return false
+}
+
+func (x *ECUSettings) GetLaunchRpm() float64 {
+	if x != nil {
+		return x.LaunchRpm
+	}
+	return 0
+}
+
+func (x *ECUSettings) GetFuelMapAxis() string {
+	if x != nil {
+		return x.FuelMapAxis
+	}
+	return ""
+}
+
+func (x *ECUSettings) GetIgnitionMapAxis() string {
+	if x != nil {
+		return x.IgnitionMapAxis
+	}
+	return ""
+}
+
+func (x *ECUSettings) GetAfrMapAxis() string {
+	if x != nil {
+		return x.AfrMapAxis
+	}
+	return ""
+}
+
+// Injectors to fit; 0 keeps the current injector's value
+type InjectorRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	FlowRate      float64 `protobuf:"fixed64,1,opt,name=flow_rate,json=flowRate,proto3" json:"flow_rate,omitempty"`                // cc/min at rated_pressure
+	RatedPressure float64 `protobuf:"fixed64,2,opt,name=rated_pressure,json=ratedPressure,proto3" json:"rated_pressure,omitempty"` // kPa
+	DeadTime      float64 `protobuf:"fixed64,3,opt,name=dead_time,json=deadTime,proto3" json:"dead_time,omitempty"`                // ms to open at 14 V
+	RescaleEcu    bool    `protobuf:"varint,4,opt,name=rescale_ecu,json=rescaleEcu,proto3" json:"rescale_ecu,omitempty"`           // Also switch the ECU's injector data and dead-time table
 }
 
-func (x *UpdateStatus) Reset() {
-	*x = UpdateStatus{}
+func (x *InjectorRequest) Reset() {
+	*x = InjectorRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_motorcycle_proto_msgTypes[8]
+		mi := &file_proto_motorcycle_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateStatus) String() string {
+func (x *InjectorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStatus) ProtoMessage() {}
+func (*InjectorRequest) ProtoMessage() {}
 
-func (x *UpdateStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_motorcycle_proto_msgTypes[8]
+func (x *InjectorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -640,23 +1282,1483 @@ func (x *UpdateStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStatus.ProtoReflect.Descriptor instead.
-func (*UpdateStatus) Descriptor() ([]byte, []int) {
-	return file_proto_motorcycle_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use InjectorRequest.ProtoReflect.Descriptor instead.
+func (*InjectorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UpdateStatus) GetSuccess() bool {
+func (x *InjectorRequest) GetFlowRate() float64 {
+	if x != nil {
+		return x.FlowRate
+	}
+	return 0
+}
+
+func (x *InjectorRequest) GetRatedPressure() float64 {
+	if x != nil {
+		return x.RatedPressure
+	}
+	return 0
+}
+
+func (x *InjectorRequest) GetDeadTime() float64 {
+	if x != nil {
+		return x.DeadTime
+	}
+	return 0
+}
+
+func (x *InjectorRequest) GetRescaleEcu() bool {
+	if x != nil {
+		return x.RescaleEcu
+	}
+	return false
+}
+
+// Request for the stored trouble codes
+type CodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CodesRequest) Reset() {
+	*x = CodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CodesRequest) ProtoMessage() {}
+
+func (x *CodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CodesRequest.ProtoReflect.Descriptor instead.
+func (*CodesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{11}
+}
+
+// Sensors when a trouble code was first set
+type FreezeFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp         int64   `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Rpm               float64 `protobuf:"fixed64,2,opt,name=rpm,proto3" json:"rpm,omitempty"`
+	ThrottlePosition  float64 `protobuf:"fixed64,3,opt,name=throttle_position,json=throttlePosition,proto3" json:"throttle_position,omitempty"`
+	EngineTemp        float64 `protobuf:"fixed64,4,opt,name=engine_temp,json=engineTemp,proto3" json:"engine_temp,omitempty"`
+	AirTemp           float64 `protobuf:"fixed64,5,opt,name=air_temp,json=airTemp,proto3" json:"air_temp,omitempty"`
+	ManifoldPressure  float64 `protobuf:"fixed64,6,opt,name=manifold_pressure,json=manifoldPressure,proto3" json:"manifold_pressure,omitempty"`
+	O2                float64 `protobuf:"fixed64,7,opt,name=o2,proto3" json:"o2,omitempty"` // Lambda
+	Baro              float64 `protobuf:"fixed64,8,opt,name=baro,proto3" json:"baro,omitempty"`
+	BatteryVoltage    float64 `protobuf:"fixed64,9,opt,name=battery_voltage,json=batteryVoltage,proto3" json:"battery_voltage,omitempty"`
+	Speed             float64 `protobuf:"fixed64,10,opt,name=speed,proto3" json:"speed,omitempty"`
+	Gear              int32   `protobuf:"varint,11,opt,name=gear,proto3" json:"gear,omitempty"`
+	EngineLoad        float64 `protobuf:"fixed64,12,opt,name=engine_load,json=engineLoad,proto3" json:"engine_load,omitempty"`
+	ShortTermFuelTrim float64 `protobuf:"fixed64,13,opt,name=short_term_fuel_trim,json=shortTermFuelTrim,proto3" json:"short_term_fuel_trim,omitempty"`
+	LongTermFuelTrim  float64 `protobuf:"fixed64,14,opt,name=long_term_fuel_trim,json=longTermFuelTrim,proto3" json:"long_term_fuel_trim,omitempty"`
+}
+
+func (x *FreezeFrame) Reset() {
+	*x = FreezeFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FreezeFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreezeFrame) ProtoMessage() {}
+
+func (x *FreezeFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreezeFrame.ProtoReflect.Descriptor instead.
+func (*FreezeFrame) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FreezeFrame) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetRpm() float64 {
+	if x != nil {
+		return x.Rpm
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetThrottlePosition() float64 {
+	if x != nil {
+		return x.ThrottlePosition
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetEngineTemp() float64 {
+	if x != nil {
+		return x.EngineTemp
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetAirTemp() float64 {
+	if x != nil {
+		return x.AirTemp
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetManifoldPressure() float64 {
+	if x != nil {
+		return x.ManifoldPressure
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetO2() float64 {
+	if x != nil {
+		return x.O2
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetBaro() float64 {
+	if x != nil {
+		return x.Baro
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetBatteryVoltage() float64 {
+	if x != nil {
+		return x.BatteryVoltage
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetGear() int32 {
+	if x != nil {
+		return x.Gear
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetEngineLoad() float64 {
+	if x != nil {
+		return x.EngineLoad
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetShortTermFuelTrim() float64 {
+	if x != nil {
+		return x.ShortTermFuelTrim
+	}
+	return 0
+}
+
+func (x *FreezeFrame) GetLongTermFuelTrim() float64 {
+	if x != nil {
+		return x.LongTermFuelTrim
+	}
+	return 0
+}
+
+// A stored trouble code
+type TroubleCode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code        string       `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // e.g. "P0122"
+	Description string       `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Status      string       `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`  // "pending" or "confirmed"
+	Active      bool         `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"` // Fault present now
+	Occurrences int32        `protobuf:"varint,5,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
+	FirstSeen   int64        `protobuf:"varint,6,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"` // Unix nanoseconds on the simulated clock
+	LastSeen    int64        `protobuf:"varint,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	FreezeFrame *FreezeFrame `protobuf:"bytes,8,opt,name=freeze_frame,json=freezeFrame,proto3" json:"freeze_frame,omitempty"`
+}
+
+func (x *TroubleCode) Reset() {
+	*x = TroubleCode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TroubleCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TroubleCode) ProtoMessage() {}
+
+func (x *TroubleCode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TroubleCode.ProtoReflect.Descriptor instead.
+func (*TroubleCode) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TroubleCode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TroubleCode) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TroubleCode) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TroubleCode) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *TroubleCode) GetOccurrences() int32 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return 0
+}
+
+func (x *TroubleCode) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *TroubleCode) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *TroubleCode) GetFreezeFrame() *FreezeFrame {
+	if x != nil {
+		return x.FreezeFrame
+	}
+	return nil
+}
+
+// All stored trouble codes
+type TroubleCodes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codes  []*TroubleCode `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	FiLamp bool           `protobuf:"varint,2,opt,name=fi_lamp,json=fiLamp,proto3" json:"fi_lamp,omitempty"`
+}
+
+func (x *TroubleCodes) Reset() {
+	*x = TroubleCodes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TroubleCodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TroubleCodes) ProtoMessage() {}
+
+func (x *TroubleCodes) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TroubleCodes.ProtoReflect.Descriptor instead.
+func (*TroubleCodes) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TroubleCodes) GetCodes() []*TroubleCode {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+func (x *TroubleCodes) GetFiLamp() bool {
+	if x != nil {
+		return x.FiLamp
+	}
+	return false
+}
+
+// Inject a sensor fault
+type FaultRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sensor   string  `protobuf:"bytes,1,opt,name=sensor,proto3" json:"sensor,omitempty"`       // "rpm", "tps", "iat", "ect", "map" or "o2"
+	Type     string  `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`           // "stuck", "offset", "gain", "noise", "dropout", "open" or "short"
+	Value    float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`       // Offset, gain, noise amplitude or dropout share (0-1)
+	Delay    float64 `protobuf:"fixed64,4,opt,name=delay,proto3" json:"delay,omitempty"`       // Seconds before the fault starts
+	Duration float64 `protobuf:"fixed64,5,opt,name=duration,proto3" json:"duration,omitempty"` // Seconds the fault lasts, 0 = until cleared
+}
+
+func (x *FaultRequest) Reset() {
+	*x = FaultRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultRequest) ProtoMessage() {}
+
+func (x *FaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultRequest.ProtoReflect.Descriptor instead.
+func (*FaultRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FaultRequest) GetSensor() string {
+	if x != nil {
+		return x.Sensor
+	}
+	return ""
+}
+
+func (x *FaultRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *FaultRequest) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *FaultRequest) GetDelay() float64 {
+	if x != nil {
+		return x.Delay
+	}
+	return 0
+}
+
+func (x *FaultRequest) GetDuration() float64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+// Remove injected sensor faults
+type ClearFaultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sensor string `protobuf:"bytes,1,opt,name=sensor,proto3" json:"sensor,omitempty"` // Sensor to clear, empty clears every sensor
+}
+
+func (x *ClearFaultsRequest) Reset() {
+	*x = ClearFaultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearFaultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearFaultsRequest) ProtoMessage() {}
+
+func (x *ClearFaultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearFaultsRequest.ProtoReflect.Descriptor instead.
+func (*ClearFaultsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ClearFaultsRequest) GetSensor() string {
+	if x != nil {
+		return x.Sensor
+	}
+	return ""
+}
+
+// Request to export a map or table as a file
+type MapExportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MapType string `protobuf:"bytes,1,opt,name=map_type,json=mapType,proto3" json:"map_type,omitempty"` // Any 2D map, 1D table or 3D map type
+	Format  string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`                  // "csv" or "json"; 3D maps are JSON only
+}
+
+func (x *MapExportRequest) Reset() {
+	*x = MapExportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapExportRequest) ProtoMessage() {}
+
+func (x *MapExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapExportRequest.ProtoReflect.Descriptor instead.
+func (*MapExportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MapExportRequest) GetMapType() string {
+	if x != nil {
+		return x.MapType
+	}
+	return ""
+}
+
+func (x *MapExportRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// A map or table as a file, exported or to import
+type MapFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MapType string `protobuf:"bytes,1,opt,name=map_type,json=mapType,proto3" json:"map_type,omitempty"`
+	Format  string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // "csv" or "json"
+	Data    string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`     // File contents; CSV has load across the first row and RPM down the first column
+}
+
+func (x *MapFile) Reset() {
+	*x = MapFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapFile) ProtoMessage() {}
+
+func (x *MapFile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapFile.ProtoReflect.Descriptor instead.
+func (*MapFile) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MapFile) GetMapType() string {
+	if x != nil {
+		return x.MapType
+	}
+	return ""
+}
+
+func (x *MapFile) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *MapFile) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+// Save the current calibration as a tune file
+type SaveTuneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // File name in the server's tune directory, also the tune's name; defaults to the loaded tune's
+	Author string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"` // Optional, defaults to the loaded tune's author
+	Notes  string `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`   // Optional, defaults to the loaded tune's notes
+}
+
+func (x *SaveTuneRequest) Reset() {
+	*x = SaveTuneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveTuneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTuneRequest) ProtoMessage() {}
+
+func (x *SaveTuneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTuneRequest.ProtoReflect.Descriptor instead.
+func (*SaveTuneRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SaveTuneRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SaveTuneRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *SaveTuneRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+// Load a tune file
+type LoadTuneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // File name in the server's tune directory
+}
+
+func (x *LoadTuneRequest) Reset() {
+	*x = LoadTuneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadTuneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadTuneRequest) ProtoMessage() {}
+
+func (x *LoadTuneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadTuneRequest.ProtoReflect.Descriptor instead.
+func (*LoadTuneRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LoadTuneRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// A saved or loaded tune
+type TuneStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path     string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Author   string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Notes    string `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
+	Revision int32  `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	Version  int32  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`  // Tune file format
+	Created  string `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`   // RFC 3339
+	Checksum string `protobuf:"bytes,8,opt,name=checksum,proto3" json:"checksum,omitempty"` // SHA-256, hex encoded
+}
+
+func (x *TuneStatus) Reset() {
+	*x = TuneStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuneStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuneStatus) ProtoMessage() {}
+
+func (x *TuneStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuneStatus.ProtoReflect.Descriptor instead.
+func (*TuneStatus) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TuneStatus) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TuneStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TuneStatus) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *TuneStatus) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *TuneStatus) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *TuneStatus) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TuneStatus) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *TuneStatus) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+// Status response for updates
+type UpdateStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *UpdateStatus) Reset() {
+	*x = UpdateStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStatus) ProtoMessage() {}
+
+func (x *UpdateStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStatus.ProtoReflect.Descriptor instead.
+func (*UpdateStatus) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateStatus) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Pause or resume the session clock
+type PauseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Paused bool `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+}
+
+func (x *PauseRequest) Reset() {
+	*x = PauseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRequest) ProtoMessage() {}
+
+func (x *PauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseRequest.ProtoReflect.Descriptor instead.
+func (*PauseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PauseRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+// Advance a paused session by a number of ticks
+type StepRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticks int32 `protobuf:"varint,1,opt,name=ticks,proto3" json:"ticks,omitempty"` // 1-5000
+}
+
+func (x *StepRequest) Reset() {
+	*x = StepRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepRequest) ProtoMessage() {}
+
+func (x *StepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepRequest.ProtoReflect.Descriptor instead.
+func (*StepRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StepRequest) GetTicks() int32 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+// Change the session clock; zero leaves a value unchanged
+type TimeControlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TimestepMs float64 `protobuf:"fixed64,1,opt,name=timestep_ms,json=timestepMs,proto3" json:"timestep_ms,omitempty"` // Simulated time per tick
+	TimeScale  float64 `protobuf:"fixed64,2,opt,name=time_scale,json=timeScale,proto3" json:"time_scale,omitempty"`    // Simulated seconds per real second
+}
+
+func (x *TimeControlRequest) Reset() {
+	*x = TimeControlRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeControlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeControlRequest) ProtoMessage() {}
+
+func (x *TimeControlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeControlRequest.ProtoReflect.Descriptor instead.
+func (*TimeControlRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TimeControlRequest) GetTimestepMs() float64 {
+	if x != nil {
+		return x.TimestepMs
+	}
+	return 0
+}
+
+func (x *TimeControlRequest) GetTimeScale() float64 {
+	if x != nil {
+		return x.TimeScale
+	}
+	return 0
+}
+
+// Current state of the session clock
+type TimeStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Paused     bool    `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	TimestepMs float64 `protobuf:"fixed64,2,opt,name=timestep_ms,json=timestepMs,proto3" json:"timestep_ms,omitempty"`
+	TimeScale  float64 `protobuf:"fixed64,3,opt,name=time_scale,json=timeScale,proto3" json:"time_scale,omitempty"`
+	Tick       int64   `protobuf:"varint,4,opt,name=tick,proto3" json:"tick,omitempty"`
+	SimTime    float64 `protobuf:"fixed64,5,opt,name=sim_time,json=simTime,proto3" json:"sim_time,omitempty"` // Seconds of simulated time
+	Replay     bool    `protobuf:"varint,6,opt,name=replay,proto3" json:"replay,omitempty"`                   // Session is playing back a recorded log
+	EndTick    int64   `protobuf:"varint,7,opt,name=end_tick,json=endTick,proto3" json:"end_tick,omitempty"`  // Last tick in the log, when replaying
+}
+
+func (x *TimeStatus) Reset() {
+	*x = TimeStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeStatus) ProtoMessage() {}
+
+func (x *TimeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeStatus.ProtoReflect.Descriptor instead.
+func (*TimeStatus) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TimeStatus) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *TimeStatus) GetTimestepMs() float64 {
+	if x != nil {
+		return x.TimestepMs
+	}
+	return 0
+}
+
+func (x *TimeStatus) GetTimeScale() float64 {
+	if x != nil {
+		return x.TimeScale
+	}
+	return 0
+}
+
+func (x *TimeStatus) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *TimeStatus) GetSimTime() float64 {
+	if x != nil {
+		return x.SimTime
+	}
+	return 0
+}
+
+func (x *TimeStatus) GetReplay() bool {
+	if x != nil {
+		return x.Replay
+	}
+	return false
+}
+
+func (x *TimeStatus) GetEndTick() int64 {
+	if x != nil {
+		return x.EndTick
+	}
+	return 0
+}
+
+// Save the session's input journal to a file on the server
+type JournalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Optional file name, created in the server's recording directory
+}
+
+func (x *JournalRequest) Reset() {
+	*x = JournalRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JournalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalRequest) ProtoMessage() {}
+
+func (x *JournalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalRequest.ProtoReflect.Descriptor instead.
+func (*JournalRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *JournalRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// A saved input journal
+type JournalStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path    string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Entries int32  `protobuf:"varint,2,opt,name=entries,proto3" json:"entries,omitempty"` // Inputs and ECU changes journaled
+	Ticks   int64  `protobuf:"varint,3,opt,name=ticks,proto3" json:"ticks,omitempty"`     // Ticks covered
+}
+
+func (x *JournalStatus) Reset() {
+	*x = JournalStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JournalStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalStatus) ProtoMessage() {}
+
+func (x *JournalStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalStatus.ProtoReflect.Descriptor instead.
+func (*JournalStatus) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *JournalStatus) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *JournalStatus) GetEntries() int32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *JournalStatus) GetTicks() int64 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+// Jump to a tick in a replayed log
+type SeekRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tick int64 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+}
+
+func (x *SeekRequest) Reset() {
+	*x = SeekRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeekRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeekRequest) ProtoMessage() {}
+
+func (x *SeekRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeekRequest.ProtoReflect.Descriptor instead.
+func (*SeekRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SeekRequest) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+// Start recording the session's telemetry to a file on the server
+type RecordingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // "csv", "jsonl" or "bin" (default "csv")
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`     // Optional file name, created in the server's recording directory
+}
+
+func (x *RecordingRequest) Reset() {
+	*x = RecordingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordingRequest) ProtoMessage() {}
+
+func (x *RecordingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordingRequest.ProtoReflect.Descriptor instead.
+func (*RecordingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RecordingRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *RecordingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Stop the current recording
+type StopRecordingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StopRecordingRequest) Reset() {
+	*x = StopRecordingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopRecordingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRecordingRequest) ProtoMessage() {}
+
+func (x *StopRecordingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRecordingRequest.ProtoReflect.Descriptor instead.
+func (*StopRecordingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{31}
+}
+
+// State of a session's recorder
+type RecordingStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Recording bool   `protobuf:"varint,1,opt,name=recording,proto3" json:"recording,omitempty"`
+	Path      string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Format    string `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	Frames    int64  `protobuf:"varint,4,opt,name=frames,proto3" json:"frames,omitempty"` // Ticks written so far
+}
+
+func (x *RecordingStatus) Reset() {
+	*x = RecordingStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_motorcycle_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordingStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordingStatus) ProtoMessage() {}
+
+func (x *RecordingStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_motorcycle_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordingStatus.ProtoReflect.Descriptor instead.
+func (*RecordingStatus) Descriptor() ([]byte, []int) {
+	return file_proto_motorcycle_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RecordingStatus) GetRecording() bool {
+	if x != nil {
+		return x.Recording
+	}
+	return false
+}
+
+func (x *RecordingStatus) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RecordingStatus) GetFormat() string {
 	if x != nil {
-		return x.Success
+		return x.Format
 	}
-	return false
+	return ""
 }
 
-func (x *UpdateStatus) GetMessage() string {
+func (x *RecordingStatus) GetFrames() int64 {
 	if x != nil {
-		return x.Message
+		return x.Frames
 	}
-	return ""
+	return 0
 }
 
 var File_proto_motorcycle_proto protoreflect.FileDescriptor
@@ -664,7 +2766,7 @@ var File_proto_motorcycle_proto protoreflect.FileDescriptor
 var file_proto_motorcycle_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63,
 	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63,
-	0x79, 0x63, 0x6c, 0x65, 0x22, 0xa2, 0x03, 0x0a, 0x0a, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x44,
+	0x79, 0x63, 0x6c, 0x65, 0x22, 0xac, 0x0f, 0x0a, 0x0a, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x44,
 	0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x70, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
 	0x52, 0x03, 0x72, 0x70, 0x6d, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c,
 	0x65, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
@@ -690,44 +2792,206 @@ var file_proto_motorcycle_proto_rawDesc = []byte{
 	0x65, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64,
 	0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6c, 0x75, 0x74, 0x63, 0x68, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74,
 	0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x63, 0x6c, 0x75, 0x74, 0x63,
-	0x68, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x75, 0x0a, 0x09, 0x55, 0x73, 0x65,
-	0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74,
-	0x6c, 0x65, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x10, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6c, 0x75, 0x74, 0x63, 0x68, 0x5f, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x63, 0x6c,
-	0x75, 0x74, 0x63, 0x68, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
-	0x67, 0x65, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x67, 0x65, 0x61, 0x72,
-	0x22, 0x20, 0x0a, 0x06, 0x4d, 0x61, 0x70, 0x52, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x73, 0x22, 0x9b, 0x01, 0x0a, 0x05, 0x4d, 0x61, 0x70, 0x32, 0x44, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x12, 0x27, 0x0a, 0x0f, 0x72, 0x70, 0x6d, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0e, 0x72, 0x70, 0x6d, 0x42, 0x72,
-	0x65, 0x61, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x6f, 0x61,
-	0x64, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x01, 0x52, 0x0f, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c,
-	0x65, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x6f, 0x77, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
-	0x22, 0x99, 0x01, 0x0a, 0x07, 0x45, 0x43, 0x55, 0x4d, 0x61, 0x70, 0x73, 0x12, 0x2c, 0x0a, 0x08,
-	0x66, 0x75, 0x65, 0x6c, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x32,
-	0x44, 0x52, 0x07, 0x66, 0x75, 0x65, 0x6c, 0x4d, 0x61, 0x70, 0x12, 0x34, 0x0a, 0x0c, 0x69, 0x67,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x68, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x63,
+	0x6b, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23,
+	0x0a, 0x0d, 0x61, 0x66, 0x72, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x61, 0x66, 0x72, 0x44, 0x65, 0x76, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x6c, 0x6f,
+	0x6f, 0x70, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64,
+	0x4c, 0x6f, 0x6f, 0x70, 0x12, 0x2f, 0x0a, 0x14, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x74, 0x65,
+	0x72, 0x6d, 0x5f, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x74, 0x72, 0x69, 0x6d, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x11, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x46, 0x75, 0x65,
+	0x6c, 0x54, 0x72, 0x69, 0x6d, 0x12, 0x2d, 0x0a, 0x13, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x74, 0x65,
+	0x72, 0x6d, 0x5f, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x74, 0x72, 0x69, 0x6d, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x10, 0x6c, 0x6f, 0x6e, 0x67, 0x54, 0x65, 0x72, 0x6d, 0x46, 0x75, 0x65, 0x6c,
+	0x54, 0x72, 0x69, 0x6d, 0x12, 0x27, 0x0a, 0x0f, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x14, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x6b,
+	0x6e, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x6b, 0x6e, 0x6f,
+	0x63, 0x6b, 0x5f, 0x72, 0x65, 0x74, 0x61, 0x72, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0b, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x74, 0x61, 0x72, 0x64, 0x12, 0x30, 0x0a, 0x14,
+	0x6c, 0x65, 0x61, 0x72, 0x6e, 0x65, 0x64, 0x5f, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x65,
+	0x74, 0x61, 0x72, 0x64, 0x18, 0x17, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x6c, 0x65, 0x61, 0x72,
+	0x6e, 0x65, 0x64, 0x4b, 0x6e, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x74, 0x61, 0x72, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x4c, 0x6f, 0x61, 0x64, 0x12,
+	0x2b, 0x0a, 0x11, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x75, 0x72, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6d, 0x61, 0x6e, 0x69,
+	0x66, 0x6f, 0x6c, 0x64, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x61, 0x69, 0x72, 0x5f, 0x6d, 0x61, 0x73, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
+	0x61, 0x69, 0x72, 0x4d, 0x61, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x69, 0x72, 0x5f, 0x66,
+	0x6c, 0x6f, 0x77, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x69, 0x72, 0x46, 0x6c,
+	0x6f, 0x77, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x63, 0x75, 0x74, 0x18, 0x1c,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x66, 0x75, 0x65, 0x6c, 0x43, 0x75, 0x74, 0x12, 0x29, 0x0a,
+	0x10, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x5f, 0x65, 0x6e, 0x72, 0x69, 0x63, 0x68, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x45, 0x6e,
+	0x72, 0x69, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x65, 0x6c,
+	0x5f, 0x66, 0x69, 0x6c, 0x6d, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x75, 0x65,
+	0x6c, 0x46, 0x69, 0x6c, 0x6d, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x76, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x1f, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x10, 0x72, 0x65, 0x76, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x20, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x72, 0x65, 0x76, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x18, 0x21, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x65, 0x72, 0x5f, 0x72, 0x65, 0x74, 0x61, 0x72, 0x64, 0x18, 0x22, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0d, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x65, 0x74, 0x61, 0x72, 0x64, 0x12, 0x2a,
+	0x0a, 0x11, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x70, 0x61, 0x72, 0x6b, 0x5f,
+	0x63, 0x75, 0x74, 0x18, 0x23, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x65, 0x72, 0x53, 0x70, 0x61, 0x72, 0x6b, 0x43, 0x75, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x63, 0x75, 0x74, 0x18, 0x24,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x46, 0x75, 0x65,
+	0x6c, 0x43, 0x75, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x5f, 0x65,
+	0x6e, 0x72, 0x69, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x25, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x10, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x45, 0x6e, 0x72, 0x69, 0x63, 0x68, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x34, 0x0a, 0x16, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x65, 0x6e, 0x72, 0x69, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x26, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x14, 0x61, 0x66, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74, 0x45, 0x6e, 0x72,
+	0x69, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x64, 0x5f,
+	0x69, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x74, 0x61, 0x72, 0x64, 0x18,
+	0x27, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x63, 0x6f, 0x6c, 0x64, 0x49, 0x67, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x74, 0x61, 0x72, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x64, 0x6c,
+	0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x28, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x69, 0x64, 0x6c, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x18, 0x29, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0e, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x56, 0x6f, 0x6c, 0x74,
+	0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x72, 0x6f, 0x18, 0x2a, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x62, 0x61, 0x72, 0x6f, 0x12, 0x2e, 0x0a, 0x13, 0x69, 0x61, 0x74, 0x5f, 0x66,
+	0x75, 0x65, 0x6c, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x2b,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x69, 0x61, 0x74, 0x46, 0x75, 0x65, 0x6c, 0x43, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x14, 0x62, 0x61, 0x72, 0x6f, 0x5f,
+	0x66, 0x75, 0x65, 0x6c, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x2c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x62, 0x61, 0x72, 0x6f, 0x46, 0x75, 0x65, 0x6c, 0x43,
+	0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x17, 0x69, 0x61, 0x74,
+	0x5f, 0x69, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x69, 0x61, 0x74, 0x49,
+	0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x65,
+	0x61, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x69,
+	0x6e, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x6d, 0x61, 0x73, 0x73, 0x18, 0x2f, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x08, 0x66, 0x75, 0x65, 0x6c, 0x4d, 0x61, 0x73, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x75, 0x74, 0x79, 0x18, 0x30, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0c, 0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x75, 0x74,
+	0x79, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x69, 0x5f, 0x6c, 0x61, 0x6d, 0x70, 0x18, 0x31, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x66, 0x69, 0x4c, 0x61, 0x6d, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x74,
+	0x63, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x32, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64,
+	0x74, 0x63, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x6e, 0x73, 0x6f,
+	0x72, 0x5f, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x33, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x12,
+	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x34, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61,
+	0x69, 0x6c, 0x73, 0x61, 0x66, 0x65, 0x18, 0x35, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x61,
+	0x69, 0x6c, 0x73, 0x61, 0x66, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x69, 0x6d, 0x70, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x36, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6c, 0x69, 0x6d, 0x70, 0x4d,
+	0x6f, 0x64, 0x65, 0x22, 0x75, 0x0a, 0x09, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x12, 0x2b, 0x0a, 0x11, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x5f, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x74, 0x68, 0x72,
+	0x6f, 0x74, 0x74, 0x6c, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x6c, 0x75, 0x74, 0x63, 0x68, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x63, 0x6c, 0x75, 0x74, 0x63, 0x68, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x65, 0x61, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x67, 0x65, 0x61, 0x72, 0x22, 0x20, 0x0a, 0x06, 0x4d, 0x61,
+	0x70, 0x52, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x9b, 0x01, 0x0a,
+	0x05, 0x4d, 0x61, 0x70, 0x32, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x70,
+	0x6d, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x01, 0x52, 0x0e, 0x72, 0x70, 0x6d, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x62, 0x72, 0x65, 0x61,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x6c,
+	0x6f, 0x61, 0x64, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2a,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x52,
+	0x6f, 0x77, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x7c, 0x0a, 0x05, 0x4d, 0x61,
+	0x70, 0x33, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x78, 0x69, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x78, 0x69, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x62,
+	0x72, 0x65, 0x61, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x0b, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a,
+	0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x32, 0x44,
+	0x52, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22, 0x55, 0x0a, 0x05, 0x4d, 0x61, 0x70, 0x31,
+	0x44, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0b, 0x62, 0x72, 0x65, 0x61,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22,
+	0xc6, 0x07, 0x0a, 0x07, 0x45, 0x43, 0x55, 0x4d, 0x61, 0x70, 0x73, 0x12, 0x2c, 0x0a, 0x08, 0x66,
+	0x75, 0x65, 0x6c, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x32, 0x44,
+	0x52, 0x07, 0x66, 0x75, 0x65, 0x6c, 0x4d, 0x61, 0x70, 0x12, 0x34, 0x0a, 0x0c, 0x69, 0x67, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70,
+	0x32, 0x44, 0x52, 0x0b, 0x69, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x70, 0x12,
+	0x2a, 0x0a, 0x07, 0x61, 0x66, 0x72, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61,
+	0x70, 0x32, 0x44, 0x52, 0x06, 0x61, 0x66, 0x72, 0x4d, 0x61, 0x70, 0x12, 0x40, 0x0a, 0x13, 0x6c,
+	0x6f, 0x6e, 0x67, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x5f, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x74, 0x72,
+	0x69, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72,
+	0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x32, 0x44, 0x52, 0x10, 0x6c, 0x6f, 0x6e,
+	0x67, 0x54, 0x65, 0x72, 0x6d, 0x46, 0x75, 0x65, 0x6c, 0x54, 0x72, 0x69, 0x6d, 0x12, 0x34, 0x0a,
+	0x0c, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x74, 0x61, 0x72, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65,
+	0x2e, 0x4d, 0x61, 0x70, 0x32, 0x44, 0x52, 0x0b, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x74,
+	0x61, 0x72, 0x64, 0x12, 0x3e, 0x0a, 0x11, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x5f, 0x65, 0x6e,
+	0x72, 0x69, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x31,
+	0x44, 0x52, 0x10, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x45, 0x6e, 0x72, 0x69, 0x63, 0x68, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x47, 0x0a, 0x16, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x65, 0x6e, 0x72, 0x69, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65,
+	0x2e, 0x4d, 0x61, 0x70, 0x31, 0x44, 0x52, 0x14, 0x61, 0x66, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x45, 0x6e, 0x72, 0x69, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x43, 0x0a, 0x14,
+	0x63, 0x6f, 0x6c, 0x64, 0x5f, 0x69, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65,
+	0x74, 0x61, 0x72, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74,
+	0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x31, 0x44, 0x52, 0x12, 0x63,
+	0x6f, 0x6c, 0x64, 0x49, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x74, 0x61, 0x72,
+	0x64, 0x12, 0x35, 0x0a, 0x0d, 0x63, 0x6f, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x72,
+	0x70, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72,
+	0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x31, 0x44, 0x52, 0x0b, 0x63, 0x6f, 0x6c,
+	0x64, 0x49, 0x64, 0x6c, 0x65, 0x52, 0x70, 0x6d, 0x12, 0x41, 0x0a, 0x13, 0x69, 0x61, 0x74, 0x5f,
+	0x66, 0x75, 0x65, 0x6c, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63,
+	0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x31, 0x44, 0x52, 0x11, 0x69, 0x61, 0x74, 0x46, 0x75, 0x65,
+	0x6c, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x17, 0x69,
+	0x61, 0x74, 0x5f, 0x69, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x72, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d,
+	0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x31, 0x44, 0x52,
+	0x15, 0x69, 0x61, 0x74, 0x49, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x72, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x43, 0x0a, 0x14, 0x62, 0x61, 0x72, 0x6f, 0x5f, 0x66,
+	0x75, 0x65, 0x6c, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x2e, 0x4d, 0x61, 0x70, 0x31, 0x44, 0x52, 0x12, 0x62, 0x61, 0x72, 0x6f, 0x46, 0x75, 0x65,
+	0x6c, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x12, 0x69,
+	0x6e, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x31, 0x44, 0x52, 0x10, 0x69, 0x6e, 0x6a, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x44, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x0a,
+	0x66, 0x75, 0x65, 0x6c, 0x5f, 0x6d, 0x61, 0x70, 0x33, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61,
-	0x70, 0x32, 0x44, 0x52, 0x0b, 0x69, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x70,
-	0x12, 0x2a, 0x0a, 0x07, 0x61, 0x66, 0x72, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d,
-	0x61, 0x70, 0x32, 0x44, 0x52, 0x06, 0x61, 0x66, 0x72, 0x4d, 0x61, 0x70, 0x22, 0x0d, 0x0a, 0x0b,
-	0x4d, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x69, 0x0a, 0x10, 0x4d,
-	0x61, 0x70, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x19, 0x0a, 0x08, 0x6d, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x6d, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x70,
-	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x72, 0x70, 0x6d, 0x12, 0x12, 0x0a, 0x04,
-	0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xb4, 0x01, 0x0a, 0x0b, 0x45, 0x43, 0x55, 0x53, 0x65,
+	0x70, 0x33, 0x44, 0x52, 0x09, 0x66, 0x75, 0x65, 0x6c, 0x4d, 0x61, 0x70, 0x33, 0x64, 0x12, 0x38,
+	0x0a, 0x0e, 0x69, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x61, 0x70, 0x33, 0x64,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79,
+	0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x33, 0x44, 0x52, 0x0d, 0x69, 0x67, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x70, 0x33, 0x64, 0x12, 0x2e, 0x0a, 0x09, 0x61, 0x66, 0x72, 0x5f,
+	0x6d, 0x61, 0x70, 0x33, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f,
+	0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x33, 0x44, 0x52, 0x08,
+	0x61, 0x66, 0x72, 0x4d, 0x61, 0x70, 0x33, 0x64, 0x22, 0x0d, 0x0a, 0x0b, 0x4d, 0x61, 0x70, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x7d, 0x0a, 0x10, 0x4d, 0x61, 0x70, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d,
+	0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x70, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x03, 0x72, 0x70, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x78, 0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x04, 0x61, 0x78, 0x69, 0x73, 0x22, 0xd4, 0x03, 0x0a, 0x0b, 0x45, 0x43, 0x55, 0x53, 0x65,
 	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x74,
 	0x72, 0x69, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x75, 0x65, 0x6c, 0x54,
 	0x72, 0x69, 0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
@@ -738,34 +3002,270 @@ var file_proto_motorcycle_proto_rawDesc = []byte{
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x72, 0x65, 0x76, 0x4c, 0x69, 0x6d, 0x69, 0x74,
 	0x12, 0x2b, 0x0a, 0x11, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x6e, 0x73,
 	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x74, 0x65, 0x6d,
-	0x70, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x6e, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x42, 0x0a,
-	0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x32, 0xa9, 0x02, 0x0a, 0x13, 0x4d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65,
-	0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x43, 0x0a, 0x0c, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x15, 0x2e, 0x6d, 0x6f, 0x74, 0x6f,
-	0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74,
-	0x1a, 0x16, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x45, 0x6e,
-	0x67, 0x69, 0x6e, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3c,
-	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x45, 0x43, 0x55, 0x4d, 0x61, 0x70, 0x73, 0x12, 0x17, 0x2e, 0x6d,
-	0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63,
-	0x6c, 0x65, 0x2e, 0x45, 0x43, 0x55, 0x4d, 0x61, 0x70, 0x73, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x43, 0x55, 0x4d, 0x61, 0x70, 0x12, 0x1c, 0x2e, 0x6d,
-	0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x6f, 0x74,
-	0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x45, 0x43, 0x55,
-	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x17, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72,
-	0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x45, 0x43, 0x55, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x73, 0x1a, 0x18, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x42, 0x2b, 0x5a,
-	0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x6f, 0x75, 0x72,
-	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x6e, 0x69, 0x6e, 0x6a, 0x61, 0x36, 0x35,
-	0x30, 0x73, 0x69, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x70, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x6e, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1f, 0x0a, 0x0b,
+	0x72, 0x65, 0x76, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x72, 0x65, 0x76, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a,
+	0x0f, 0x67, 0x65, 0x61, 0x72, 0x5f, 0x72, 0x65, 0x76, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0d, 0x67, 0x65, 0x61, 0x72, 0x52, 0x65, 0x76, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x5f,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6c,
+	0x61, 0x75, 0x6e, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x5f, 0x72, 0x70, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x52, 0x70, 0x6d, 0x12, 0x22, 0x0a, 0x0d, 0x66,
+	0x75, 0x65, 0x6c, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x61, 0x78, 0x69, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x66, 0x75, 0x65, 0x6c, 0x4d, 0x61, 0x70, 0x41, 0x78, 0x69, 0x73, 0x12,
+	0x2a, 0x0a, 0x11, 0x69, 0x67, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x61, 0x70, 0x5f,
+	0x61, 0x78, 0x69, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x67, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x70, 0x41, 0x78, 0x69, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x61,
+	0x66, 0x72, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x61, 0x78, 0x69, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x61, 0x66, 0x72, 0x4d, 0x61, 0x70, 0x41, 0x78, 0x69, 0x73, 0x22, 0x93, 0x01,
+	0x0a, 0x0f, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x6c, 0x6f, 0x77, 0x52, 0x61, 0x74, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x72, 0x61, 0x74, 0x65, 0x64, 0x50, 0x72, 0x65,
+	0x73, 0x73, 0x75, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f, 0x65, 0x63,
+	0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x63, 0x61, 0x6c, 0x65,
+	0x45, 0x63, 0x75, 0x22, 0x0e, 0x0a, 0x0c, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xcb, 0x03, 0x0a, 0x0b, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x70, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03,
+	0x72, 0x70, 0x6d, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x5f,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10,
+	0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x54, 0x65, 0x6d,
+	0x70, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x69, 0x72, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x69, 0x72, 0x54, 0x65, 0x6d, 0x70, 0x12, 0x2b, 0x0a, 0x11,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x6f, 0x6c,
+	0x64, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x32, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x02, 0x6f, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x72,
+	0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x62, 0x61, 0x72, 0x6f, 0x12, 0x27, 0x0a,
+	0x0f, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x56,
+	0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x67, 0x65, 0x61, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x67, 0x65, 0x61, 0x72,
+	0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x4c, 0x6f, 0x61,
+	0x64, 0x12, 0x2f, 0x0a, 0x14, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x5f,
+	0x66, 0x75, 0x65, 0x6c, 0x5f, 0x74, 0x72, 0x69, 0x6d, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x11, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x54, 0x65, 0x72, 0x6d, 0x46, 0x75, 0x65, 0x6c, 0x54, 0x72,
+	0x69, 0x6d, 0x12, 0x2d, 0x0a, 0x13, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x5f,
+	0x66, 0x75, 0x65, 0x6c, 0x5f, 0x74, 0x72, 0x69, 0x6d, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x10, 0x6c, 0x6f, 0x6e, 0x67, 0x54, 0x65, 0x72, 0x6d, 0x46, 0x75, 0x65, 0x6c, 0x54, 0x72, 0x69,
+	0x6d, 0x22, 0x8d, 0x02, 0x0a, 0x0b, 0x54, 0x72, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6f, 0x63,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x61, 0x73,
+	0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x3a, 0x0a, 0x0c, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x5f,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x6f,
+	0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x52, 0x0b, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x22, 0x56, 0x0a, 0x0c, 0x54, 0x72, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x64, 0x65,
+	0x73, 0x12, 0x2d, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x54, 0x72,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x17, 0x0a, 0x07, 0x66, 0x69, 0x5f, 0x6c, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x66, 0x69, 0x4c, 0x61, 0x6d, 0x70, 0x22, 0x82, 0x01, 0x0a, 0x0c, 0x46, 0x61,
+	0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x64, 0x65, 0x6c,
+	0x61, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2c,
+	0x0a, 0x12, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x22, 0x45, 0x0a, 0x10,
+	0x4d, 0x61, 0x70, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x22, 0x50, 0x0a, 0x07, 0x4d, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x6d, 0x61, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x61, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x53, 0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65, 0x54, 0x75, 0x6e,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x22, 0x25, 0x0a, 0x0f, 0x4c, 0x6f,
+	0x61, 0x64, 0x54, 0x75, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0xce, 0x01, 0x0a, 0x0a, 0x54, 0x75, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x22, 0x42, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x26, 0x0a, 0x0c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x22, 0x23,
+	0x0a, 0x0b, 0x53, 0x74, 0x65, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x69,
+	0x63, 0x6b, 0x73, 0x22, 0x54, 0x0a, 0x12, 0x54, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x65, 0x70, 0x4d, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x22, 0xc6, 0x01, 0x0a, 0x0a, 0x54, 0x69,
+	0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x65, 0x70, 0x4d,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x63, 0x61, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x74, 0x69, 0x63, 0x6b, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x69, 0x6d, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x73, 0x69, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74,
+	0x69, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x63, 0x6b, 0x22, 0x24, 0x0a, 0x0e, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x53, 0x0a, 0x0d, 0x4a, 0x6f, 0x75, 0x72,
+	0x6e, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x22, 0x21, 0x0a,
+	0x0b, 0x53, 0x65, 0x65, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x63, 0x6b,
+	0x22, 0x3e, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x16, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x73, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x32, 0x8a, 0x0b,
+	0x0a, 0x13, 0x4d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x43, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x15, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63,
+	0x6c, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x16, 0x2e, 0x6d,
+	0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0a, 0x47, 0x65,
+	0x74, 0x45, 0x43, 0x55, 0x4d, 0x61, 0x70, 0x73, 0x12, 0x17, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72,
+	0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x13, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x45,
+	0x43, 0x55, 0x4d, 0x61, 0x70, 0x73, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x45, 0x43, 0x55, 0x4d, 0x61, 0x70, 0x12, 0x1c, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72,
+	0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79,
+	0x63, 0x6c, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x22, 0x00, 0x12, 0x45, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x45, 0x43, 0x55, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x17, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x2e, 0x45, 0x43, 0x55, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x1a, 0x18, 0x2e,
+	0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0b, 0x53, 0x65, 0x74,
+	0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72,
+	0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63,
+	0x6c, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
+	0x00, 0x12, 0x41, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18,
+	0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72,
+	0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x54, 0x72, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x64,
+	0x65, 0x73, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x18, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e,
+	0x43, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d,
+	0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0b, 0x49, 0x6e, 0x6a, 0x65,
+	0x63, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x49, 0x0a,
+	0x0b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x6d,
+	0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x46,
+	0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d,
+	0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x4d, 0x61, 0x70, 0x12, 0x1c, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63,
+	0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65,
+	0x2e, 0x4d, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x09, 0x49, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x70, 0x12, 0x13, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4d, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x65, 0x1a, 0x18, 0x2e, 0x6d,
+	0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x08, 0x53, 0x61, 0x76, 0x65,
+	0x54, 0x75, 0x6e, 0x65, 0x12, 0x1b, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x54, 0x75, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x54,
+	0x75, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x08, 0x4c,
+	0x6f, 0x61, 0x64, 0x54, 0x75, 0x6e, 0x65, 0x12, 0x1b, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x75, 0x6e, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x2e, 0x54, 0x75, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x3f,
+	0x0a, 0x09, 0x53, 0x65, 0x74, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x18, 0x2e, 0x6d, 0x6f,
+	0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63,
+	0x6c, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12,
+	0x43, 0x0a, 0x0e, 0x53, 0x74, 0x65, 0x70, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x17, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x53,
+	0x74, 0x65, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x6f, 0x74,
+	0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x1e, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79,
+	0x63, 0x6c, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79,
+	0x63, 0x6c, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00,
+	0x12, 0x3f, 0x0a, 0x0a, 0x53, 0x65, 0x65, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x17,
+	0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x53, 0x65, 0x65, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
+	0x00, 0x12, 0x4d, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x1c, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00,
+	0x12, 0x50, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x20, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x53,
+	0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x22, 0x00, 0x12, 0x46, 0x0a, 0x0b, 0x53, 0x61, 0x76, 0x65, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61,
+	0x6c, 0x12, 0x1a, 0x2e, 0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4a,
+	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x6d, 0x6f, 0x74, 0x6f, 0x72, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x4a, 0x6f, 0x75, 0x72, 0x6e,
+	0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x74, 0x65, 0x76, 0x65, 0x6e, 0x44,
+	0x32, 0x30, 0x30, 0x32, 0x2f, 0x6e, 0x69, 0x6e, 0x6a, 0x61, 0x36, 0x35, 0x30, 0x73, 0x69, 0x6d,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -780,36 +3280,108 @@ func file_proto_motorcycle_proto_rawDescGZIP() []byte {
 	return file_proto_motorcycle_proto_rawDescData
 }
 
-var file_proto_motorcycle_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_motorcycle_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_proto_motorcycle_proto_goTypes = []interface{}{
-	(*EngineData)(nil),       // 0: motorcycle.EngineData
-	(*UserInput)(nil),        // 1: motorcycle.UserInput
-	(*MapRow)(nil),           // 2: motorcycle.MapRow
-	(*Map2D)(nil),            // 3: motorcycle.Map2D
-	(*ECUMaps)(nil),          // 4: motorcycle.ECUMaps
-	(*MapsRequest)(nil),      // 5: motorcycle.MapsRequest
-	(*MapUpdateRequest)(nil), // 6: motorcycle.MapUpdateRequest
-	(*ECUSettings)(nil),      // 7: motorcycle.ECUSettings
-	(*UpdateStatus)(nil),     // 8: motorcycle.UpdateStatus
+	(*EngineData)(nil),           // 0: motorcycle.EngineData
+	(*UserInput)(nil),            // 1: motorcycle.UserInput
+	(*MapRow)(nil),               // 2: motorcycle.MapRow
+	(*Map2D)(nil),                // 3: motorcycle.Map2D
+	(*Map3D)(nil),                // 4: motorcycle.Map3D
+	(*Map1D)(nil),                // 5: motorcycle.Map1D
+	(*ECUMaps)(nil),              // 6: motorcycle.ECUMaps
+	(*MapsRequest)(nil),          // 7: motorcycle.MapsRequest
+	(*MapUpdateRequest)(nil),     // 8: motorcycle.MapUpdateRequest
+	(*ECUSettings)(nil),          // 9: motorcycle.ECUSettings
+	(*InjectorRequest)(nil),      // 10: motorcycle.InjectorRequest
+	(*CodesRequest)(nil),         // 11: motorcycle.CodesRequest
+	(*FreezeFrame)(nil),          // 12: motorcycle.FreezeFrame
+	(*TroubleCode)(nil),          // 13: motorcycle.TroubleCode
+	(*TroubleCodes)(nil),         // 14: motorcycle.TroubleCodes
+	(*FaultRequest)(nil),         // 15: motorcycle.FaultRequest
+	(*ClearFaultsRequest)(nil),   // 16: motorcycle.ClearFaultsRequest
+	(*MapExportRequest)(nil),     // 17: motorcycle.MapExportRequest
+	(*MapFile)(nil),              // 18: motorcycle.MapFile
+	(*SaveTuneRequest)(nil),      // 19: motorcycle.SaveTuneRequest
+	(*LoadTuneRequest)(nil),      // 20: motorcycle.LoadTuneRequest
+	(*TuneStatus)(nil),           // 21: motorcycle.TuneStatus
+	(*UpdateStatus)(nil),         // 22: motorcycle.UpdateStatus
+	(*PauseRequest)(nil),         // 23: motorcycle.PauseRequest
+	(*StepRequest)(nil),          // 24: motorcycle.StepRequest
+	(*TimeControlRequest)(nil),   // 25: motorcycle.TimeControlRequest
+	(*TimeStatus)(nil),           // 26: motorcycle.TimeStatus
+	(*JournalRequest)(nil),       // 27: motorcycle.JournalRequest
+	(*JournalStatus)(nil),        // 28: motorcycle.JournalStatus
+	(*SeekRequest)(nil),          // 29: motorcycle.SeekRequest
+	(*RecordingRequest)(nil),     // 30: motorcycle.RecordingRequest
+	(*StopRecordingRequest)(nil), // 31: motorcycle.StopRecordingRequest
+	(*RecordingStatus)(nil),      // 32: motorcycle.RecordingStatus
 }
 var file_proto_motorcycle_proto_depIdxs = []int32{
-	2, // 0: motorcycle.Map2D.values:type_name -> motorcycle.MapRow
-	3, // 1: motorcycle.ECUMaps.fuel_map:type_name -> motorcycle.Map2D
-	3, // 2: motorcycle.ECUMaps.ignition_map:type_name -> motorcycle.Map2D
-	3, // 3: motorcycle.ECUMaps.afr_map:type_name -> motorcycle.Map2D
-	1, // 4: motorcycle.MotorcycleSimulator.StreamEngine:input_type -> motorcycle.UserInput
-	5, // 5: motorcycle.MotorcycleSimulator.GetECUMaps:input_type -> motorcycle.MapsRequest
-	6, // 6: motorcycle.MotorcycleSimulator.UpdateECUMap:input_type -> motorcycle.MapUpdateRequest
-	7, // 7: motorcycle.MotorcycleSimulator.SetECUSettings:input_type -> motorcycle.ECUSettings
-	0, // 8: motorcycle.MotorcycleSimulator.StreamEngine:output_type -> motorcycle.EngineData
-	4, // 9: motorcycle.MotorcycleSimulator.GetECUMaps:output_type -> motorcycle.ECUMaps
-	8, // 10: motorcycle.MotorcycleSimulator.UpdateECUMap:output_type -> motorcycle.UpdateStatus
-	8, // 11: motorcycle.MotorcycleSimulator.SetECUSettings:output_type -> motorcycle.UpdateStatus
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	2,  // 0: motorcycle.Map2D.values:type_name -> motorcycle.MapRow
+	3,  // 1: motorcycle.Map3D.layers:type_name -> motorcycle.Map2D
+	3,  // 2: motorcycle.ECUMaps.fuel_map:type_name -> motorcycle.Map2D
+	3,  // 3: motorcycle.ECUMaps.ignition_map:type_name -> motorcycle.Map2D
+	3,  // 4: motorcycle.ECUMaps.afr_map:type_name -> motorcycle.Map2D
+	3,  // 5: motorcycle.ECUMaps.long_term_fuel_trim:type_name -> motorcycle.Map2D
+	3,  // 6: motorcycle.ECUMaps.knock_retard:type_name -> motorcycle.Map2D
+	5,  // 7: motorcycle.ECUMaps.warmup_enrichment:type_name -> motorcycle.Map1D
+	5,  // 8: motorcycle.ECUMaps.after_start_enrichment:type_name -> motorcycle.Map1D
+	5,  // 9: motorcycle.ECUMaps.cold_ignition_retard:type_name -> motorcycle.Map1D
+	5,  // 10: motorcycle.ECUMaps.cold_idle_rpm:type_name -> motorcycle.Map1D
+	5,  // 11: motorcycle.ECUMaps.iat_fuel_correction:type_name -> motorcycle.Map1D
+	5,  // 12: motorcycle.ECUMaps.iat_ignition_correction:type_name -> motorcycle.Map1D
+	5,  // 13: motorcycle.ECUMaps.baro_fuel_correction:type_name -> motorcycle.Map1D
+	5,  // 14: motorcycle.ECUMaps.injector_dead_time:type_name -> motorcycle.Map1D
+	4,  // 15: motorcycle.ECUMaps.fuel_map3d:type_name -> motorcycle.Map3D
+	4,  // 16: motorcycle.ECUMaps.ignition_map3d:type_name -> motorcycle.Map3D
+	4,  // 17: motorcycle.ECUMaps.afr_map3d:type_name -> motorcycle.Map3D
+	12, // 18: motorcycle.TroubleCode.freeze_frame:type_name -> motorcycle.FreezeFrame
+	13, // 19: motorcycle.TroubleCodes.codes:type_name -> motorcycle.TroubleCode
+	1,  // 20: motorcycle.MotorcycleSimulator.StreamEngine:input_type -> motorcycle.UserInput
+	7,  // 21: motorcycle.MotorcycleSimulator.GetECUMaps:input_type -> motorcycle.MapsRequest
+	8,  // 22: motorcycle.MotorcycleSimulator.UpdateECUMap:input_type -> motorcycle.MapUpdateRequest
+	9,  // 23: motorcycle.MotorcycleSimulator.SetECUSettings:input_type -> motorcycle.ECUSettings
+	10, // 24: motorcycle.MotorcycleSimulator.SetInjector:input_type -> motorcycle.InjectorRequest
+	11, // 25: motorcycle.MotorcycleSimulator.ReadCodes:input_type -> motorcycle.CodesRequest
+	11, // 26: motorcycle.MotorcycleSimulator.ClearCodes:input_type -> motorcycle.CodesRequest
+	15, // 27: motorcycle.MotorcycleSimulator.InjectFault:input_type -> motorcycle.FaultRequest
+	16, // 28: motorcycle.MotorcycleSimulator.ClearFaults:input_type -> motorcycle.ClearFaultsRequest
+	17, // 29: motorcycle.MotorcycleSimulator.ExportMap:input_type -> motorcycle.MapExportRequest
+	18, // 30: motorcycle.MotorcycleSimulator.ImportMap:input_type -> motorcycle.MapFile
+	19, // 31: motorcycle.MotorcycleSimulator.SaveTune:input_type -> motorcycle.SaveTuneRequest
+	20, // 32: motorcycle.MotorcycleSimulator.LoadTune:input_type -> motorcycle.LoadTuneRequest
+	23, // 33: motorcycle.MotorcycleSimulator.SetPaused:input_type -> motorcycle.PauseRequest
+	24, // 34: motorcycle.MotorcycleSimulator.StepSimulation:input_type -> motorcycle.StepRequest
+	25, // 35: motorcycle.MotorcycleSimulator.SetTimeControl:input_type -> motorcycle.TimeControlRequest
+	29, // 36: motorcycle.MotorcycleSimulator.SeekReplay:input_type -> motorcycle.SeekRequest
+	30, // 37: motorcycle.MotorcycleSimulator.StartRecording:input_type -> motorcycle.RecordingRequest
+	31, // 38: motorcycle.MotorcycleSimulator.StopRecording:input_type -> motorcycle.StopRecordingRequest
+	27, // 39: motorcycle.MotorcycleSimulator.SaveJournal:input_type -> motorcycle.JournalRequest
+	0,  // 40: motorcycle.MotorcycleSimulator.StreamEngine:output_type -> motorcycle.EngineData
+	6,  // 41: motorcycle.MotorcycleSimulator.GetECUMaps:output_type -> motorcycle.ECUMaps
+	22, // 42: motorcycle.MotorcycleSimulator.UpdateECUMap:output_type -> motorcycle.UpdateStatus
+	22, // 43: motorcycle.MotorcycleSimulator.SetECUSettings:output_type -> motorcycle.UpdateStatus
+	22, // 44: motorcycle.MotorcycleSimulator.SetInjector:output_type -> motorcycle.UpdateStatus
+	14, // 45: motorcycle.MotorcycleSimulator.ReadCodes:output_type -> motorcycle.TroubleCodes
+	22, // 46: motorcycle.MotorcycleSimulator.ClearCodes:output_type -> motorcycle.UpdateStatus
+	22, // 47: motorcycle.MotorcycleSimulator.InjectFault:output_type -> motorcycle.UpdateStatus
+	22, // 48: motorcycle.MotorcycleSimulator.ClearFaults:output_type -> motorcycle.UpdateStatus
+	18, // 49: motorcycle.MotorcycleSimulator.ExportMap:output_type -> motorcycle.MapFile
+	22, // 50: motorcycle.MotorcycleSimulator.ImportMap:output_type -> motorcycle.UpdateStatus
+	21, // 51: motorcycle.MotorcycleSimulator.SaveTune:output_type -> motorcycle.TuneStatus
+	21, // 52: motorcycle.MotorcycleSimulator.LoadTune:output_type -> motorcycle.TuneStatus
+	26, // 53: motorcycle.MotorcycleSimulator.SetPaused:output_type -> motorcycle.TimeStatus
+	26, // 54: motorcycle.MotorcycleSimulator.StepSimulation:output_type -> motorcycle.TimeStatus
+	26, // 55: motorcycle.MotorcycleSimulator.SetTimeControl:output_type -> motorcycle.TimeStatus
+	26, // 56: motorcycle.MotorcycleSimulator.SeekReplay:output_type -> motorcycle.TimeStatus
+	32, // 57: motorcycle.MotorcycleSimulator.StartRecording:output_type -> motorcycle.RecordingStatus
+	32, // 58: motorcycle.MotorcycleSimulator.StopRecording:output_type -> motorcycle.RecordingStatus
+	28, // 59: motorcycle.MotorcycleSimulator.SaveJournal:output_type -> motorcycle.JournalStatus
+	40, // [40:60] is the sub-list for method output_type
+	20, // [20:40] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_proto_motorcycle_proto_init() }
@@ -867,7 +3439,7 @@ func file_proto_motorcycle_proto_init() {
 			}
 		}
 		file_proto_motorcycle_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ECUMaps); i {
+			switch v := v.(*Map3D); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -879,7 +3451,7 @@ func file_proto_motorcycle_proto_init() {
 			}
 		}
 		file_proto_motorcycle_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MapsRequest); i {
+			switch v := v.(*Map1D); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -891,7 +3463,7 @@ func file_proto_motorcycle_proto_init() {
 			}
 		}
 		file_proto_motorcycle_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MapUpdateRequest); i {
+			switch v := v.(*ECUMaps); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -903,7 +3475,7 @@ func file_proto_motorcycle_proto_init() {
 			}
 		}
 		file_proto_motorcycle_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ECUSettings); i {
+			switch v := v.(*MapsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -915,6 +3487,174 @@ func file_proto_motorcycle_proto_init() {
 			}
 		}
 		file_proto_motorcycle_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MapUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ECUSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InjectorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FreezeFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TroubleCode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TroubleCodes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FaultRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearFaultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MapExportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MapFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveTuneRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadTuneRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TuneStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateStatus); i {
 			case 0:
 				return &v.state
@@ -926,6 +3666,126 @@ func file_proto_motorcycle_proto_init() {
 				return nil
 			}
 		}
+		file_proto_motorcycle_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeControlRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JournalRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JournalStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeekRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopRecordingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_motorcycle_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordingStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -933,7 +3793,7 @@ func file_proto_motorcycle_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_motorcycle_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -30,6 +30,38 @@ type MotorcycleSimulatorClient interface {
 	UpdateECUMap(ctx context.Context, in *MapUpdateRequest, opts ...grpc.CallOption) (*UpdateStatus, error)
 	// Update ECU settings
 	SetECUSettings(ctx context.Context, in *ECUSettings, opts ...grpc.CallOption) (*UpdateStatus, error)
+	// Fit different injectors, optionally rescaling the ECU for them
+	SetInjector(ctx context.Context, in *InjectorRequest, opts ...grpc.CallOption) (*UpdateStatus, error)
+	// Read the stored trouble codes with their freeze frames
+	ReadCodes(ctx context.Context, in *CodesRequest, opts ...grpc.CallOption) (*TroubleCodes, error)
+	// Erase the stored trouble codes and turn the FI lamp off
+	ClearCodes(ctx context.Context, in *CodesRequest, opts ...grpc.CallOption) (*UpdateStatus, error)
+	// Inject a sensor fault between the engine and the ECU
+	InjectFault(ctx context.Context, in *FaultRequest, opts ...grpc.CallOption) (*UpdateStatus, error)
+	// Remove injected sensor faults
+	ClearFaults(ctx context.Context, in *ClearFaultsRequest, opts ...grpc.CallOption) (*UpdateStatus, error)
+	// Export a map or table as CSV or JSON
+	ExportMap(ctx context.Context, in *MapExportRequest, opts ...grpc.CallOption) (*MapFile, error)
+	// Replace a map or table with one from a CSV or JSON file
+	ImportMap(ctx context.Context, in *MapFile, opts ...grpc.CallOption) (*UpdateStatus, error)
+	// Save every map, table and setting as a tune file on the server
+	SaveTune(ctx context.Context, in *SaveTuneRequest, opts ...grpc.CallOption) (*TuneStatus, error)
+	// Load a tune file, refusing it if it was built for other hardware or map axes
+	LoadTune(ctx context.Context, in *LoadTuneRequest, opts ...grpc.CallOption) (*TuneStatus, error)
+	// Pause or resume the simulation clock
+	SetPaused(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*TimeStatus, error)
+	// Single-step a paused simulation
+	StepSimulation(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*TimeStatus, error)
+	// Change the timestep and time scale
+	SetTimeControl(ctx context.Context, in *TimeControlRequest, opts ...grpc.CallOption) (*TimeStatus, error)
+	// Jump to a point in a replayed log
+	SeekReplay(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*TimeStatus, error)
+	// Record every tick of telemetry to disk
+	StartRecording(ctx context.Context, in *RecordingRequest, opts ...grpc.CallOption) (*RecordingStatus, error)
+	// Finish the current recording
+	StopRecording(ctx context.Context, in *StopRecordingRequest, opts ...grpc.CallOption) (*RecordingStatus, error)
+	// Save every input and ECU change since the session started, for re-simulation
+	SaveJournal(ctx context.Context, in *JournalRequest, opts ...grpc.CallOption) (*JournalStatus, error)
 }
 
 type motorcycleSimulatorClient struct {
@@ -98,6 +130,150 @@ func (c *motorcycleSimulatorClient) SetECUSettings(ctx context.Context, in *ECUS
 	return out, nil
 }
 
+func (c *motorcycleSimulatorClient) SetInjector(ctx context.Context, in *InjectorRequest, opts ...grpc.CallOption) (*UpdateStatus, error) {
+	out := new(UpdateStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/SetInjector", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) ReadCodes(ctx context.Context, in *CodesRequest, opts ...grpc.CallOption) (*TroubleCodes, error) {
+	out := new(TroubleCodes)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/ReadCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) ClearCodes(ctx context.Context, in *CodesRequest, opts ...grpc.CallOption) (*UpdateStatus, error) {
+	out := new(UpdateStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/ClearCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) InjectFault(ctx context.Context, in *FaultRequest, opts ...grpc.CallOption) (*UpdateStatus, error) {
+	out := new(UpdateStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/InjectFault", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) ClearFaults(ctx context.Context, in *ClearFaultsRequest, opts ...grpc.CallOption) (*UpdateStatus, error) {
+	out := new(UpdateStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/ClearFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) ExportMap(ctx context.Context, in *MapExportRequest, opts ...grpc.CallOption) (*MapFile, error) {
+	out := new(MapFile)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/ExportMap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) ImportMap(ctx context.Context, in *MapFile, opts ...grpc.CallOption) (*UpdateStatus, error) {
+	out := new(UpdateStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/ImportMap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) SaveTune(ctx context.Context, in *SaveTuneRequest, opts ...grpc.CallOption) (*TuneStatus, error) {
+	out := new(TuneStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/SaveTune", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) LoadTune(ctx context.Context, in *LoadTuneRequest, opts ...grpc.CallOption) (*TuneStatus, error) {
+	out := new(TuneStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/LoadTune", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) SetPaused(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*TimeStatus, error) {
+	out := new(TimeStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/SetPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) StepSimulation(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*TimeStatus, error) {
+	out := new(TimeStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/StepSimulation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) SetTimeControl(ctx context.Context, in *TimeControlRequest, opts ...grpc.CallOption) (*TimeStatus, error) {
+	out := new(TimeStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/SetTimeControl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) SeekReplay(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*TimeStatus, error) {
+	out := new(TimeStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/SeekReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) StartRecording(ctx context.Context, in *RecordingRequest, opts ...grpc.CallOption) (*RecordingStatus, error) {
+	out := new(RecordingStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/StartRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) StopRecording(ctx context.Context, in *StopRecordingRequest, opts ...grpc.CallOption) (*RecordingStatus, error) {
+	out := new(RecordingStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/StopRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *motorcycleSimulatorClient) SaveJournal(ctx context.Context, in *JournalRequest, opts ...grpc.CallOption) (*JournalStatus, error) {
+	out := new(JournalStatus)
+	err := c.cc.Invoke(ctx, "/motorcycle.MotorcycleSimulator/SaveJournal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MotorcycleSimulatorServer is the server API for MotorcycleSimulator service.
 // All implementations must embed UnimplementedMotorcycleSimulatorServer
 // for forward compatibility
@@ -110,6 +286,38 @@ type MotorcycleSimulatorServer interface {
 	UpdateECUMap(context.Context, *MapUpdateRequest) (*UpdateStatus, error)
 	// Update ECU settings
 	SetECUSettings(context.Context, *ECUSettings) (*UpdateStatus, error)
+	// Fit different injectors, optionally rescaling the ECU for them
+	SetInjector(context.Context, *InjectorRequest) (*UpdateStatus, error)
+	// Read the stored trouble codes with their freeze frames
+	ReadCodes(context.Context, *CodesRequest) (*TroubleCodes, error)
+	// Erase the stored trouble codes and turn the FI lamp off
+	ClearCodes(context.Context, *CodesRequest) (*UpdateStatus, error)
+	// Inject a sensor fault between the engine and the ECU
+	InjectFault(context.Context, *FaultRequest) (*UpdateStatus, error)
+	// Remove injected sensor faults
+	ClearFaults(context.Context, *ClearFaultsRequest) (*UpdateStatus, error)
+	// Export a map or table as CSV or JSON
+	ExportMap(context.Context, *MapExportRequest) (*MapFile, error)
+	// Replace a map or table with one from a CSV or JSON file
+	ImportMap(context.Context, *MapFile) (*UpdateStatus, error)
+	// Save every map, table and setting as a tune file on the server
+	SaveTune(context.Context, *SaveTuneRequest) (*TuneStatus, error)
+	// Load a tune file, refusing it if it was built for other hardware or map axes
+	LoadTune(context.Context, *LoadTuneRequest) (*TuneStatus, error)
+	// Pause or resume the simulation clock
+	SetPaused(context.Context, *PauseRequest) (*TimeStatus, error)
+	// Single-step a paused simulation
+	StepSimulation(context.Context, *StepRequest) (*TimeStatus, error)
+	// Change the timestep and time scale
+	SetTimeControl(context.Context, *TimeControlRequest) (*TimeStatus, error)
+	// Jump to a point in a replayed log
+	SeekReplay(context.Context, *SeekRequest) (*TimeStatus, error)
+	// Record every tick of telemetry to disk
+	StartRecording(context.Context, *RecordingRequest) (*RecordingStatus, error)
+	// Finish the current recording
+	StopRecording(context.Context, *StopRecordingRequest) (*RecordingStatus, error)
+	// Save every input and ECU change since the session started, for re-simulation
+	SaveJournal(context.Context, *JournalRequest) (*JournalStatus, error)
 	mustEmbedUnimplementedMotorcycleSimulatorServer()
 }
 
@@ -129,6 +337,54 @@ func (UnimplementedMotorcycleSimulatorServer) UpdateECUMap(context.Context, *Map
 func (UnimplementedMotorcycleSimulatorServer) SetECUSettings(context.Context, *ECUSettings) (*UpdateStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetECUSettings not implemented")
 }
+func (UnimplementedMotorcycleSimulatorServer) SetInjector(context.Context, *InjectorRequest) (*UpdateStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInjector not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) ReadCodes(context.Context, *CodesRequest) (*TroubleCodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadCodes not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) ClearCodes(context.Context, *CodesRequest) (*UpdateStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCodes not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) InjectFault(context.Context, *FaultRequest) (*UpdateStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectFault not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) ClearFaults(context.Context, *ClearFaultsRequest) (*UpdateStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearFaults not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) ExportMap(context.Context, *MapExportRequest) (*MapFile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMap not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) ImportMap(context.Context, *MapFile) (*UpdateStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportMap not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) SaveTune(context.Context, *SaveTuneRequest) (*TuneStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveTune not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) LoadTune(context.Context, *LoadTuneRequest) (*TuneStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadTune not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) SetPaused(context.Context, *PauseRequest) (*TimeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPaused not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) StepSimulation(context.Context, *StepRequest) (*TimeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StepSimulation not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) SetTimeControl(context.Context, *TimeControlRequest) (*TimeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTimeControl not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) SeekReplay(context.Context, *SeekRequest) (*TimeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeekReplay not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) StartRecording(context.Context, *RecordingRequest) (*RecordingStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartRecording not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) StopRecording(context.Context, *StopRecordingRequest) (*RecordingStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopRecording not implemented")
+}
+func (UnimplementedMotorcycleSimulatorServer) SaveJournal(context.Context, *JournalRequest) (*JournalStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveJournal not implemented")
+}
 func (UnimplementedMotorcycleSimulatorServer) mustEmbedUnimplementedMotorcycleSimulatorServer() {}
 
 // UnsafeMotorcycleSimulatorServer may be embedded to opt out of forward compatibility for this service.
@@ -222,6 +478,294 @@ func _MotorcycleSimulator_SetECUSettings_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MotorcycleSimulator_SetInjector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).SetInjector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/SetInjector",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).SetInjector(ctx, req.(*InjectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_ReadCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).ReadCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/ReadCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).ReadCodes(ctx, req.(*CodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_ClearCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).ClearCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/ClearCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).ClearCodes(ctx, req.(*CodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_InjectFault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).InjectFault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/InjectFault",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).InjectFault(ctx, req.(*FaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_ClearFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).ClearFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/ClearFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).ClearFaults(ctx, req.(*ClearFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_ExportMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).ExportMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/ExportMap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).ExportMap(ctx, req.(*MapExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_ImportMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MapFile)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).ImportMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/ImportMap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).ImportMap(ctx, req.(*MapFile))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_SaveTune_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTuneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).SaveTune(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/SaveTune",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).SaveTune(ctx, req.(*SaveTuneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_LoadTune_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadTuneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).LoadTune(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/LoadTune",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).LoadTune(ctx, req.(*LoadTuneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_SetPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).SetPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/SetPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).SetPaused(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_StepSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).StepSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/StepSimulation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).StepSimulation(ctx, req.(*StepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_SetTimeControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).SetTimeControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/SetTimeControl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).SetTimeControl(ctx, req.(*TimeControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_SeekReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).SeekReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/SeekReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).SeekReplay(ctx, req.(*SeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_StartRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).StartRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/StartRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).StartRecording(ctx, req.(*RecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_StopRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).StopRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/StopRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).StopRecording(ctx, req.(*StopRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MotorcycleSimulator_SaveJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MotorcycleSimulatorServer).SaveJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/motorcycle.MotorcycleSimulator/SaveJournal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MotorcycleSimulatorServer).SaveJournal(ctx, req.(*JournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MotorcycleSimulator_ServiceDesc is the grpc.ServiceDesc for MotorcycleSimulator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,6 +785,70 @@ var MotorcycleSimulator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetECUSettings",
 			Handler:    _MotorcycleSimulator_SetECUSettings_Handler,
 		},
+		{
+			MethodName: "SetInjector",
+			Handler:    _MotorcycleSimulator_SetInjector_Handler,
+		},
+		{
+			MethodName: "ReadCodes",
+			Handler:    _MotorcycleSimulator_ReadCodes_Handler,
+		},
+		{
+			MethodName: "ClearCodes",
+			Handler:    _MotorcycleSimulator_ClearCodes_Handler,
+		},
+		{
+			MethodName: "InjectFault",
+			Handler:    _MotorcycleSimulator_InjectFault_Handler,
+		},
+		{
+			MethodName: "ClearFaults",
+			Handler:    _MotorcycleSimulator_ClearFaults_Handler,
+		},
+		{
+			MethodName: "ExportMap",
+			Handler:    _MotorcycleSimulator_ExportMap_Handler,
+		},
+		{
+			MethodName: "ImportMap",
+			Handler:    _MotorcycleSimulator_ImportMap_Handler,
+		},
+		{
+			MethodName: "SaveTune",
+			Handler:    _MotorcycleSimulator_SaveTune_Handler,
+		},
+		{
+			MethodName: "LoadTune",
+			Handler:    _MotorcycleSimulator_LoadTune_Handler,
+		},
+		{
+			MethodName: "SetPaused",
+			Handler:    _MotorcycleSimulator_SetPaused_Handler,
+		},
+		{
+			MethodName: "StepSimulation",
+			Handler:    _MotorcycleSimulator_StepSimulation_Handler,
+		},
+		{
+			MethodName: "SetTimeControl",
+			Handler:    _MotorcycleSimulator_SetTimeControl_Handler,
+		},
+		{
+			MethodName: "SeekReplay",
+			Handler:    _MotorcycleSimulator_SeekReplay_Handler,
+		},
+		{
+			MethodName: "StartRecording",
+			Handler:    _MotorcycleSimulator_StartRecording_Handler,
+		},
+		{
+			MethodName: "StopRecording",
+			Handler:    _MotorcycleSimulator_StopRecording_Handler,
+		},
+		{
+			MethodName: "SaveJournal",
+			Handler:    _MotorcycleSimulator_SaveJournal_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{